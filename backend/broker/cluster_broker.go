@@ -0,0 +1,321 @@
+package broker
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"strconv"
+	"sync"
+
+	"github.com/rabbitmq/amqp091-go"
+)
+
+// Обмен, через который экземпляры сервера пересылают друг другу события для потоков пользователей.
+// Очередь экземпляра привязана к ключу user.<id> каждого пользователя, у которого на нем открыт поток.
+const clusterExchange = "chat_cluster"
+
+const contentTypeJSON = "application/json"
+
+// Ключ, по которому экземпляры обмениваются состоянием пользователей в сети;
+// к нему привязана очередь каждого экземпляра
+const presenceRoutingKey = "presence"
+
+// ClusterEvent событие для потоков пользователя, открытых на любом экземпляре сервера
+type ClusterEvent struct {
+	ReceiverID       uint64
+	ReceiverUsername string
+	SenderID         uint64 // автор сообщения или сигнала; для остальных событий 0
+	// Собеседник получателя в личном чате, к которому относится событие; 0 для событий группы
+	PeerID   uint64
+	Response []byte // сериализованный ChatResponse
+	// Событие получают только потоки, в которых открыт его чат; без потоков сигнал теряется
+	Signal bool
+	// Событие для очереди получателя, если ни один экземпляр не доставил его в поток
+	Queued *QueuedMessage
+	// Экземпляр, отправивший событие: он уже поставил его в свои потоки и пропускает свою копию
+	Instance string
+	// В потоках отправившего экземпляра открыт чат события; тогда остальные экземпляры
+	// не кладут его в очередь получателя
+	Watched bool
+}
+
+// PresenceEvent состояние пользователей на одном экземпляре сервера
+type PresenceEvent struct {
+	Instance string
+	// Состояния пользователей (manager.PresenceStatus); 0 — не в сети
+	Statuses map[uint64]int
+	// Statuses перечисляет всех пользователей в сети на экземпляре
+	Full bool
+	// Экземпляр просит остальных прислать полные снимки, например после запуска
+	SyncRequest bool
+}
+
+type ClusterBroker interface {
+	// Publish отправляет событие всем экземплярам, на которых у получателя открыты потоки, в том числе этому.
+	// Если таких нет, событие с Queued кладется в очередь chat_queue_<username> получателя.
+	Publish(event ClusterEvent) error
+	// PublishPresence рассылает состояние пользователей всем экземплярам, в том числе этому
+	PublishPresence(event PresenceEvent) error
+	// Bind направляет события пользователя на этот экземпляр
+	Bind(userId uint64) error
+	Unbind(userId uint64) error
+	// Consume передает события пользователей этого экземпляра в handle, а состояния
+	// пользователей в handlePresence, пока соединение открыто. handle возвращает false,
+	// если событие не доставлено ни в один поток. Событие подтверждается только после обработки.
+	Consume(handle func(ClusterEvent) bool, handlePresence func(PresenceEvent)) error
+	// Reconnect открывает новое соединение и заново объявляет обмен и очередь экземпляра.
+	// Привязки пользователей после него нужно восстановить через Bind.
+	Reconnect() error
+	Close()
+}
+
+// Сколько неподтвержденных событий получает потребитель
+const clusterPrefetch = 64
+
+type clusterBroker struct {
+	url     string
+	offline MessageBroker // очереди пользователей не в сети
+
+	mu      sync.RWMutex
+	conn    *amqp091.Connection
+	channel *amqp091.Channel // публикации и привязки; потребитель открывает свой канал
+	queue   string
+}
+
+func NewClusterBroker(url string, offline MessageBroker) (*clusterBroker, error) {
+	cb := &clusterBroker{
+		url:     url,
+		offline: offline,
+	}
+
+	if err := cb.Reconnect(); err != nil {
+		return nil, err
+	}
+
+	return cb, nil
+}
+
+func (cb *clusterBroker) Reconnect() error {
+	conn, err := dial(cb.url)
+	if err != nil {
+		return err
+	}
+
+	ch, err := conn.Channel()
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("failed to create a channel: %v", err)
+	}
+
+	if err := ch.ExchangeDeclare(clusterExchange, "topic", true, false, false, false, nil); err != nil {
+		conn.Close()
+		return fmt.Errorf("failed to declare exchange %s: %v", clusterExchange, err)
+	}
+
+	// Очередь экземпляра удаляется вместе с соединением, а с ней и привязки его пользователей
+	queue, err := ch.QueueDeclare("", false, true, true, false, nil)
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("failed to declare cluster queue: %v", err)
+	}
+
+	if err := ch.QueueBind(queue.Name, presenceRoutingKey, clusterExchange, false, nil); err != nil {
+		conn.Close()
+		return fmt.Errorf("failed to bind cluster queue to presence updates: %v", err)
+	}
+
+	go cb.handleReturns(ch.NotifyReturn(make(chan amqp091.Return, 1)))
+
+	cb.mu.Lock()
+	previous := cb.conn
+	cb.conn = conn
+	cb.channel = ch
+	cb.queue = queue.Name
+	cb.mu.Unlock()
+
+	if previous != nil {
+		previous.Close()
+	}
+
+	log.Printf("Cluster queue %s declared", queue.Name)
+	return nil
+}
+
+// current возвращает канал для публикаций и привязок и очередь текущего соединения
+func (cb *clusterBroker) current() (*amqp091.Channel, string) {
+	cb.mu.RLock()
+	defer cb.mu.RUnlock()
+
+	return cb.channel, cb.queue
+}
+
+func userRoutingKey(userId uint64) string {
+	return "user." + strconv.FormatUint(userId, 10)
+}
+
+func (cb *clusterBroker) Publish(event ClusterEvent) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal cluster event: %v", err)
+	}
+
+	channel, _ := cb.current()
+
+	// mandatory: если ни одна очередь не привязана к ключу получателя, RabbitMQ вернет событие в handleReturns
+	err = channel.Publish(
+		clusterExchange,
+		userRoutingKey(event.ReceiverID),
+		true,
+		false,
+		amqp091.Publishing{
+			ContentType: contentTypeJSON,
+			Body:        body,
+		},
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to publish cluster event: %v", err)
+	}
+
+	return nil
+}
+
+func (cb *clusterBroker) PublishPresence(event PresenceEvent) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal presence event: %v", err)
+	}
+
+	channel, _ := cb.current()
+	err = channel.Publish(
+		clusterExchange,
+		presenceRoutingKey,
+		false,
+		false,
+		amqp091.Publishing{
+			ContentType: contentTypeJSON,
+			Body:        body,
+		},
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to publish presence event: %v", err)
+	}
+
+	return nil
+}
+
+func (cb *clusterBroker) Bind(userId uint64) error {
+	channel, queue := cb.current()
+	if err := channel.QueueBind(queue, userRoutingKey(userId), clusterExchange, false, nil); err != nil {
+		return fmt.Errorf("failed to bind user %d to cluster queue: %v", userId, err)
+	}
+
+	return nil
+}
+
+func (cb *clusterBroker) Unbind(userId uint64) error {
+	channel, queue := cb.current()
+	if err := channel.QueueUnbind(queue, userRoutingKey(userId), clusterExchange, nil); err != nil {
+		return fmt.Errorf("failed to unbind user %d from cluster queue: %v", userId, err)
+	}
+
+	return nil
+}
+
+func (cb *clusterBroker) Consume(handle func(ClusterEvent) bool, handlePresence func(PresenceEvent)) error {
+	cb.mu.RLock()
+	conn, queue := cb.conn, cb.queue
+	cb.mu.RUnlock()
+
+	// Отдельный канал: ошибка потребителя не закрывает канал публикаций и привязок
+	ch, err := conn.Channel()
+	if err != nil {
+		return fmt.Errorf("failed to create a consumer channel: %v", err)
+	}
+	defer ch.Close()
+
+	if err := ch.Qos(clusterPrefetch, 0, false); err != nil {
+		return fmt.Errorf("failed to set cluster consumer prefetch: %v", err)
+	}
+
+	msgs, err := ch.Consume(
+		queue,
+		"",
+		false,
+		true,
+		false,
+		false,
+		nil,
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to consume cluster events: %v", err)
+	}
+
+	for msg := range msgs {
+		if msg.RoutingKey == presenceRoutingKey {
+			var event PresenceEvent
+			if err := json.Unmarshal(msg.Body, &event); err != nil {
+				log.Printf("Failed to unmarshal presence event: %v", err)
+				msg.Reject(false)
+				continue
+			}
+
+			handlePresence(event)
+			if err := msg.Ack(false); err != nil {
+				log.Printf("Failed to ack presence event from %s: %v", event.Instance, err)
+			}
+			continue
+		}
+
+		var event ClusterEvent
+		if err := json.Unmarshal(msg.Body, &event); err != nil {
+			log.Printf("Failed to unmarshal cluster event: %v", err)
+			msg.Reject(false)
+			continue
+		}
+
+		// Пользователь мог закрыть последний поток, пока событие шло через обмен
+		if !handle(event) {
+			cb.queueOffline(event)
+		}
+
+		if err := msg.Ack(false); err != nil {
+			log.Printf("Failed to ack cluster event for user %d: %v", event.ReceiverID, err)
+		}
+	}
+
+	return fmt.Errorf("cluster queue %s closed", queue)
+}
+
+// handleReturns кладет в очереди пользователей события, которые не удалось направить ни одному экземпляру
+func (cb *clusterBroker) handleReturns(returns <-chan amqp091.Return) {
+	for ret := range returns {
+		var event ClusterEvent
+		if err := json.Unmarshal(ret.Body, &event); err != nil {
+			log.Printf("Failed to unmarshal returned cluster event: %v", err)
+			continue
+		}
+
+		cb.queueOffline(event)
+	}
+}
+
+func (cb *clusterBroker) queueOffline(event ClusterEvent) {
+	if event.Queued == nil || event.Watched {
+		return
+	}
+
+	if err := cb.offline.PublishEvent([]string{event.ReceiverUsername}, *event.Queued); err != nil {
+		log.Printf("Failed to publish event for user %d to queue: %v", event.ReceiverID, err)
+	}
+}
+
+func (cb *clusterBroker) Close() {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	cb.channel.Close()
+	cb.conn.Close()
+}
@@ -1,19 +1,70 @@
 package broker
 
 import (
+	"errors"
 	"fmt"
 	"log"
+	"strconv"
 	"time"
 
 	"github.com/rabbitmq/amqp091-go"
 )
 
+// Тело с конвертом сообщения отличается от текстового типом содержимого
+const (
+	contentTypeText     = "text/plain"
+	contentTypeEnvelope = "application/x-protobuf"
+)
+
+// Типы событий в очереди пользователя
+const (
+	EventMessage = "message"
+	EventEdited  = "edited"
+	EventDeleted = "deleted"
+
+	// Отметки о доставке и прочтении; отправитель события — получатель сообщения
+	EventDelivered = "delivered"
+	EventRead      = "read"
+
+	// Реакции; эмодзи передается в теле, отправитель события — поставивший реакцию
+	EventReactionAdded   = "reaction_added"
+	EventReactionRemoved = "reaction_removed"
+
+	// Закрепление сообщений; отправитель события — закрепивший или открепивший
+	EventPinned   = "pinned"
+	EventUnpinned = "unpinned"
+
+	// Отложенное сообщение отправлено; событие получает только его отправитель
+	EventReleased = "released"
+)
+
+// ErrSkipMessage возвращает обработчик ProcessMessages, чтобы оставить сообщение в очереди,
+// например событие чата, который не открыт в этом потоке
+var ErrSkipMessage = errors.New("message is left in the queue")
+
+// QueuedMessage представляет сообщение или событие, полученное из очереди пользователя
+type QueuedMessage struct {
+	SenderUsername string
+	GroupID        uint64     // 0 для личных сообщений
+	MessageID      uint64     // 0 для сообщений, поставленных в очередь до появления ID
+	Event          string     // один из Event*
+	Content        string     // для событий без конверта, например эмодзи реакции
+	Envelope       []byte     // сериализованный MessageEnvelope сообщения или правки
+	Timestamp      time.Time  // время отправки, а для правок и удалений — время события
+	ReplyToID      uint64     // сообщение, на которое отвечают; 0, если это не ответ
+	ThreadRootID   uint64     // первое сообщение ветки ответов
+	ScheduledID    uint64     // отложенное сообщение, из которого отправлено это
+	ExpiresAt      *time.Time // исчезающее сообщение удаляется из очереди в это время
+}
+
 type MessageBroker interface {
-	PublishMessage(senderUsername, receiverUsername, content string, timestamp time.Time) error
+	PublishMessage(senderUsername, receiverUsername string, messageID uint64, content string, timestamp time.Time) error
+	PublishEvent(receiverUsernames []string, message QueuedMessage) error
 	SubscribeMessages(queueName string, handleMessage func(string, time.Time) error) error
 	GetMessagesFromQueueWithoutDeleting(queueName string, handleMessage func(string, time.Time) error) error
-	ProcessMessages(queueName string, handleMessage func(string, string, time.Time) error) error
+	ProcessMessages(queueName string, handleMessage func(QueuedMessage) error) error
 	CheckMessages(queue string) (bool, error)
+	DeleteQueue(queueName string) error
 	Close()
 }
 
@@ -23,6 +74,25 @@ type messageBroker struct {
 }
 
 func NewMessageBroker(url string) (*messageBroker, error) {
+	conn, err := dial(url)
+	if err != nil {
+		return nil, err
+	}
+
+	ch, err := conn.Channel()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create a channel: %v", err)
+	}
+
+	log.Print("RabbitMQ connection successfully established")
+	return &messageBroker{
+		conn:    conn,
+		channel: ch,
+	}, nil
+}
+
+// dial подключается к RabbitMQ, повторяя попытки, пока он запускается
+func dial(url string) (*amqp091.Connection, error) {
 	maxRetries := 10
 	delay := 3 * time.Second
 	var conn *amqp091.Connection
@@ -31,29 +101,71 @@ func NewMessageBroker(url string) (*messageBroker, error) {
 	for i := 0; i < maxRetries; i++ {
 		conn, err = amqp091.Dial(url)
 		if err == nil {
-			break
+			return conn, nil
 		}
 		// log.Printf("Failed to connect to RabbitMQ (attempt %d/%d): %v", i+1, maxRetries, err)
 		time.Sleep(delay)
 	}
 
-	if err != nil {
-		return nil, fmt.Errorf("failed to connect to RabbitMQ after %d attempts: %v", maxRetries, err)
+	return nil, fmt.Errorf("failed to connect to RabbitMQ after %d attempts: %v", maxRetries, err)
+}
+
+func (mb *messageBroker) PublishMessage(senderUsername, receiverUsername string, messageID uint64, content string, timestamp time.Time) error {
+	return mb.PublishEvent([]string{receiverUsername}, QueuedMessage{
+		SenderUsername: senderUsername,
+		MessageID:      messageID,
+		Event:          EventMessage,
+		Content:        content,
+		Timestamp:      timestamp,
+	})
+}
+
+// PublishEvent кладет сообщение или событие (правку, удаление) в очередь каждого получателя
+func (mb *messageBroker) PublishEvent(receiverUsernames []string, message QueuedMessage) error {
+	headers := amqp091.Table{
+		"sender":     message.SenderUsername,
+		"message_id": int64(message.MessageID),
+		"event":      message.Event,
+	}
+	// Заголовок group_id есть только у сообщений групповых чатов
+	if message.GroupID != 0 {
+		headers["group_id"] = int64(message.GroupID)
+	}
+	// Заголовки reply_to_id и thread_root_id есть только у ответов
+	if message.ReplyToID != 0 {
+		headers["reply_to_id"] = int64(message.ReplyToID)
+		headers["thread_root_id"] = int64(message.ThreadRootID)
+	}
+	if message.ScheduledID != 0 {
+		headers["scheduled_id"] = int64(message.ScheduledID)
 	}
 
-	ch, err := conn.Channel()
-	if err != nil {
-		return nil, fmt.Errorf("failed to create a channel: %v", err)
+	// Исчезающее сообщение RabbitMQ удалит сам, если получатель не появится в сети до срока
+	var expiration string
+	if message.ExpiresAt != nil {
+		headers["expires_at"] = message.ExpiresAt.Unix()
+		ttl := time.Until(*message.ExpiresAt).Milliseconds()
+		if ttl <= 0 {
+			return nil
+		}
+		expiration = strconv.FormatInt(ttl, 10)
 	}
 
-	log.Print("RabbitMQ connection successfully established")
-	return &messageBroker{
-		conn:    conn,
-		channel: ch,
-	}, nil
+	contentType, body := contentTypeText, []byte(message.Content)
+	if message.Envelope != nil {
+		contentType, body = contentTypeEnvelope, message.Envelope
+	}
+
+	for _, receiverUsername := range receiverUsernames {
+		if err := mb.publish(receiverUsername, contentType, body, message.Timestamp, expiration, headers); err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
 
-func (mb *messageBroker) PublishMessage(senderUsername, receiverUsername, content string, timestamp time.Time) error {
+func (mb *messageBroker) publish(receiverUsername, contentType string, body []byte, timestamp time.Time, expiration string, headers amqp091.Table) error {
 	queueName := fmt.Sprintf("chat_queue_%s", receiverUsername)
 	_, err := mb.channel.QueueDeclare(
 		queueName,
@@ -68,20 +180,17 @@ func (mb *messageBroker) PublishMessage(senderUsername, receiverUsername, conten
 		return fmt.Errorf("failed to declare queue: %v", err)
 	}
 
-	messageBody := content
-
 	err = mb.channel.Publish(
 		"",
 		queueName,
 		false,
 		false,
 		amqp091.Publishing{
-			ContentType: "text/plain",
-			Body:        []byte(messageBody),
+			ContentType: contentType,
+			Body:        body,
 			Timestamp:   timestamp,
-			Headers: amqp091.Table{
-				"sender": senderUsername,
-			},
+			Expiration:  expiration,
+			Headers:     headers,
 		},
 	)
 
@@ -183,7 +292,7 @@ func (mb *messageBroker) CheckMessages(queueName string) (bool, error) {
 	return queue.Messages > 0, nil
 }
 
-func (mb *messageBroker) ProcessMessages(queueName string, handleMessage func(string, string, time.Time) error) error {
+func (mb *messageBroker) ProcessMessages(queueName string, handleMessage func(QueuedMessage) error) error {
 	log.Printf("Processing offline message started")
 
 	_, err := mb.channel.QueueDeclare(
@@ -202,9 +311,10 @@ func (mb *messageBroker) ProcessMessages(queueName string, handleMessage func(st
 
 	log.Printf("Queue %s declared", queueName)
 
+	consumer := fmt.Sprintf("offline-%s-%d", queueName, time.Now().UnixNano())
 	deliveryChan, err := mb.channel.Consume(
 		queueName,
-		"",
+		consumer,
 		false,
 		false,
 		false,
@@ -219,6 +329,13 @@ func (mb *messageBroker) ProcessMessages(queueName string, handleMessage func(st
 
 	log.Printf("Messages from queue %s consumed successfully", queueName)
 
+	// Пропущенные сообщения остаются неподтвержденными, пока очередь не разобрана,
+	// иначе брокер сразу доставит их снова
+	var skipped []amqp091.Delivery
+	defer func() {
+		mb.requeueSkipped(consumer, deliveryChan, skipped)
+	}()
+
 	done := make(chan bool)
 	go mb.queueMonitor(queueName, done)
 	log.Printf("Starting processing online messages")
@@ -231,7 +348,6 @@ func (mb *messageBroker) ProcessMessages(queueName string, handleMessage func(st
 				return nil
 			}
 
-			messageBody := string(msg.Body)
 			timestamp := msg.Timestamp
 			sender, ok := msg.Headers["sender"].(string)
 			if !ok {
@@ -240,7 +356,58 @@ func (mb *messageBroker) ProcessMessages(queueName string, handleMessage func(st
 				continue
 			}
 
-			err := handleMessage(sender, messageBody, timestamp)
+			// Заголовки group_id, message_id и event есть не у всех сообщений:
+			// group_id только у групповых, а message_id и event нет у старых сообщений в очереди
+			var groupID, messageID, replyToID, threadRootID, scheduledID uint64
+			if value, ok := msg.Headers["group_id"].(int64); ok {
+				groupID = uint64(value)
+			}
+			if value, ok := msg.Headers["message_id"].(int64); ok {
+				messageID = uint64(value)
+			}
+			if value, ok := msg.Headers["reply_to_id"].(int64); ok {
+				replyToID = uint64(value)
+			}
+			if value, ok := msg.Headers["thread_root_id"].(int64); ok {
+				threadRootID = uint64(value)
+			}
+			if value, ok := msg.Headers["scheduled_id"].(int64); ok {
+				scheduledID = uint64(value)
+			}
+			var expiresAt *time.Time
+			if value, ok := msg.Headers["expires_at"].(int64); ok {
+				expires := time.Unix(value, 0)
+				expiresAt = &expires
+			}
+			event, ok := msg.Headers["event"].(string)
+			if !ok {
+				event = EventMessage
+			}
+
+			queued := QueuedMessage{
+				SenderUsername: sender,
+				GroupID:        groupID,
+				MessageID:      messageID,
+				Event:          event,
+				Timestamp:      timestamp,
+				ReplyToID:      replyToID,
+				ThreadRootID:   threadRootID,
+				ScheduledID:    scheduledID,
+				ExpiresAt:      expiresAt,
+			}
+			// Сообщения, поставленные в очередь до появления конвертов, приходят текстом
+			if msg.ContentType == contentTypeEnvelope {
+				queued.Envelope = msg.Body
+			} else {
+				queued.Content = string(msg.Body)
+			}
+
+			err := handleMessage(queued)
+
+			if errors.Is(err, ErrSkipMessage) {
+				skipped = append(skipped, msg)
+				continue
+			}
 
 			if err != nil {
 				log.Printf("Error processing messages from sender %s: %v", sender, err)
@@ -260,6 +427,25 @@ func (mb *messageBroker) ProcessMessages(queueName string, handleMessage func(st
 	}
 }
 
+// requeueSkipped отменяет подписку и возвращает в очередь пропущенные сообщения и сообщения,
+// которые брокер успел доставить после того, как очередь опустела
+func (mb *messageBroker) requeueSkipped(consumer string, deliveryChan <-chan amqp091.Delivery, skipped []amqp091.Delivery) {
+	if err := mb.channel.Cancel(consumer, false); err != nil {
+		log.Printf("Failed to cancel consumer %s: %v", consumer, err)
+		return
+	}
+
+	for msg := range deliveryChan {
+		skipped = append(skipped, msg)
+	}
+
+	for _, msg := range skipped {
+		if err := msg.Nack(false, true); err != nil {
+			log.Printf("Failed to return message to the queue: %v", err)
+		}
+	}
+}
+
 func (mb *messageBroker) queueMonitor(queueName string, done chan bool) {
 	for {
 		queue, err := mb.channel.QueueDeclare(
@@ -288,6 +474,15 @@ func (mb *messageBroker) queueMonitor(queueName string, done chan bool) {
 	}
 }
 
+// DeleteQueue удаляет очередь вместе с недоставленными сообщениями
+func (mb *messageBroker) DeleteQueue(queueName string) error {
+	if _, err := mb.channel.QueueDelete(queueName, false, false, false); err != nil {
+		return fmt.Errorf("failed to delete queue %s: %v", queueName, err)
+	}
+
+	return nil
+}
+
 func (mb *messageBroker) Close() {
 	mb.channel.Close()
 	mb.conn.Close()
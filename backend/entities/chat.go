@@ -2,13 +2,15 @@ package entities
 
 import "time"
 
-// Chat представляет информацию о чате между двумя пользователями
+// Chat представляет информацию о чате между двумя пользователями или о групповом чате.
+// У группы нет пары участников: FirstUserID и SecondUserID равны нулю.
 type Chat struct {
 	ID                  uint64    `db:"id"`
 	FirstUserID         uint64    `db:"first_user_id"`
 	SecondUserID        uint64    `db:"second_user_id"`
 	FirstUsername       string    `db:"first_username"`
 	SecondUsername      string    `db:"second_username"`
+	IsGroup             bool      `db:"is_group"`
 	EncryptionKey       []byte    `db:"encryption_key"`       // Общий ключ шифрования, полученный по протоколу Диффи-Хеллмана
 	EncryptionAlgorithm string    `db:"encryption_algorithm"` // Алгоритм шифрования (например, AES)
 	EncryptionMode      string    `db:"encryption_mode"`      // Режим шифрования (например, GCM, CBC)
@@ -23,4 +25,8 @@ type ChatInfoDTO struct {
 	EncryptionAlgorithm *string `db:"encryption_algorithm"`
 	EncryptionMode      *string `db:"encryption_mode"`
 	EncryptionPadding   *string `db:"encryption_padding"`
+	DisplayName         string  `db:"display_name"`        // Отображаемое имя собеседника
+	AvatarFileID        *string `db:"avatar_file_id"`      // Аватар собеседника
+	UnreadCount         int     `db:"unread_count"`        // Непрочитанные сообщения
+	MessageTTLSeconds   *int    `db:"message_ttl_seconds"` // Срок жизни сообщений; nil — не исчезают
 }
@@ -11,7 +11,7 @@ type File struct {
 	Size       int64     `db:"size"`
 	Path       string    `db:"path"`
 	UploadedBy uint64    `db:"uploaded_by"`
-	ChatID     uint64    `db:"chat_id"`
+	ChatID     *uint64   `db:"chat_id"` // nil для личных файлов (аватаров)
 	Checksum   string    `db:"checksum"`
 	CreatedAt  time.Time `db:"created_at"`
 	DeletedAt  time.Time `db:"deleted_at,omitempty"`
@@ -28,7 +28,7 @@ type FileUpload struct {
 	ChunkSize      int       `db:"chunk_size"`
 	TempPath       string    `db:"temp_path"`
 	UserID         uint64    `db:"user_id"`
-	ChatID         uint64    `db:"chat_id"`
+	ChatID         *uint64   `db:"chat_id"`
 	Status         string    `db:"status"`
 	CreatedAt      time.Time `db:"created_at"`
 	UpdatedAt      time.Time `db:"updated_at"`
@@ -0,0 +1,32 @@
+package entities
+
+import "time"
+
+// Роли участников группового чата
+const (
+	RoleOwner  = "owner"
+	RoleAdmin  = "admin"
+	RoleMember = "member"
+)
+
+// Group представляет групповой чат
+type Group struct {
+	ID                  uint64    `db:"id"`
+	Title               string    `db:"title"`
+	EncryptionAlgorithm *string   `db:"encryption_algorithm"`
+	EncryptionMode      *string   `db:"encryption_mode"`
+	EncryptionPadding   *string   `db:"encryption_padding"`
+	Role                string    `db:"role"`                // роль текущего пользователя, если группа получена для него
+	UnreadCount         int       `db:"unread_count"`        // непрочитанные сообщения текущего пользователя
+	MessageTTLSeconds   *int      `db:"message_ttl_seconds"` // срок жизни сообщений; nil — не исчезают
+	CreatedAt           time.Time `db:"created_at"`
+}
+
+// GroupMember представляет участника группового чата
+type GroupMember struct {
+	ChatID   uint64    `db:"chat_id"`
+	UserID   uint64    `db:"user_id"`
+	Username string    `db:"username"`
+	Role     string    `db:"role"`
+	JoinedAt time.Time `db:"joined_at"`
+}
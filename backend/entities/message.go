@@ -3,10 +3,102 @@ package entities
 import "time"
 
 type Message struct {
-	ID         uint64    `json:"id" db:"id"`
-	ChatID     uint64    `json:"chat_id" db:"chat_id"`
-	SenderId   uint64    `json:"sender_id" db:"sender_id"`
-	ReceiverId uint64    `json:"receiver_id" db:"receiver_id"`
-	Content    string    `json:"content" db:"content"`
-	Timestamp  time.Time `json:"timestamp" db:"timestamp"`
+	ID             uint64     `json:"id" db:"id"`
+	ChatID         uint64     `json:"chat_id" db:"chat_id"`
+	SenderId       uint64     `json:"sender_id" db:"sender_id"`
+	SenderUsername string     `json:"sender_username,omitempty" db:"sender_username"` // заполняется только при чтении истории
+	ReceiverId     uint64     `json:"receiver_id" db:"receiver_id"`
+	Timestamp      time.Time  `json:"timestamp" db:"timestamp"`
+	EditedAt       *time.Time `json:"edited_at,omitempty" db:"edited_at"`
+	DeletedAt      *time.Time `json:"deleted_at,omitempty" db:"deleted_at"`
+	ReplyToID      uint64     `json:"reply_to_id,omitempty" db:"reply_to_id"`       // 0, если сообщение не ответ
+	ThreadRootID   uint64     `json:"thread_root_id,omitempty" db:"thread_root_id"` // первое сообщение ветки; 0 вне ветки
+	ReplyCount     int        `json:"-" db:"reply_count"`                           // заполняется только при чтении истории
+	Status         int        `json:"-" db:"status"`                                // заполняется только при чтении истории
+	ExpiresAt      *time.Time `json:"expires_at,omitempty" db:"expires_at"`         // время удаления исчезающего сообщения
+	TTLSeconds     int        `json:"-" db:"ttl_seconds"`                           // срок жизни, заданный отправителем; 0 — по настройке чата
+	ScheduledID    uint64     `json:"-" db:"scheduled_id"`                          // отложенное сообщение, из которого отправлено это
+
+	Envelope
+}
+
+// Тип сообщения
+const (
+	KindText = 0
+	KindFile = 1 // сообщение с вложениями; тело — подпись к ним
+)
+
+// Envelope содержимое сообщения. Тело шифрует клиент; сервер хранит его вместе с IV и параметрами шифра как есть.
+type Envelope struct {
+	Kind            int          `json:"kind" db:"kind"`
+	Payload         []byte       `json:"payload" db:"payload"`
+	IV              []byte       `json:"iv,omitempty" db:"iv"`
+	CipherAlgorithm string       `json:"cipher_algorithm,omitempty" db:"cipher_algorithm"` // пусто — параметры шифрования чата
+	CipherMode      string       `json:"cipher_mode,omitempty" db:"cipher_mode"`
+	CipherPadding   string       `json:"cipher_padding,omitempty" db:"cipher_padding"`
+	Attachments     []Attachment `json:"attachments,omitempty" db:"-"` // файлы удаляются вместе с исчезающим сообщением
+}
+
+// Attachment файл, приложенный к сообщению
+type Attachment struct {
+	MessageID uint64 `json:"-" db:"message_id"`
+	FileID    string `json:"file_id" db:"file_id"`
+	FileName  string `json:"file_name" db:"file_name"`
+	FileSize  int64  `json:"file_size" db:"size"`
+	MimeType  string `json:"mime_type" db:"mime_type"`
+}
+
+// Состояние сообщения; для группы — наименьшее среди всех получателей
+const (
+	MessageSent      = 0
+	MessageDelivered = 1
+	MessageRead      = 2
+)
+
+// ReadReceipt описывает прочитанные сообщения одного отправителя: все до MessageID включительно
+type ReadReceipt struct {
+	SenderID  uint64 `db:"sender_id"`
+	MessageID uint64 `db:"message_id"`
+}
+
+// HistoryQuery описывает запрос страницы истории чата.
+// Без курсоров возвращаются последние сообщения; BeforeID листает назад, AfterID — вперед.
+type HistoryQuery struct {
+	ChatID       uint64
+	ThreadRootID uint64     // только ответы в ветке этого сообщения; 0 — весь чат
+	BeforeID     uint64     // 0 — без ограничения
+	AfterID      uint64     // 0 — без ограничения
+	Since        *time.Time // включительно
+	Until        *time.Time // не включительно
+	Limit        int
+}
+
+// MessageEdit представляет предыдущую версию отредактированного сообщения
+type MessageEdit struct {
+	MessageID uint64    `json:"message_id" db:"message_id"`
+	EditedAt  time.Time `json:"edited_at" db:"edited_at"`
+
+	Envelope // тип и вложения не меняются при правке и не заполняются
+}
+
+// ReactionCount сводка реакций на сообщение по одному эмодзи
+type ReactionCount struct {
+	MessageID uint64 `db:"message_id"`
+	Emoji     string `db:"emoji"`
+	Count     int    `db:"count"`
+	Reacted   bool   `db:"reacted"` // среди поставивших есть запросивший пользователь
+}
+
+// PinnedMessage закрепленное сообщение чата
+type PinnedMessage struct {
+	Message
+	PinnedBy string    `db:"pinned_by"` // пусто, если закрепивший удалил аккаунт
+	PinnedAt time.Time `db:"pinned_at"`
+}
+
+// StarredMessage сообщение, добавленное пользователем в избранное
+type StarredMessage struct {
+	Message
+	PeerUsername string    `db:"peer_username"` // собеседник в личном чате; пусто для группы
+	StarredAt    time.Time `db:"starred_at"`
 }
@@ -0,0 +1,15 @@
+package entities
+
+import "time"
+
+// UserProfile представляет публичный профиль пользователя
+type UserProfile struct {
+	UserID           uint64     `db:"user_id"`
+	Username         string     `db:"username"`
+	DisplayName      string     `db:"display_name"`
+	Bio              string     `db:"bio"`
+	AvatarFileID     *string    `db:"avatar_file_id"`
+	HiddenFromSearch bool       `db:"hidden_from_search"` // найти можно только по точному имени
+	HideLastSeen     bool       `db:"hide_last_seen"`     // время посещения видно только самому пользователю
+	LastSeenAt       *time.Time `db:"last_seen_at"`       // последняя активность среди сессий пользователя
+}
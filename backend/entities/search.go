@@ -0,0 +1,25 @@
+package entities
+
+import "time"
+
+// SearchQuery описывает поиск сообщений по метаданным и слепому индексу пользователя.
+// Пустые поля не ограничивают поиск; результаты идут от новых сообщений к старым.
+type SearchQuery struct {
+	UserID         uint64 // кто ищет: поиск идет только по его чатам и его токенам
+	ChatID         uint64 // 0 — во всех чатах
+	SenderID       uint64
+	Since          *time.Time // включительно
+	Until          *time.Time // не включительно
+	HasAttachment  bool
+	FileName       string   // подстрока имени файла без учета регистра
+	MimeTypePrefix string   // например, "image/"
+	Tokens         [][]byte // сообщение должно содержать все токены
+	BeforeID       uint64
+	Limit          int
+}
+
+// SearchResult найденное сообщение
+type SearchResult struct {
+	Message
+	PeerUsername string `db:"peer_username"` // собеседник в личном чате; пусто для группы
+}
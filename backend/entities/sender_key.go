@@ -0,0 +1,33 @@
+package entities
+
+import "time"
+
+// SenderKeyEpoch представляет эпоху ключа отправителя в группе
+type SenderKeyEpoch struct {
+	ChatID            uint64    `db:"chat_id"`
+	SenderID          uint64    `db:"sender_id"`
+	Epoch             uint32    `db:"epoch"`
+	MembershipVersion int       `db:"membership_version"` // версия состава группы на момент выпуска ключа
+	Stale             bool      `db:"stale"`              // состав группы изменился после выпуска ключа
+	CreatedAt         time.Time `db:"created_at"`
+}
+
+// SenderKeyBundle представляет ключ отправителя, зашифрованный для одного получателя
+type SenderKeyBundle struct {
+	ChatID         uint64     `db:"chat_id"`
+	SenderID       uint64     `db:"sender_id"`
+	SenderUsername string     `db:"sender_username"`
+	Epoch          uint32     `db:"epoch"`
+	RecipientID    uint64     `db:"recipient_id"`
+	WrappedKey     []byte     `db:"wrapped_key"`
+	CreatedAt      time.Time  `db:"created_at"`
+	DeliveredAt    *time.Time `db:"delivered_at"`
+}
+
+// SenderKeyRecipient представляет состояние доставки ключа отправителя одному участнику группы
+type SenderKeyRecipient struct {
+	UserID    uint64 `db:"user_id"`
+	Username  string `db:"username"`
+	HasKey    bool   `db:"has_key"`   // отправитель передал ключ для этого участника
+	Delivered bool   `db:"delivered"` // участник забрал ключ
+}
@@ -0,0 +1,17 @@
+package entities
+
+import "time"
+
+// Session представляет сессию входа пользователя с refresh-токеном
+type Session struct {
+	ID               uint64     `db:"id"`
+	SessionID        string     `db:"session_id"`
+	UserID           uint64     `db:"user_id"`
+	RefreshTokenHash string     `db:"refresh_token_hash"`
+	DeviceLabel      string     `db:"device_label"`
+	IPAddress        string     `db:"ip_address"`
+	ExpiresAt        time.Time  `db:"expires_at"`
+	RevokedAt        *time.Time `db:"revoked_at"`
+	CreatedAt        time.Time  `db:"created_at"`
+	LastSeenAt       time.Time  `db:"last_seen_at"`
+}
@@ -0,0 +1,20 @@
+package entities
+
+import "time"
+
+// TOTPSettings представляет настройки двухфакторной аутентификации пользователя
+type TOTPSettings struct {
+	UserID       uint64    `db:"user_id"`
+	Secret       string    `db:"secret"`
+	Enabled      bool      `db:"enabled"`
+	LastUsedStep int64     `db:"last_used_step"`
+	CreatedAt    time.Time `db:"created_at"`
+}
+
+// RecoveryCode представляет хешированный одноразовый код восстановления
+type RecoveryCode struct {
+	ID       uint64     `db:"id"`
+	UserID   uint64     `db:"user_id"`
+	CodeHash string     `db:"code_hash"`
+	UsedAt   *time.Time `db:"used_at"`
+}
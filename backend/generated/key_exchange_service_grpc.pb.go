@@ -22,6 +22,9 @@ const (
 	KeyExchangeService_InitKeyExchange_FullMethodName      = "/messenger.KeyExchangeService/InitKeyExchange"
 	KeyExchangeService_CompleteKeyExchange_FullMethodName  = "/messenger.KeyExchangeService/CompleteKeyExchange"
 	KeyExchangeService_GetKeyExchangeParams_FullMethodName = "/messenger.KeyExchangeService/GetKeyExchangeParams"
+	KeyExchangeService_DistributeSenderKey_FullMethodName  = "/messenger.KeyExchangeService/DistributeSenderKey"
+	KeyExchangeService_GetSenderKeyStatus_FullMethodName   = "/messenger.KeyExchangeService/GetSenderKeyStatus"
+	KeyExchangeService_GetSenderKeys_FullMethodName        = "/messenger.KeyExchangeService/GetSenderKeys"
 )
 
 // KeyExchangeServiceClient is the client API for KeyExchangeService service.
@@ -47,6 +50,19 @@ type KeyExchangeServiceClient interface {
 	// GetKeyExchangeParams получает параметры обмена ключами, сохраненные на сервере
 	// для конкретного собеседника.
 	GetKeyExchangeParams(ctx context.Context, in *GetKeyExchangeParamsRequest, opts ...grpc.CallOption) (*GetKeyExchangeParamsResponse, error)
+	// DistributeSenderKey сохраняет ключ отправителя для группы, зашифрованный
+	// отдельно для каждого получателя на их общем секрете Диффи-Хеллмана.
+	// Сервер хранит обертки как непрозрачные данные и не может их расшифровать.
+	// Эпоха должна быть текущей (дослать ключ недостающим участникам)
+	// или следующей за ней (ротация ключа).
+	DistributeSenderKey(ctx context.Context, in *DistributeSenderKeyRequest, opts ...grpc.CallOption) (*DistributeSenderKeyResponse, error)
+	// GetSenderKeyStatus возвращает текущую эпоху ключа отправителя, признак
+	// необходимости ротации после изменения состава группы и участников,
+	// которым ключ текущей эпохи еще не доставлен.
+	GetSenderKeyStatus(ctx context.Context, in *GetSenderKeyStatusRequest, opts ...grpc.CallOption) (*GetSenderKeyStatusResponse, error)
+	// GetSenderKeys возвращает ключи других участников группы, зашифрованные
+	// для текущего пользователя, и отмечает их доставленными.
+	GetSenderKeys(ctx context.Context, in *GetSenderKeysRequest, opts ...grpc.CallOption) (*GetSenderKeysResponse, error)
 }
 
 type keyExchangeServiceClient struct {
@@ -87,6 +103,36 @@ func (c *keyExchangeServiceClient) GetKeyExchangeParams(ctx context.Context, in
 	return out, nil
 }
 
+func (c *keyExchangeServiceClient) DistributeSenderKey(ctx context.Context, in *DistributeSenderKeyRequest, opts ...grpc.CallOption) (*DistributeSenderKeyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DistributeSenderKeyResponse)
+	err := c.cc.Invoke(ctx, KeyExchangeService_DistributeSenderKey_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *keyExchangeServiceClient) GetSenderKeyStatus(ctx context.Context, in *GetSenderKeyStatusRequest, opts ...grpc.CallOption) (*GetSenderKeyStatusResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetSenderKeyStatusResponse)
+	err := c.cc.Invoke(ctx, KeyExchangeService_GetSenderKeyStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *keyExchangeServiceClient) GetSenderKeys(ctx context.Context, in *GetSenderKeysRequest, opts ...grpc.CallOption) (*GetSenderKeysResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetSenderKeysResponse)
+	err := c.cc.Invoke(ctx, KeyExchangeService_GetSenderKeys_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // KeyExchangeServiceServer is the server API for KeyExchangeService service.
 // All implementations must embed UnimplementedKeyExchangeServiceServer
 // for forward compatibility.
@@ -110,6 +156,19 @@ type KeyExchangeServiceServer interface {
 	// GetKeyExchangeParams получает параметры обмена ключами, сохраненные на сервере
 	// для конкретного собеседника.
 	GetKeyExchangeParams(context.Context, *GetKeyExchangeParamsRequest) (*GetKeyExchangeParamsResponse, error)
+	// DistributeSenderKey сохраняет ключ отправителя для группы, зашифрованный
+	// отдельно для каждого получателя на их общем секрете Диффи-Хеллмана.
+	// Сервер хранит обертки как непрозрачные данные и не может их расшифровать.
+	// Эпоха должна быть текущей (дослать ключ недостающим участникам)
+	// или следующей за ней (ротация ключа).
+	DistributeSenderKey(context.Context, *DistributeSenderKeyRequest) (*DistributeSenderKeyResponse, error)
+	// GetSenderKeyStatus возвращает текущую эпоху ключа отправителя, признак
+	// необходимости ротации после изменения состава группы и участников,
+	// которым ключ текущей эпохи еще не доставлен.
+	GetSenderKeyStatus(context.Context, *GetSenderKeyStatusRequest) (*GetSenderKeyStatusResponse, error)
+	// GetSenderKeys возвращает ключи других участников группы, зашифрованные
+	// для текущего пользователя, и отмечает их доставленными.
+	GetSenderKeys(context.Context, *GetSenderKeysRequest) (*GetSenderKeysResponse, error)
 	mustEmbedUnimplementedKeyExchangeServiceServer()
 }
 
@@ -129,6 +188,15 @@ func (UnimplementedKeyExchangeServiceServer) CompleteKeyExchange(context.Context
 func (UnimplementedKeyExchangeServiceServer) GetKeyExchangeParams(context.Context, *GetKeyExchangeParamsRequest) (*GetKeyExchangeParamsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetKeyExchangeParams not implemented")
 }
+func (UnimplementedKeyExchangeServiceServer) DistributeSenderKey(context.Context, *DistributeSenderKeyRequest) (*DistributeSenderKeyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DistributeSenderKey not implemented")
+}
+func (UnimplementedKeyExchangeServiceServer) GetSenderKeyStatus(context.Context, *GetSenderKeyStatusRequest) (*GetSenderKeyStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSenderKeyStatus not implemented")
+}
+func (UnimplementedKeyExchangeServiceServer) GetSenderKeys(context.Context, *GetSenderKeysRequest) (*GetSenderKeysResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSenderKeys not implemented")
+}
 func (UnimplementedKeyExchangeServiceServer) mustEmbedUnimplementedKeyExchangeServiceServer() {}
 func (UnimplementedKeyExchangeServiceServer) testEmbeddedByValue()                            {}
 
@@ -204,6 +272,60 @@ func _KeyExchangeService_GetKeyExchangeParams_Handler(srv interface{}, ctx conte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _KeyExchangeService_DistributeSenderKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DistributeSenderKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeyExchangeServiceServer).DistributeSenderKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KeyExchangeService_DistributeSenderKey_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeyExchangeServiceServer).DistributeSenderKey(ctx, req.(*DistributeSenderKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KeyExchangeService_GetSenderKeyStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSenderKeyStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeyExchangeServiceServer).GetSenderKeyStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KeyExchangeService_GetSenderKeyStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeyExchangeServiceServer).GetSenderKeyStatus(ctx, req.(*GetSenderKeyStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KeyExchangeService_GetSenderKeys_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSenderKeysRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeyExchangeServiceServer).GetSenderKeys(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KeyExchangeService_GetSenderKeys_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeyExchangeServiceServer).GetSenderKeys(ctx, req.(*GetSenderKeysRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // KeyExchangeService_ServiceDesc is the grpc.ServiceDesc for KeyExchangeService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -223,6 +345,18 @@ var KeyExchangeService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetKeyExchangeParams",
 			Handler:    _KeyExchangeService_GetKeyExchangeParams_Handler,
 		},
+		{
+			MethodName: "DistributeSenderKey",
+			Handler:    _KeyExchangeService_DistributeSenderKey_Handler,
+		},
+		{
+			MethodName: "GetSenderKeyStatus",
+			Handler:    _KeyExchangeService_GetSenderKeyStatus_Handler,
+		},
+		{
+			MethodName: "GetSenderKeys",
+			Handler:    _KeyExchangeService_GetSenderKeys_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/key_exchange_service.proto",
@@ -0,0 +1,275 @@
+package manager
+
+import (
+	"sync"
+	"time"
+)
+
+// PresenceStatus состояние пользователя в сети. Хранится только в памяти и никуда не записывается.
+// Экземпляры сервера обмениваются состояниями своих пользователей через обмен кластера.
+type PresenceStatus int
+
+const (
+	PresenceOffline PresenceStatus = iota
+	PresenceOnline
+	PresenceAway
+)
+
+// Размер буфера обновлений одного подписчика; при переполнении обновления пропускаются
+const presenceBufferSize = 64
+
+// PresenceUpdate изменение состояния пользователя
+type PresenceUpdate struct {
+	UserID uint64
+	Status PresenceStatus
+	At     time.Time
+}
+
+type PresenceManager interface {
+	// SetOnline, SetAway и SetOffline меняют состояние пользователя на этом экземпляре сервера
+	SetOnline(userId uint64)
+	SetAway(userId uint64)
+	SetOffline(userId uint64)
+	// GetStatus возвращает состояние пользователя с учетом всех экземпляров сервера
+	GetStatus(userId uint64) PresenceStatus
+	// Subscribe возвращает канал обновлений состояния указанных пользователей
+	// и функцию отмены подписки, которая закрывает канал
+	Subscribe(userIds []uint64) (<-chan PresenceUpdate, func())
+
+	// LocalChanges возвращает изменения состояния пользователей этого экземпляра для рассылки
+	// остальным. Если канал переполнен, изменение пропускается до следующего полного снимка.
+	LocalChanges() <-chan PresenceUpdate
+	// LocalStatuses возвращает пользователей в сети на этом экземпляре
+	LocalStatuses() map[uint64]PresenceStatus
+	// SetRemote запоминает состояния пользователей на другом экземпляре. Полный снимок заменяет
+	// все прежние состояния экземпляра; PresenceOffline удаляет пользователя.
+	SetRemote(instance string, statuses map[uint64]PresenceStatus, full bool)
+	// ExpireRemote забывает экземпляры, от которых ничего не приходило с момента before
+	ExpireRemote(before time.Time)
+}
+
+// Размер буфера изменений этого экземпляра, ожидающих рассылки
+const presenceChangesBufferSize = 1024
+
+type presenceSubscriber struct {
+	updates chan PresenceUpdate
+}
+
+// remotePresence пользователи в сети на другом экземпляре сервера
+type remotePresence struct {
+	statuses map[uint64]PresenceStatus
+	seenAt   time.Time
+}
+
+type presenceManager struct {
+	mu          sync.Mutex
+	local       map[uint64]PresenceStatus  // пользователи в сети на этом экземпляре
+	remote      map[string]*remotePresence // экземпляр -> его пользователи в сети
+	statuses    map[uint64]PresenceStatus  // итоговое состояние по всем экземплярам
+	subscribers map[uint64]map[*presenceSubscriber]struct{}
+	changes     chan PresenceUpdate
+}
+
+func NewPresenceManager() *presenceManager {
+	return &presenceManager{
+		local:       make(map[uint64]PresenceStatus),
+		remote:      make(map[string]*remotePresence),
+		statuses:    make(map[uint64]PresenceStatus),
+		subscribers: make(map[uint64]map[*presenceSubscriber]struct{}),
+		changes:     make(chan PresenceUpdate, presenceChangesBufferSize),
+	}
+}
+
+func (pm *presenceManager) SetOnline(userId uint64) {
+	pm.setStatus(userId, PresenceOnline)
+}
+
+// SetAway помечает пользователя отошедшим; пользователь не в сети так и остается не в сети
+func (pm *presenceManager) SetAway(userId uint64) {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+
+	if pm.local[userId] == PresenceOffline {
+		return
+	}
+
+	pm.updateLocal(userId, PresenceAway)
+}
+
+func (pm *presenceManager) SetOffline(userId uint64) {
+	pm.setStatus(userId, PresenceOffline)
+}
+
+func (pm *presenceManager) GetStatus(userId uint64) PresenceStatus {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+
+	return pm.statuses[userId]
+}
+
+func (pm *presenceManager) Subscribe(userIds []uint64) (<-chan PresenceUpdate, func()) {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+
+	subscriber := &presenceSubscriber{
+		updates: make(chan PresenceUpdate, presenceBufferSize),
+	}
+
+	for _, userId := range userIds {
+		if pm.subscribers[userId] == nil {
+			pm.subscribers[userId] = make(map[*presenceSubscriber]struct{})
+		}
+		pm.subscribers[userId][subscriber] = struct{}{}
+	}
+
+	var once sync.Once
+	unsubscribe := func() {
+		once.Do(func() {
+			pm.mu.Lock()
+			defer pm.mu.Unlock()
+
+			for _, userId := range userIds {
+				delete(pm.subscribers[userId], subscriber)
+				if len(pm.subscribers[userId]) == 0 {
+					delete(pm.subscribers, userId)
+				}
+			}
+			close(subscriber.updates)
+		})
+	}
+
+	return subscriber.updates, unsubscribe
+}
+
+func (pm *presenceManager) LocalChanges() <-chan PresenceUpdate {
+	return pm.changes
+}
+
+func (pm *presenceManager) LocalStatuses() map[uint64]PresenceStatus {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+
+	statuses := make(map[uint64]PresenceStatus, len(pm.local))
+	for userId, status := range pm.local {
+		statuses[userId] = status
+	}
+	return statuses
+}
+
+func (pm *presenceManager) SetRemote(instance string, statuses map[uint64]PresenceStatus, full bool) {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+
+	remote, exists := pm.remote[instance]
+	if !exists {
+		remote = &remotePresence{statuses: make(map[uint64]PresenceStatus)}
+		pm.remote[instance] = remote
+	}
+	remote.seenAt = time.Now()
+
+	// Пользователи, пропавшие из полного снимка, на экземпляре больше не в сети
+	affected := make(map[uint64]struct{}, len(statuses))
+	if full {
+		for userId := range remote.statuses {
+			affected[userId] = struct{}{}
+		}
+		remote.statuses = make(map[uint64]PresenceStatus, len(statuses))
+	}
+
+	for userId, status := range statuses {
+		affected[userId] = struct{}{}
+		if status == PresenceOffline {
+			delete(remote.statuses, userId)
+		} else {
+			remote.statuses[userId] = status
+		}
+	}
+
+	for userId := range affected {
+		pm.refresh(userId)
+	}
+}
+
+func (pm *presenceManager) ExpireRemote(before time.Time) {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+
+	for instance, remote := range pm.remote {
+		if !remote.seenAt.Before(before) {
+			continue
+		}
+
+		delete(pm.remote, instance)
+		for userId := range remote.statuses {
+			pm.refresh(userId)
+		}
+	}
+}
+
+func (pm *presenceManager) setStatus(userId uint64, status PresenceStatus) {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+
+	pm.updateLocal(userId, status)
+}
+
+// updateLocal меняет состояние пользователя на этом экземпляре; вызывается под pm.mu
+func (pm *presenceManager) updateLocal(userId uint64, status PresenceStatus) {
+	if pm.local[userId] == status {
+		return
+	}
+
+	if status == PresenceOffline {
+		delete(pm.local, userId)
+	} else {
+		pm.local[userId] = status
+	}
+
+	select {
+	case pm.changes <- PresenceUpdate{UserID: userId, Status: status, At: time.Now()}:
+	default:
+	}
+
+	pm.refresh(userId)
+}
+
+// refresh пересчитывает итоговое состояние пользователя и оповещает подписчиков; вызывается под pm.mu.
+// Пользователь в сети, если он в сети хотя бы на одном экземпляре, и отошел, если отошел на всех.
+func (pm *presenceManager) refresh(userId uint64) {
+	status := pm.local[userId]
+	for _, remote := range pm.remote {
+		status = presenceMax(status, remote.statuses[userId])
+	}
+
+	if pm.statuses[userId] == status {
+		return
+	}
+
+	if status == PresenceOffline {
+		delete(pm.statuses, userId)
+	} else {
+		pm.statuses[userId] = status
+	}
+
+	update := PresenceUpdate{
+		UserID: userId,
+		Status: status,
+		At:     time.Now(),
+	}
+	for subscriber := range pm.subscribers[userId] {
+		select {
+		case subscriber.updates <- update:
+		default:
+		}
+	}
+}
+
+func presenceMax(a, b PresenceStatus) PresenceStatus {
+	if a == PresenceOnline || b == PresenceOnline {
+		return PresenceOnline
+	}
+	if a == PresenceAway || b == PresenceAway {
+		return PresenceAway
+	}
+	return PresenceOffline
+}
@@ -0,0 +1,108 @@
+package manager
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPresenceManagerAggregation(t *testing.T) {
+	pm := NewPresenceManager()
+
+	steps := []struct {
+		name   string
+		action func()
+		want   PresenceStatus
+	}{
+		{"unknown user is offline", func() {}, PresenceOffline},
+		{"away on another instance", func() {
+			pm.SetRemote("b", map[uint64]PresenceStatus{1: PresenceAway}, false)
+		}, PresenceAway},
+		{"online locally outranks away", func() { pm.SetOnline(1) }, PresenceOnline},
+		{"away locally and on another instance", func() { pm.SetAway(1) }, PresenceAway},
+		{"online on a third instance", func() {
+			pm.SetRemote("c", map[uint64]PresenceStatus{1: PresenceOnline}, false)
+		}, PresenceOnline},
+		{"full snapshot without the user removes it", func() {
+			pm.SetRemote("c", map[uint64]PresenceStatus{2: PresenceOnline}, true)
+		}, PresenceAway},
+		{"offline locally, still away remotely", func() { pm.SetOffline(1) }, PresenceAway},
+		{"offline change from the remote instance", func() {
+			pm.SetRemote("b", map[uint64]PresenceStatus{1: PresenceOffline}, false)
+		}, PresenceOffline},
+	}
+
+	for _, step := range steps {
+		step.action()
+
+		if got := pm.GetStatus(1); got != step.want {
+			t.Fatalf("%s: status = %v, want %v", step.name, got, step.want)
+		}
+	}
+}
+
+func TestPresenceManagerSetAwayWhenOffline(t *testing.T) {
+	pm := NewPresenceManager()
+
+	pm.SetAway(1)
+	if got := pm.GetStatus(1); got != PresenceOffline {
+		t.Errorf("status = %v, want offline", got)
+	}
+	if statuses := pm.LocalStatuses(); len(statuses) != 0 {
+		t.Errorf("local statuses = %v, want none", statuses)
+	}
+}
+
+func TestPresenceManagerExpireRemote(t *testing.T) {
+	pm := NewPresenceManager()
+
+	pm.SetRemote("b", map[uint64]PresenceStatus{1: PresenceOnline}, true)
+	cutoff := time.Now().Add(time.Millisecond)
+	time.Sleep(2 * time.Millisecond)
+	pm.SetRemote("c", map[uint64]PresenceStatus{2: PresenceOnline}, true)
+
+	pm.ExpireRemote(cutoff)
+
+	tests := []struct {
+		userId uint64
+		want   PresenceStatus
+	}{
+		{1, PresenceOffline}, // экземпляр b давно не присылал снимков
+		{2, PresenceOnline},
+	}
+
+	for _, tt := range tests {
+		if got := pm.GetStatus(tt.userId); got != tt.want {
+			t.Errorf("status of user %d = %v, want %v", tt.userId, got, tt.want)
+		}
+	}
+}
+
+func TestPresenceManagerSubscribe(t *testing.T) {
+	pm := NewPresenceManager()
+
+	updates, unsubscribe := pm.Subscribe([]uint64{1})
+
+	pm.SetOnline(2) // не входит в подписку
+	pm.SetOnline(1)
+	pm.SetRemote("b", map[uint64]PresenceStatus{1: PresenceOnline}, false) // итог не меняется
+
+	select {
+	case update := <-updates:
+		if update.UserID != 1 || update.Status != PresenceOnline {
+			t.Errorf("update = %+v, want user 1 online", update)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("no update after SetOnline")
+	}
+
+	select {
+	case update := <-updates:
+		t.Errorf("unexpected update %+v", update)
+	default:
+	}
+
+	unsubscribe()
+	if _, open := <-updates; open {
+		t.Error("updates channel is open after unsubscribe")
+	}
+}
@@ -1,97 +1,342 @@
 package manager
 
 import (
-	"fmt"
+	"context"
+	"errors"
 	pb "gRPCWebServer/backend/generated"
+	"log"
 	"sync"
 )
 
+// Размер очереди отправки одного потока. Событие, не поместившееся в очередь,
+// кладется в очередь пользователя в брокере и придет при следующем подключении.
+const sessionQueueSize = 256
+
+var ErrSessionClosed = errors.New("session is closed")
+
+// EventStream поток, в который пользователю отправляются сообщения и события чата:
+// поток Chat или поток ReceiveMessages
+type EventStream interface {
+	Send(*pb.ChatResponse) error
+	Context() context.Context
+}
+
+// Subscription чат, открытый на устройстве: личный чат с собеседником или группа.
+// Поток ReceiveMessages подписан на все чаты пользователя.
+type Subscription struct {
+	PeerID   uint64
+	GroupID  uint64
+	AllChats bool
+}
+
+// Watches сообщает, показывает ли подписка события чата target
+func (s Subscription) Watches(target Subscription) bool {
+	return s.AllChats || s == target
+}
+
+// Router направляет события пользователя на этот экземпляр сервера, пока у пользователя открыт здесь хотя бы один поток
+type Router interface {
+	Bind(userId uint64) error
+	Unbind(userId uint64) error
+}
+
 type StreamManager3 interface {
-	AddConnection(senderId, receiverId uint64) error
-	AddStream(senderId uint64, stream pb.ChatService_ChatServer) error
-	GetConnectionData(senderId uint64) (uint64, error)
-	GetStream(senderId uint64) (pb.ChatService_ChatServer, error)
-	ClearConnectionAndStream(senderId uint64)
+	// Connect запоминает чат, который устройство откроет следующим потоком Chat. Потоки,
+	// уже открытые на этом и других устройствах, не закрываются.
+	Connect(userId uint64, deviceId string, sub Subscription)
+	// PendingConnection возвращает чат, выбранный устройством через Connect
+	PendingConnection(userId uint64, deviceId string) (Subscription, bool)
+	// Open регистрирует поток устройства и запускает его очередь отправки. Поток этого же
+	// устройства с той же подпиской закрывается.
+	Open(userId uint64, deviceId string, sub Subscription, stream EventStream) *Session
+	Close(session *Session)
+	// Rebind заново направляет на этот экземпляр события пользователей с открытыми потоками,
+	// например после переподключения к RabbitMQ
+	Rebind()
+	// DeliverWatching ставит событие в очереди потоков пользователя на этом экземпляре сервера,
+	// в которых открыт чат sub, и сообщает, сколько потоков его приняло и сколько не приняло
+	// из-за переполненной очереди. Для последних событие нужно положить в очередь пользователя.
+	DeliverWatching(userId uint64, sub Subscription, resp *pb.ChatResponse) Delivery
 }
 
-type streamManager3 struct {
-	connectionData sync.Map
-	streams        sync.Map
-	mu             sync.RWMutex
+// Delivery итог постановки события в потоки пользователя, в которых открыт его чат
+type Delivery struct {
+	Accepted   int
+	Overflowed int
 }
 
-func NewStreamManager3() *streamManager3 {
-	return &streamManager3{}
+// Watched сообщает, открыт ли чат события хотя бы в одном потоке
+func (d Delivery) Watched() bool {
+	return d.Accepted > 0 || d.Overflowed > 0
 }
 
-func (sm *streamManager3) AddConnection(senderId, receiverId uint64) error {
-	sm.mu.Lock()
-	defer sm.mu.Unlock()
+// Session поток одного устройства пользователя. События отправляются в поток отдельной горутиной
+// из очереди сессии, поэтому медленный клиент не задерживает рассылку остальным.
+type Session struct {
+	UserID       uint64
+	DeviceID     string
+	Subscription Subscription
+
+	stream    EventStream
+	queue     chan *pb.ChatResponse
+	done      chan struct{}
+	closeOnce sync.Once
+}
 
-	if _, exists := sm.connectionData.Load(senderId); exists {
-		return fmt.Errorf("connection with senderID=%d and receiverID=%d already exists", senderId, receiverId)
+func newSession(userId uint64, deviceId string, sub Subscription, stream EventStream) *Session {
+	return &Session{
+		UserID:       userId,
+		DeviceID:     deviceId,
+		Subscription: sub,
+		stream:       stream,
+		queue:        make(chan *pb.ChatResponse, sessionQueueSize),
+		done:         make(chan struct{}),
 	}
+}
 
-	sm.connectionData.Store(senderId, receiverId)
+// Send ставит событие в очередь сессии, дожидаясь в ней места. Используется обработчиком
+// самого потока: подтверждения и сообщения из очереди брокера не должны теряться.
+func (s *Session) Send(resp *pb.ChatResponse) error {
+	// Без отдельной проверки select может положить событие в очередь уже закрытой сессии
+	select {
+	case <-s.done:
+		return ErrSessionClosed
+	default:
+	}
 
-	return nil
+	select {
+	case s.queue <- resp:
+		return nil
+	case <-s.done:
+		return ErrSessionClosed
+	case <-s.stream.Context().Done():
+		return s.stream.Context().Err()
+	}
 }
 
-func (sm *streamManager3) AddStream(senderId uint64, stream pb.ChatService_ChatServer) error {
-	sm.mu.Lock()
-	defer sm.mu.Unlock()
+func (s *Session) Context() context.Context {
+	return s.stream.Context()
+}
+
+// Done закрывается, когда сессия закрыта и события в нее больше не отправляются
+func (s *Session) Done() <-chan struct{} {
+	return s.done
+}
+
+// offer ставит событие в очередь, не дожидаясь места. overflow сообщает, что сессия
+// открыта, но ее очередь переполнена.
+func (s *Session) offer(resp *pb.ChatResponse) (accepted, overflow bool) {
+	select {
+	case <-s.done:
+		return false, false
+	default:
+	}
 
-	if _, exists := sm.connectionData.Load(senderId); !exists {
-		return fmt.Errorf("failed to add stream: no connection found for senderID=%d", senderId)
+	select {
+	case s.queue <- resp:
+		return true, false
+	default:
+		log.Printf("Send queue of user %d on device %q is full", s.UserID, s.DeviceID)
+		return false, true
 	}
+}
 
-	if _, exists := sm.streams.Load(senderId); exists {
-		return fmt.Errorf("failed to add stream: stream for senderID=%d already exists", senderId)
+func (s *Session) run() {
+	for {
+		select {
+		case resp := <-s.queue:
+			if err := s.stream.Send(resp); err != nil {
+				log.Printf("Failed to send event to user %d on device %q: %v", s.UserID, s.DeviceID, err)
+				s.close()
+				return
+			}
+		case <-s.done:
+			return
+		}
 	}
+}
 
-	sm.streams.Store(senderId, stream)
+func (s *Session) close() {
+	s.closeOnce.Do(func() {
+		close(s.done)
+	})
+}
+
+type deviceKey struct {
+	userId   uint64
+	deviceId string
+}
 
-	return nil
+// userBinding состояние привязки событий пользователя к этому экземпляру. Вызовы Router
+// выполняются под mu привязки, а не под mu менеджера, чтобы не задерживать рассылку.
+type userBinding struct {
+	mu    sync.Mutex
+	bound bool
 }
 
-func (sm *streamManager3) GetConnectionData(senderId uint64) (uint64, error) {
+type streamManager3 struct {
+	mu       sync.RWMutex
+	pending  map[deviceKey]Subscription
+	sessions map[uint64]map[*Session]struct{}
+	bindings map[uint64]*userBinding // не удаляются, чтобы привязку пользователя меняла одна запись
+	presence PresenceManager         // пользователь в сети, пока у него открыт хотя бы один поток
+	router   Router
+}
+
+func NewStreamManager3(presence PresenceManager, router Router) *streamManager3 {
+	return &streamManager3{
+		pending:  make(map[deviceKey]Subscription),
+		sessions: make(map[uint64]map[*Session]struct{}),
+		bindings: make(map[uint64]*userBinding),
+		presence: presence,
+		router:   router,
+	}
+}
+
+func (sm *streamManager3) Connect(userId uint64, deviceId string, sub Subscription) {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	sm.pending[deviceKey{userId, deviceId}] = sub
+}
+
+func (sm *streamManager3) PendingConnection(userId uint64, deviceId string) (Subscription, bool) {
 	sm.mu.RLock()
 	defer sm.mu.RUnlock()
 
-	value, exists := sm.connectionData.Load(senderId)
+	sub, exists := sm.pending[deviceKey{userId, deviceId}]
+	return sub, exists
+}
+
+func (sm *streamManager3) Open(userId uint64, deviceId string, sub Subscription, stream EventStream) *Session {
+	session := newSession(userId, deviceId, sub, stream)
+
+	sm.mu.Lock()
+	sessions, exists := sm.sessions[userId]
 	if !exists {
-		return 0, fmt.Errorf("failed to get connection data: senderID=%d has no receiver", senderId)
+		sessions = make(map[*Session]struct{})
+		sm.sessions[userId] = sessions
+	}
+
+	for previous := range sessions {
+		if previous.DeviceID == deviceId && previous.Subscription == sub {
+			delete(sessions, previous)
+			previous.close()
+		}
 	}
+	sessions[session] = struct{}{}
+	sm.mu.Unlock()
 
-	receiverId, ok := value.(uint64)
-	if !ok {
-		return 0, fmt.Errorf("failed to get connection data: unexpected receiverID type: expected uint64, got %T", value)
+	sm.presence.SetOnline(userId)
+	go session.run()
+
+	// Без привязки поток получит только события из очереди пользователя
+	if !exists {
+		sm.syncBinding(userId)
 	}
 
-	return receiverId, nil
+	return session
 }
 
-func (sm *streamManager3) GetStream(senderId uint64) (pb.ChatService_ChatServer, error) {
-	sm.mu.RLock()
-	defer sm.mu.RUnlock()
+func (sm *streamManager3) Close(session *Session) {
+	session.close()
 
-	value, exists := sm.streams.Load(senderId)
+	sm.mu.Lock()
+	sessions, exists := sm.sessions[session.UserID]
 	if !exists {
-		return nil, fmt.Errorf("stream for userID=%d not found", senderId)
+		sm.mu.Unlock()
+		return
+	}
+
+	if _, registered := sessions[session]; !registered {
+		sm.mu.Unlock()
+		return
+	}
+	delete(sessions, session)
+
+	last := len(sessions) == 0
+	if last {
+		delete(sm.sessions, session.UserID)
+	}
+	sm.mu.Unlock()
+
+	if last {
+		sm.presence.SetOffline(session.UserID)
+		sm.syncBinding(session.UserID)
 	}
+}
+
+func (sm *streamManager3) Rebind() {
+	sm.mu.RLock()
+	userIds := make([]uint64, 0, len(sm.bindings))
+	for userId, binding := range sm.bindings {
+		userIds = append(userIds, userId)
 
-	stream, ok := value.(pb.ChatService_ChatServer)
-	if !ok {
-		return nil, fmt.Errorf("unexpected stream type for userID=%d: expected pb.ChatService_ChatServer, got %T", senderId, value)
+		// Привязки прежнего соединения удалены вместе с его очередью
+		binding.mu.Lock()
+		binding.bound = false
+		binding.mu.Unlock()
 	}
+	sm.mu.RUnlock()
 
-	return stream, nil
+	for _, userId := range userIds {
+		sm.syncBinding(userId)
+	}
 }
 
-func (sm *streamManager3) ClearConnectionAndStream(senderId uint64) {
+// syncBinding приводит привязку пользователя к тому, открыт ли у него сейчас хотя бы один поток.
+// Состояние потоков перечитывается под mu привязки, поэтому параллельные Open и Close
+// не оставят пользователя привязанным без потоков или наоборот.
+func (sm *streamManager3) syncBinding(userId uint64) {
 	sm.mu.Lock()
-	defer sm.mu.Unlock()
+	binding, exists := sm.bindings[userId]
+	if !exists {
+		binding = &userBinding{}
+		sm.bindings[userId] = binding
+	}
+	sm.mu.Unlock()
+
+	binding.mu.Lock()
+	defer binding.mu.Unlock()
+
+	sm.mu.RLock()
+	connected := len(sm.sessions[userId]) > 0
+	sm.mu.RUnlock()
+
+	switch {
+	case connected && !binding.bound:
+		if err := sm.router.Bind(userId); err != nil {
+			log.Printf("Failed to route events of user %d to this server: %v", userId, err)
+			return
+		}
+		binding.bound = true
+	case !connected && binding.bound:
+		if err := sm.router.Unbind(userId); err != nil {
+			log.Printf("Failed to stop routing events of user %d to this server: %v", userId, err)
+			return
+		}
+		binding.bound = false
+	}
+}
+
+func (sm *streamManager3) DeliverWatching(userId uint64, sub Subscription, resp *pb.ChatResponse) Delivery {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	var delivery Delivery
+	for session := range sm.sessions[userId] {
+		if !session.Subscription.Watches(sub) {
+			continue
+		}
+
+		// Поток с переполненной очередью получит событие из очереди пользователя при переподключении
+		switch accepted, overflow := session.offer(resp); {
+		case accepted:
+			delivery.Accepted++
+		case overflow:
+			delivery.Overflowed++
+		}
+	}
 
-	sm.connectionData.Delete(senderId)
-	sm.streams.Delete(senderId)
+	return delivery
 }
@@ -0,0 +1,333 @@
+package manager
+
+import (
+	"context"
+	pb "gRPCWebServer/backend/generated"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeStream складывает отправленные события в канал. Если задан gate, Send ждет его закрытия.
+type fakeStream struct {
+	ctx      context.Context
+	received chan *pb.ChatResponse
+	gate     chan struct{}
+}
+
+func newFakeStream(t *testing.T) *fakeStream {
+	t.Helper()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	return &fakeStream{ctx: ctx, received: make(chan *pb.ChatResponse, 16)}
+}
+
+func (s *fakeStream) Send(resp *pb.ChatResponse) error {
+	if s.gate != nil {
+		select {
+		case <-s.gate:
+		case <-s.ctx.Done():
+			return s.ctx.Err()
+		}
+	}
+
+	select {
+	case s.received <- resp:
+		return nil
+	case <-s.ctx.Done():
+		return s.ctx.Err()
+	}
+}
+
+func (s *fakeStream) Context() context.Context {
+	return s.ctx
+}
+
+// fakeRouter запоминает вызовы Bind и Unbind
+type fakeRouter struct {
+	mu    sync.Mutex
+	calls []string
+}
+
+func (r *fakeRouter) Bind(userId uint64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.calls = append(r.calls, "bind")
+	return nil
+}
+
+func (r *fakeRouter) Unbind(userId uint64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.calls = append(r.calls, "unbind")
+	return nil
+}
+
+func (r *fakeRouter) Calls() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]string(nil), r.calls...)
+}
+
+// receivedBefore отправляет в сессию метку и сообщает, пришло ли до нее событие с messageId.
+// Очередь сессии упорядочена, поэтому после метки событие уже не придет.
+func receivedBefore(t *testing.T, session *Session, stream *fakeStream, messageId uint64) bool {
+	t.Helper()
+
+	marker := &pb.ChatResponse{ClientId: "marker"}
+	if err := session.Send(marker); err != nil {
+		t.Fatalf("Send marker: %v", err)
+	}
+
+	found := false
+	for {
+		select {
+		case resp := <-stream.received:
+			if resp == marker {
+				return found
+			}
+			if resp.MessageId == messageId {
+				found = true
+			}
+		case <-time.After(time.Second):
+			t.Fatal("marker was not sent to the stream")
+		}
+	}
+}
+
+func TestStreamManager3DeliverWatching(t *testing.T) {
+	sm := NewStreamManager3(NewPresenceManager(), &fakeRouter{})
+
+	// Устройства пользователя 1: лента всех чатов, личные чаты с 7 и 8 и группа 3.
+	// У пользователя 3 открыт только чат с 7.
+	subs := map[string]Subscription{
+		"all":    {AllChats: true},
+		"peer7":  {PeerID: 7},
+		"peer8":  {PeerID: 8},
+		"group3": {GroupID: 3},
+	}
+
+	sessions := make(map[string]*Session)
+	streams := make(map[string]*fakeStream)
+	for name, sub := range subs {
+		streams[name] = newFakeStream(t)
+		sessions[name] = sm.Open(1, "device-"+name, sub, streams[name])
+	}
+
+	otherStream := newFakeStream(t)
+	otherSession := sm.Open(2, "device", Subscription{AllChats: true}, otherStream)
+	sm.Open(3, "device", Subscription{PeerID: 7}, newFakeStream(t))
+
+	tests := []struct {
+		name          string
+		userId        uint64
+		target        Subscription
+		wantReceivers []string
+	}{
+		{"direct chat", 1, Subscription{PeerID: 7}, []string{"all", "peer7"}},
+		{"group", 1, Subscription{GroupID: 3}, []string{"all", "group3"}},
+		{"peer with the id of a group", 1, Subscription{PeerID: 3}, []string{"all"}},
+		{"only unrelated chats open", 3, Subscription{PeerID: 8}, nil},
+		{"user without streams", 4, Subscription{PeerID: 7}, nil},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			messageId := uint64(i + 1)
+			delivery := sm.DeliverWatching(tt.userId, tt.target, &pb.ChatResponse{MessageId: messageId})
+			if want := (Delivery{Accepted: len(tt.wantReceivers)}); delivery != want {
+				t.Errorf("DeliverWatching = %+v, want %+v", delivery, want)
+			}
+
+			want := make(map[string]bool)
+			for _, name := range tt.wantReceivers {
+				want[name] = true
+			}
+			for name, session := range sessions {
+				if got := receivedBefore(t, session, streams[name], messageId); got != (tt.userId == 1 && want[name]) {
+					t.Errorf("session %q received = %v", name, got)
+				}
+			}
+
+			if receivedBefore(t, otherSession, otherStream, messageId) {
+				t.Error("event was sent to a stream of another user")
+			}
+		})
+	}
+}
+
+func TestStreamManager3DeliverWatchingOverflow(t *testing.T) {
+	sm := NewStreamManager3(NewPresenceManager(), &fakeRouter{})
+
+	// Поток не отправляет события, пока не закрыт gate, и очередь сессии заполняется
+	stream := newFakeStream(t)
+	stream.gate = make(chan struct{})
+	defer close(stream.gate)
+
+	session := sm.Open(1, "device", Subscription{AllChats: true}, stream)
+	defer sm.Close(session)
+
+	// Одно событие может забрать горутина отправки, остальные остаются в очереди
+	for i := 0; i <= sessionQueueSize+1; i++ {
+		delivery := sm.DeliverWatching(1, Subscription{PeerID: 7}, &pb.ChatResponse{MessageId: uint64(i + 1)})
+		if delivery.Overflowed > 0 {
+			if i < sessionQueueSize {
+				t.Fatalf("event %d rejected before the queue was full", i+1)
+			}
+			if delivery != (Delivery{Overflowed: 1}) {
+				t.Errorf("DeliverWatching = %+v, want one overflowed stream", delivery)
+			}
+			return
+		}
+	}
+
+	t.Fatal("DeliverWatching accepted more events than the queue holds")
+}
+
+func TestStreamManager3DeliverWatchingReportsEachStream(t *testing.T) {
+	sm := NewStreamManager3(NewPresenceManager(), &fakeRouter{})
+
+	slow := newFakeStream(t)
+	slow.gate = make(chan struct{})
+	defer close(slow.gate)
+
+	sm.Open(1, "phone", Subscription{AllChats: true}, slow)
+	sm.Open(1, "laptop", Subscription{PeerID: 7}, newFakeStream(t))
+
+	// Очередь медленного потока заполняется событиями чата, который не открыт на втором устройстве
+	for i := 0; i <= sessionQueueSize+1; i++ {
+		if sm.DeliverWatching(1, Subscription{PeerID: 8}, &pb.ChatResponse{MessageId: uint64(i + 1)}).Overflowed > 0 {
+			break
+		}
+	}
+
+	delivery := sm.DeliverWatching(1, Subscription{PeerID: 7}, &pb.ChatResponse{MessageId: sessionQueueSize + 2})
+
+	// Только переполненный поток должен получить событие повторно из очереди пользователя
+	if want := (Delivery{Accepted: 1, Overflowed: 1}); delivery != want {
+		t.Errorf("DeliverWatching = %+v, want %+v", delivery, want)
+	}
+	if !delivery.Watched() {
+		t.Error("Watched = false with open streams of the chat")
+	}
+}
+
+func TestStreamManager3OpenReplacesSameDeviceAndChat(t *testing.T) {
+	sm := NewStreamManager3(NewPresenceManager(), &fakeRouter{})
+
+	first := sm.Open(1, "device", Subscription{PeerID: 7}, newFakeStream(t))
+	otherChat := sm.Open(1, "device", Subscription{PeerID: 8}, newFakeStream(t))
+	otherDevice := sm.Open(1, "laptop", Subscription{PeerID: 7}, newFakeStream(t))
+	second := sm.Open(1, "device", Subscription{PeerID: 7}, newFakeStream(t))
+
+	tests := []struct {
+		name       string
+		session    *Session
+		wantClosed bool
+	}{
+		{"replaced stream", first, true},
+		{"other chat on the same device", otherChat, false},
+		{"same chat on another device", otherDevice, false},
+		{"new stream", second, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			closed := false
+			select {
+			case <-tt.session.Done():
+				closed = true
+			default:
+			}
+
+			if closed != tt.wantClosed {
+				t.Errorf("closed = %v, want %v", closed, tt.wantClosed)
+			}
+		})
+	}
+
+	if err := first.Send(&pb.ChatResponse{}); err != ErrSessionClosed {
+		t.Errorf("Send to replaced session = %v, want ErrSessionClosed", err)
+	}
+}
+
+func TestStreamManager3Close(t *testing.T) {
+	presence := NewPresenceManager()
+	sm := NewStreamManager3(presence, &fakeRouter{})
+
+	first := sm.Open(1, "device", Subscription{PeerID: 7}, newFakeStream(t))
+	second := sm.Open(1, "laptop", Subscription{AllChats: true}, newFakeStream(t))
+
+	if status := presence.GetStatus(1); status != PresenceOnline {
+		t.Fatalf("status after Open = %v, want online", status)
+	}
+
+	sm.Close(first)
+	if status := presence.GetStatus(1); status != PresenceOnline {
+		t.Errorf("status with one stream left = %v, want online", status)
+	}
+	if delivery := sm.DeliverWatching(1, Subscription{PeerID: 7}, &pb.ChatResponse{MessageId: 1}); delivery.Accepted != 1 {
+		t.Errorf("DeliverWatching to the remaining stream = %+v", delivery)
+	}
+
+	sm.Close(second)
+	if status := presence.GetStatus(1); status != PresenceOffline {
+		t.Errorf("status after the last Close = %v, want offline", status)
+	}
+	if delivery := sm.DeliverWatching(1, Subscription{PeerID: 7}, &pb.ChatResponse{MessageId: 2}); delivery.Watched() {
+		t.Errorf("DeliverWatching after all streams were closed = %+v", delivery)
+	}
+
+	// Повторное закрытие ничего не меняет
+	sm.Close(second)
+}
+
+func TestStreamManager3Binding(t *testing.T) {
+	router := &fakeRouter{}
+	sm := NewStreamManager3(NewPresenceManager(), router)
+
+	first := sm.Open(1, "device", Subscription{PeerID: 7}, newFakeStream(t))
+	second := sm.Open(1, "laptop", Subscription{AllChats: true}, newFakeStream(t))
+	// Замена потока того же устройства не отвязывает пользователя
+	replacement := sm.Open(1, "device", Subscription{PeerID: 7}, newFakeStream(t))
+	sm.Close(first)
+
+	steps := []struct {
+		name      string
+		action    func()
+		wantCalls []string
+	}{
+		{"first stream binds, later streams and replacements do not", func() {}, []string{"bind"}},
+		{"closing one of the streams keeps the binding", func() { sm.Close(second) }, []string{"bind"}},
+		{"closing the last stream unbinds", func() { sm.Close(replacement) }, []string{"bind", "unbind"}},
+		{"reopening binds again", func() { sm.Open(1, "device", Subscription{AllChats: true}, newFakeStream(t)) }, []string{"bind", "unbind", "bind"}},
+		{"rebind restores the binding", sm.Rebind, []string{"bind", "unbind", "bind", "bind"}},
+	}
+
+	for _, step := range steps {
+		step.action()
+
+		got := router.Calls()
+		if strings.Join(got, ",") != strings.Join(step.wantCalls, ",") {
+			t.Errorf("%s: router calls = %v, want %v", step.name, got, step.wantCalls)
+		}
+	}
+}
+
+func TestStreamManager3RebindSkipsDisconnectedUsers(t *testing.T) {
+	router := &fakeRouter{}
+	sm := NewStreamManager3(NewPresenceManager(), router)
+
+	sm.Close(sm.Open(1, "device", Subscription{AllChats: true}, newFakeStream(t)))
+	sm.Rebind()
+
+	if got := router.Calls(); strings.Join(got, ",") != "bind,unbind" {
+		t.Errorf("router calls = %v, want [bind unbind]", got)
+	}
+}
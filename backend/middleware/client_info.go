@@ -0,0 +1,147 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+// Прокси, которым разрешено передавать адрес клиента в X-Forwarded-For; задаются при старте сервера
+var trustedProxies []*net.IPNet
+
+// SetTrustedProxies задает доверенные прокси списком IP-адресов и подсетей CIDR.
+// Без доверенных прокси X-Forwarded-For игнорируется.
+func SetTrustedProxies(proxies []string) error {
+	networks := make([]*net.IPNet, 0, len(proxies))
+	for _, proxy := range proxies {
+		proxy = strings.TrimSpace(proxy)
+		if proxy == "" {
+			continue
+		}
+
+		if !strings.Contains(proxy, "/") {
+			ip := net.ParseIP(proxy)
+			if ip == nil {
+				return fmt.Errorf("invalid trusted proxy address %q", proxy)
+			}
+			bits := 8 * len(ip.To16())
+			if ip.To4() != nil {
+				ip, bits = ip.To4(), 32
+			}
+			networks = append(networks, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+
+		_, network, err := net.ParseCIDR(proxy)
+		if err != nil {
+			return fmt.Errorf("invalid trusted proxy network %q: %v", proxy, err)
+		}
+		networks = append(networks, network)
+	}
+
+	trustedProxies = networks
+	return nil
+}
+
+func isTrustedProxy(addr string) bool {
+	ip := net.ParseIP(addr)
+	if ip == nil {
+		return false
+	}
+
+	for _, network := range trustedProxies {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// forwardedClientIP возвращает адрес клиента по адресу соединения и X-Forwarded-For.
+// Заголовку верят, только если соединение пришло от доверенного прокси; адреса в нем
+// просматриваются справа налево до первого, который не принадлежит доверенному прокси.
+func forwardedClientIP(remote, forwarded string) string {
+	if forwarded == "" || !isTrustedProxy(remote) {
+		return remote
+	}
+
+	hops := strings.Split(forwarded, ",")
+	for i := len(hops) - 1; i >= 0; i-- {
+		hop := strings.TrimSpace(hops[i])
+		if net.ParseIP(hop) == nil {
+			break
+		}
+		remote = hop
+		if !isTrustedProxy(hop) {
+			break
+		}
+	}
+
+	return remote
+}
+
+func hostOnly(addr string) string {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return addr
+	}
+
+	return host
+}
+
+// ClientIP возвращает IP-адрес клиента, учитывая X-Forwarded-For от доверенных прокси
+func ClientIP(ctx context.Context) string {
+	var remote string
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		remote = hostOnly(p.Addr.String())
+	}
+
+	var forwarded string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		forwarded = strings.Join(md.Get("x-forwarded-for"), ",")
+	}
+
+	return forwardedClientIP(remote, forwarded)
+}
+
+// UserAgent возвращает User-Agent клиента из метаданных запроса
+func UserAgent(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+
+	for _, key := range []string{"x-user-agent", "user-agent"} {
+		if values := md.Get(key); len(values) > 0 && values[0] != "" {
+			return values[0]
+		}
+	}
+
+	return ""
+}
+
+// DeviceID возвращает устройство клиента из метаданных x-device-id. Клиенты, которые его не передают,
+// считаются одним устройством пользователя.
+func DeviceID(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+
+	if values := md.Get("x-device-id"); len(values) > 0 {
+		return values[0]
+	}
+
+	return ""
+}
+
+// HTTPClientIP возвращает IP-адрес клиента HTTP-запроса, учитывая X-Forwarded-For от доверенных прокси
+func HTTPClientIP(r *http.Request) string {
+	return forwardedClientIP(hostOnly(r.RemoteAddr), strings.Join(r.Header.Values("X-Forwarded-For"), ","))
+}
@@ -0,0 +1,143 @@
+package middleware
+
+import (
+	"context"
+	"gRPCWebServer/backend/ratelimit"
+	"gRPCWebServer/backend/utils"
+	"log"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// RateLimitInterceptor ограничивает частоту вызовов методов входа и регистрации
+// и блокирует имя пользователя и IP после серии неудачных входов
+type RateLimitInterceptor struct {
+	limiter ratelimit.Limiter
+	// метод -> префикс ключа; для методов с trackFailures учитываются неудачные попытки
+	limitedMethods map[string]limitedMethod
+}
+
+type limitedMethod struct {
+	prefix        string
+	trackFailures bool
+}
+
+type usernameGetter interface {
+	GetUsername() string
+}
+
+// Запросы второго фактора не содержат имени: пользователь берется из challenge-токена
+type challengeTokenGetter interface {
+	GetChallengeToken() string
+}
+
+func NewRateLimitInterceptor(limiter ratelimit.Limiter) *RateLimitInterceptor {
+	return &RateLimitInterceptor{
+		limiter: limiter,
+		limitedMethods: map[string]limitedMethod{
+			"/messenger.UserService/Login":              {prefix: "login", trackFailures: true},
+			"/messenger.UserService/Register":           {prefix: "register"},
+			"/messenger.UserService/VerifySecondFactor": {prefix: "2fa", trackFailures: true},
+			"/messenger.UserService/DisableTOTP":        {prefix: "2fa", trackFailures: true},
+			"/messenger.UserService/DeleteAccount":      {prefix: "password", trackFailures: true},
+		},
+	}
+}
+
+func (rl *RateLimitInterceptor) UnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		method, ok := rl.limitedMethods[info.FullMethod]
+		if !ok {
+			return handler(ctx, req)
+		}
+
+		keys := []string{method.prefix + ":ip:" + ClientIP(ctx)}
+		if userKey := requestUserKey(req); userKey != "" {
+			keys = append(keys, method.prefix+":user:"+userKey)
+		}
+
+		if retryAfter := rl.take(ctx, keys); retryAfter > 0 {
+			return nil, rl.exhausted(ctx, retryAfter)
+		}
+
+		resp, err := handler(ctx, req)
+
+		if method.trackFailures {
+			switch status.Code(err) {
+			case codes.OK:
+				// Успешный вход снимает счетчик только с имени пользователя, но не с IP
+				if len(keys) > 1 {
+					if err := rl.limiter.Reset(ctx, keys[1]); err != nil {
+						log.Printf("Rate limiter reset failed: %v", err)
+					}
+				}
+			case codes.Unauthenticated:
+				for _, key := range keys {
+					if err := rl.limiter.Fail(ctx, key); err != nil {
+						log.Printf("Rate limiter failure tracking failed: %v", err)
+					}
+				}
+			}
+		}
+
+		return resp, err
+	}
+}
+
+// requestUserKey возвращает часть ключа лимита, относящуюся к пользователю запроса
+func requestUserKey(req interface{}) string {
+	if r, ok := req.(usernameGetter); ok && r.GetUsername() != "" {
+		return strings.ToLower(r.GetUsername())
+	}
+
+	if r, ok := req.(challengeTokenGetter); ok && r.GetChallengeToken() != "" {
+		// Подпись проверяется здесь же, поэтому подделать чужой id в ключе нельзя
+		if claims, err := utils.ValidateChallengeToken(r.GetChallengeToken()); err == nil {
+			return "id:" + strconv.FormatUint(claims.UserId, 10)
+		}
+	}
+
+	return ""
+}
+
+// Allow проверяет лимит для произвольного ключа вне gRPC (например, для WebSocket)
+func (rl *RateLimitInterceptor) Allow(ctx context.Context, key string) time.Duration {
+	return rl.take(ctx, []string{key})
+}
+
+// take расходует токены всех ключей и возвращает наибольшее время ожидания.
+// При ошибке хранилища запрос пропускается, чтобы не блокировать вход всем пользователям.
+func (rl *RateLimitInterceptor) take(ctx context.Context, keys []string) time.Duration {
+	var retryAfter time.Duration
+	for _, key := range keys {
+		wait, err := rl.limiter.Take(ctx, key)
+		if err != nil {
+			log.Printf("Rate limiter error for %s: %v", key, err)
+			continue
+		}
+		if wait > retryAfter {
+			retryAfter = wait
+		}
+	}
+	return retryAfter
+}
+
+func (rl *RateLimitInterceptor) exhausted(ctx context.Context, retryAfter time.Duration) error {
+	seconds := RetryAfterSeconds(retryAfter)
+	if err := grpc.SetTrailer(ctx, metadata.Pairs("retry-after", seconds)); err != nil {
+		log.Printf("Failed to set retry-after trailer: %v", err)
+	}
+	return status.Errorf(codes.ResourceExhausted, "too many attempts, retry after %s seconds", seconds)
+}
+
+// RetryAfterSeconds округляет ожидание вверх до целых секунд для заголовка Retry-After
+func RetryAfterSeconds(d time.Duration) string {
+	return strconv.Itoa(int(math.Ceil(d.Seconds())))
+}
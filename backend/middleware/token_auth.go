@@ -3,6 +3,7 @@ package middleware
 import (
 	"context"
 	"errors"
+	"gRPCWebServer/backend/repository"
 	"gRPCWebServer/backend/utils"
 	"log"
 	"strings"
@@ -17,14 +18,19 @@ type TokenKey string
 
 type AuthInterceptor struct {
 	skippedMethods map[string]bool
+	sessionRepo    repository.SessionRepository
 }
 
-func NewAuthInterceptor() *AuthInterceptor {
+func NewAuthInterceptor(sessionRepo repository.SessionRepository) *AuthInterceptor {
 	return &AuthInterceptor{
 		skippedMethods: map[string]bool{
-			"/messenger.UserService/Login":    true,
-			"/messenger.UserService/Register": true,
+			"/messenger.UserService/Login":        true,
+			"/messenger.UserService/Register":     true,
+			"/messenger.UserService/RefreshToken": true,
+			// Аутентифицируется challenge-токеном из Login
+			"/messenger.UserService/VerifySecondFactor": true,
 		},
+		sessionRepo: sessionRepo,
 	}
 }
 
@@ -43,7 +49,18 @@ func (a *AuthInterceptor) authenticate(ctx context.Context) (context.Context, er
 		return nil, status.Errorf(codes.Unauthenticated, "invalid token: %v", err)
 	}
 
+	// Отмечаем активность сессии; отозванная или истекшая сессия не обновится
+	active, err := a.sessionRepo.Touch(ctx, claims.SessionId, ClientIP(ctx))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to check session: %v", err)
+	}
+
+	if !active {
+		return nil, status.Errorf(codes.Unauthenticated, "session has been revoked")
+	}
+
 	ctx = context.WithValue(ctx, TokenKey("user_id"), claims.UserId)
+	ctx = context.WithValue(ctx, TokenKey("session_id"), claims.SessionId)
 	return ctx, nil
 }
 
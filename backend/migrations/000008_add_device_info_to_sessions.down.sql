@@ -0,0 +1,4 @@
+ALTER TABLE sessions
+DROP COLUMN device_label,
+DROP COLUMN ip_address,
+DROP COLUMN last_seen_at;
@@ -0,0 +1,4 @@
+ALTER TABLE sessions
+ADD COLUMN device_label VARCHAR(255) NOT NULL DEFAULT '',
+ADD COLUMN ip_address VARCHAR(64) NOT NULL DEFAULT '',
+ADD COLUMN last_seen_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW();
@@ -0,0 +1,8 @@
+-- Состояние ограничителя частоты запросов, общее для всех экземпляров сервера
+CREATE TABLE rate_limits (
+    key VARCHAR(255) PRIMARY KEY,               -- например, ip:10.0.0.1 или user:alice
+    tokens DOUBLE PRECISION NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    failures INTEGER NOT NULL DEFAULT 0,
+    locked_until TIMESTAMP WITH TIME ZONE
+);
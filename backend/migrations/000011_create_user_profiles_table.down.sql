@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS user_profiles;
+
+DELETE FROM file_uploads WHERE chat_id IS NULL;
+DELETE FROM files WHERE chat_id IS NULL;
+ALTER TABLE file_uploads ALTER COLUMN chat_id SET NOT NULL;
+ALTER TABLE files ALTER COLUMN chat_id SET NOT NULL;
@@ -0,0 +1,13 @@
+-- Профиль пользователя: отображаемое имя, описание и аватар.
+-- Время последнего посещения берется из сессий.
+CREATE TABLE user_profiles (
+    user_id INTEGER PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    display_name VARCHAR(64) NOT NULL DEFAULT '',
+    bio VARCHAR(500) NOT NULL DEFAULT '',
+    avatar_file_id VARCHAR(255) REFERENCES files(file_id) ON DELETE SET NULL,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Личные файлы (аватары) не привязаны к чату
+ALTER TABLE files ALTER COLUMN chat_id DROP NOT NULL;
+ALTER TABLE file_uploads ALTER COLUMN chat_id DROP NOT NULL;
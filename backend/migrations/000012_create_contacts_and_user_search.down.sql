@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS contacts;
+
+DROP INDEX IF EXISTS idx_user_profiles_display_name_trgm;
+DROP INDEX IF EXISTS idx_users_username_trgm;
+
+ALTER TABLE user_profiles
+DROP COLUMN hidden_from_search;
@@ -0,0 +1,16 @@
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+-- Скрытых пользователей можно найти только по точному имени
+ALTER TABLE user_profiles
+ADD COLUMN hidden_from_search BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE INDEX idx_users_username_trgm ON users USING GIN (username gin_trgm_ops);
+CREATE INDEX idx_user_profiles_display_name_trgm ON user_profiles USING GIN (display_name gin_trgm_ops);
+
+CREATE TABLE contacts (
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    contact_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, contact_id),
+    CHECK (user_id <> contact_id)
+);
@@ -0,0 +1,14 @@
+DELETE FROM messages WHERE receiver_id IS NULL;
+ALTER TABLE messages ALTER COLUMN receiver_id SET NOT NULL;
+
+DROP TABLE IF EXISTS chat_members;
+
+DELETE FROM chats WHERE is_group;
+
+ALTER TABLE chats
+DROP CONSTRAINT chats_participants_check,
+DROP COLUMN created_at,
+DROP COLUMN title,
+DROP COLUMN is_group,
+ALTER COLUMN user_1_id SET NOT NULL,
+ALTER COLUMN user_2_id SET NOT NULL;
@@ -0,0 +1,27 @@
+-- Групповые чаты хранятся в той же таблице chats; у них нет user_1_id/user_2_id,
+-- а участники перечислены в chat_members
+ALTER TABLE chats
+ALTER COLUMN user_1_id DROP NOT NULL,
+ALTER COLUMN user_2_id DROP NOT NULL,
+ADD COLUMN is_group BOOLEAN NOT NULL DEFAULT FALSE,
+ADD COLUMN title VARCHAR(128) NOT NULL DEFAULT '',
+ADD COLUMN created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW();
+
+ALTER TABLE chats
+ADD CONSTRAINT chats_participants_check CHECK (
+    (is_group AND user_1_id IS NULL AND user_2_id IS NULL)
+    OR (NOT is_group AND user_1_id IS NOT NULL AND user_2_id IS NOT NULL)
+);
+
+CREATE TABLE chat_members (
+    chat_id INTEGER NOT NULL REFERENCES chats(id) ON DELETE CASCADE,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    role VARCHAR(16) NOT NULL DEFAULT 'member' CHECK (role IN ('owner', 'admin', 'member')),
+    joined_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (chat_id, user_id)
+);
+
+CREATE INDEX idx_chat_members_user_id ON chat_members(user_id);
+
+-- У сообщений в группе нет единственного получателя
+ALTER TABLE messages ALTER COLUMN receiver_id DROP NOT NULL;
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS sender_key_bundles;
+DROP TABLE IF EXISTS sender_key_epochs;
+
+ALTER TABLE chats DROP COLUMN membership_version;
@@ -0,0 +1,28 @@
+-- Версия состава группы: увеличивается при каждом добавлении или удалении участника.
+-- Ключ отправителя, выпущенный для более старой версии, требует ротации.
+ALTER TABLE chats ADD COLUMN membership_version INTEGER NOT NULL DEFAULT 0;
+
+-- Эпохи ключей отправителей в группах
+CREATE TABLE sender_key_epochs (
+    chat_id INTEGER NOT NULL REFERENCES chats(id) ON DELETE CASCADE,
+    sender_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    epoch INTEGER NOT NULL CHECK (epoch > 0),
+    membership_version INTEGER NOT NULL, -- версия состава группы на момент выпуска ключа
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (chat_id, sender_id, epoch)
+);
+
+-- Ключи отправителей, зашифрованные для каждого получателя. Сервер хранит их как непрозрачные данные.
+CREATE TABLE sender_key_bundles (
+    chat_id INTEGER NOT NULL,
+    sender_id INTEGER NOT NULL,
+    epoch INTEGER NOT NULL,
+    recipient_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    wrapped_key BYTEA NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    delivered_at TIMESTAMP WITH TIME ZONE, -- когда получатель забрал ключ
+    PRIMARY KEY (chat_id, sender_id, epoch, recipient_id),
+    FOREIGN KEY (chat_id, sender_id, epoch) REFERENCES sender_key_epochs (chat_id, sender_id, epoch) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_sender_key_bundles_recipient ON sender_key_bundles(chat_id, recipient_id);
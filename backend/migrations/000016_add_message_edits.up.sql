@@ -0,0 +1,14 @@
+-- Отметки о редактировании и удалении сообщений. У удаленного сообщения остается только запись без текста.
+ALTER TABLE messages
+ADD COLUMN edited_at TIMESTAMP WITH TIME ZONE,
+ADD COLUMN deleted_at TIMESTAMP WITH TIME ZONE;
+
+-- Предыдущие версии отредактированных сообщений
+CREATE TABLE message_edits (
+    id SERIAL PRIMARY KEY,
+    message_id INTEGER NOT NULL REFERENCES messages(id) ON DELETE CASCADE,
+    content TEXT NOT NULL,
+    edited_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW() -- когда эта версия была заменена
+);
+
+CREATE INDEX idx_message_edits_message_id ON message_edits(message_id);
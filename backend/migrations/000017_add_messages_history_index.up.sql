@@ -0,0 +1,2 @@
+-- Постраничное чтение истории идет по (chat_id, id)
+CREATE INDEX IF NOT EXISTS idx_messages_chat_id_id ON messages(chat_id, id);
@@ -0,0 +1,12 @@
+-- Состояние сообщения для каждого получателя. Запись создается при отправке (состояние "отправлено"),
+-- delivered_at заполняется при доставке на устройство, read_at — при прочтении.
+CREATE TABLE message_receipts (
+    message_id INTEGER NOT NULL REFERENCES messages(id) ON DELETE CASCADE,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    delivered_at TIMESTAMP WITH TIME ZONE,
+    read_at TIMESTAMP WITH TIME ZONE,
+    PRIMARY KEY (message_id, user_id)
+);
+
+-- Подсчет непрочитанных сообщений пользователя
+CREATE INDEX idx_message_receipts_unread ON message_receipts(user_id) WHERE read_at IS NULL;
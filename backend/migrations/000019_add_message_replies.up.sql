@@ -0,0 +1,7 @@
+-- Ответы на сообщения. thread_root_id указывает на первое сообщение ветки,
+-- чтобы все ответы ветки выбирались одним запросом по индексу.
+ALTER TABLE messages
+ADD COLUMN reply_to_id INTEGER REFERENCES messages(id) ON DELETE SET NULL,
+ADD COLUMN thread_root_id INTEGER REFERENCES messages(id) ON DELETE SET NULL;
+
+CREATE INDEX idx_messages_thread_root_id ON messages(thread_root_id, id) WHERE thread_root_id IS NOT NULL;
@@ -0,0 +1,9 @@
+DROP TABLE IF EXISTS scheduled_messages;
+
+DROP INDEX IF EXISTS idx_messages_expires_at;
+
+ALTER TABLE messages
+DROP COLUMN IF EXISTS file_id,
+DROP COLUMN IF EXISTS expires_at;
+
+ALTER TABLE chats DROP COLUMN IF EXISTS message_ttl_seconds;
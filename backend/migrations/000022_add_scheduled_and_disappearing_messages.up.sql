@@ -0,0 +1,27 @@
+-- Срок жизни сообщений чата по умолчанию; NULL — сообщения не исчезают
+ALTER TABLE chats ADD COLUMN message_ttl_seconds INTEGER;
+
+-- Исчезающие сообщения удаляются вместе с приложенным файлом после expires_at
+ALTER TABLE messages
+ADD COLUMN expires_at TIMESTAMP WITH TIME ZONE,
+ADD COLUMN file_id VARCHAR(255);
+
+CREATE INDEX idx_messages_expires_at ON messages(expires_at) WHERE expires_at IS NOT NULL;
+
+-- Отложенные сообщения хранятся отдельно и попадают в messages только в момент отправки,
+-- поэтому ID в истории остаются упорядоченными по времени отправки
+CREATE TABLE scheduled_messages (
+    id SERIAL PRIMARY KEY,
+    chat_id INTEGER NOT NULL REFERENCES chats(id) ON DELETE CASCADE,
+    sender_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    receiver_id INTEGER REFERENCES users(id) ON DELETE CASCADE, -- NULL для сообщений в группе
+    content TEXT NOT NULL,
+    reply_to_id INTEGER REFERENCES messages(id) ON DELETE SET NULL,
+    thread_root_id INTEGER REFERENCES messages(id) ON DELETE SET NULL,
+    file_id VARCHAR(255),
+    ttl_seconds INTEGER, -- срок жизни после отправки; NULL — по настройке чата
+    deliver_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_scheduled_messages_deliver_at ON scheduled_messages(deliver_at);
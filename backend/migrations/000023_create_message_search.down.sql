@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_messages_file_id;
+DROP INDEX IF EXISTS idx_messages_sender_id;
+
+DROP TABLE IF EXISTS message_search_tokens;
@@ -0,0 +1,14 @@
+-- Слепой индекс для поиска по зашифрованным сообщениям: клиент загружает HMAC слов сообщения
+-- на своем ключе, сервер сравнивает токены, не видя текста. Индекс у каждого пользователя свой.
+CREATE TABLE message_search_tokens (
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    message_id INTEGER NOT NULL REFERENCES messages(id) ON DELETE CASCADE,
+    token BYTEA NOT NULL,
+    PRIMARY KEY (user_id, message_id, token)
+);
+
+CREATE INDEX idx_message_search_tokens_token ON message_search_tokens(user_id, token);
+
+-- Поиск по отправителю и по приложенным файлам
+CREATE INDEX idx_messages_sender_id ON messages(sender_id, id);
+CREATE INDEX idx_messages_file_id ON messages(file_id) WHERE file_id IS NOT NULL;
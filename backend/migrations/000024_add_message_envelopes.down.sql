@@ -0,0 +1,63 @@
+-- Тело с IV записано клиентом в двоичном виде и возвращается в текст в base64
+ALTER TABLE scheduled_messages
+ADD COLUMN content TEXT NOT NULL DEFAULT '',
+ADD COLUMN file_id VARCHAR(255);
+
+UPDATE scheduled_messages s SET
+	file_id = s.attachment_ids[1],
+	content = COALESCE((
+		SELECT '[FILE:' || f.file_id || ':' || f.file_name || ':' || f.size || ']'
+		FROM files f WHERE f.file_id = s.attachment_ids[1]
+	), '') || CASE WHEN s.iv IS NULL THEN convert_from(s.payload, 'UTF8') ELSE encode(s.payload, 'base64') END;
+
+ALTER TABLE scheduled_messages
+DROP COLUMN attachment_ids,
+DROP COLUMN cipher_padding,
+DROP COLUMN cipher_mode,
+DROP COLUMN cipher_algorithm,
+DROP COLUMN iv,
+DROP COLUMN payload,
+DROP COLUMN kind;
+
+ALTER TABLE message_edits ADD COLUMN content TEXT NOT NULL DEFAULT '';
+
+UPDATE message_edits SET
+	content = CASE WHEN iv IS NULL THEN convert_from(payload, 'UTF8') ELSE encode(payload, 'base64') END;
+
+ALTER TABLE message_edits
+DROP COLUMN cipher_padding,
+DROP COLUMN cipher_mode,
+DROP COLUMN cipher_algorithm,
+DROP COLUMN iv,
+DROP COLUMN payload;
+
+ALTER TABLE messages
+ADD COLUMN content TEXT NOT NULL DEFAULT '',
+ADD COLUMN file_id VARCHAR(255);
+
+-- Из нескольких вложений в префикс попадает только первое
+UPDATE messages m SET
+	file_id = a.file_id,
+	content = '[FILE:' || f.file_id || ':' || f.file_name || ':' || f.size || ']'
+FROM message_attachments a
+JOIN files f ON f.file_id = a.file_id
+WHERE a.message_id = m.id AND a.position = 0;
+
+UPDATE messages SET
+	content = content || CASE WHEN iv IS NULL THEN convert_from(payload, 'UTF8') ELSE encode(payload, 'base64') END;
+
+-- Сообщения, вложение которых не было перенесено, получают исходный текст обратно
+UPDATE messages SET content = legacy_content WHERE legacy_content IS NOT NULL;
+
+CREATE INDEX idx_messages_file_id ON messages(file_id) WHERE file_id IS NOT NULL;
+
+DROP TABLE IF EXISTS message_attachments;
+
+ALTER TABLE messages
+DROP COLUMN legacy_content,
+DROP COLUMN cipher_padding,
+DROP COLUMN cipher_mode,
+DROP COLUMN cipher_algorithm,
+DROP COLUMN iv,
+DROP COLUMN payload,
+DROP COLUMN kind;
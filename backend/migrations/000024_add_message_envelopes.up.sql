@@ -0,0 +1,86 @@
+-- Содержимое сообщения хранится как конверт: тип, вложения и зашифрованное тело с IV и параметрами шифра.
+-- Раньше вложение кодировалось в тексте префиксом [FILE:<file_id>:<имя>:<размер>].
+ALTER TABLE messages
+ADD COLUMN kind SMALLINT NOT NULL DEFAULT 0, -- 0 — текст, 1 — файл
+ADD COLUMN payload BYTEA NOT NULL DEFAULT '',
+ADD COLUMN iv BYTEA,
+ADD COLUMN cipher_algorithm VARCHAR(50) NOT NULL DEFAULT '', -- пусто — параметры шифрования чата
+ADD COLUMN cipher_mode VARCHAR(50) NOT NULL DEFAULT '',
+ADD COLUMN cipher_padding VARCHAR(50) NOT NULL DEFAULT '';
+
+-- Файлы, приложенные к сообщению; имя, размер и тип берутся из описания загруженного файла
+CREATE TABLE message_attachments (
+    message_id INTEGER NOT NULL REFERENCES messages(id) ON DELETE CASCADE,
+    position SMALLINT NOT NULL,
+    file_id VARCHAR(255) NOT NULL REFERENCES files(file_id) ON DELETE CASCADE,
+    PRIMARY KEY (message_id, position)
+);
+
+CREATE INDEX idx_message_attachments_file_id ON message_attachments(file_id);
+
+-- Имя файла в префиксе может содержать двоеточия, поэтому ID берется до первого двоеточия,
+-- а размер — после последнего. Вложение переносится, только если файл загружен в тот же чат.
+INSERT INTO message_attachments (message_id, position, file_id)
+SELECT m.id, 0, f.file_id
+FROM messages m
+JOIN files f ON f.chat_id = m.chat_id
+	AND f.file_id = COALESCE(m.file_id, substring(m.content from '^\[FILE:([^:\]]+):.*:[0-9]+\]'))
+WHERE m.content ~ '^\[FILE:[^:\]]+:.*:[0-9]+\]';
+
+-- Префикс, файл которого не найден в том же чате, не переносится во вложения. Исходный текст
+-- такого сообщения сохраняется в legacy_content, чтобы сведения о файле можно было восстановить.
+ALTER TABLE messages ADD COLUMN legacy_content TEXT;
+
+UPDATE messages m SET legacy_content = m.content
+WHERE m.content ~ '^\[FILE:[^:\]]+:.*:[0-9]+\]'
+	AND NOT EXISTS (SELECT 1 FROM message_attachments a WHERE a.message_id = m.id);
+
+DO $$
+DECLARE
+	skipped INTEGER;
+BEGIN
+	SELECT COUNT(*) INTO skipped FROM messages WHERE legacy_content IS NOT NULL;
+	IF skipped > 0 THEN
+		RAISE NOTICE '% legacy file messages were not migrated to attachments, see messages.legacy_content', skipped;
+	END IF;
+END $$;
+
+UPDATE messages SET payload = convert_to(regexp_replace(content, '^\[FILE:[^:\]]+:.*:[0-9]+\]', ''), 'UTF8');
+
+UPDATE messages m SET kind = 1
+WHERE EXISTS (SELECT 1 FROM message_attachments a WHERE a.message_id = m.id);
+
+ALTER TABLE messages
+DROP COLUMN content,
+DROP COLUMN file_id;
+
+-- Предыдущие версии хранят только тело сообщения: тип и вложения при правке не меняются
+ALTER TABLE message_edits
+ADD COLUMN payload BYTEA NOT NULL DEFAULT '',
+ADD COLUMN iv BYTEA,
+ADD COLUMN cipher_algorithm VARCHAR(50) NOT NULL DEFAULT '',
+ADD COLUMN cipher_mode VARCHAR(50) NOT NULL DEFAULT '',
+ADD COLUMN cipher_padding VARCHAR(50) NOT NULL DEFAULT '';
+
+UPDATE message_edits SET payload = convert_to(regexp_replace(content, '^\[FILE:[^:\]]+:.*:[0-9]+\]', ''), 'UTF8');
+
+ALTER TABLE message_edits DROP COLUMN content;
+
+-- Отложенные сообщения хранят ID вложений до отправки
+ALTER TABLE scheduled_messages
+ADD COLUMN kind SMALLINT NOT NULL DEFAULT 0,
+ADD COLUMN payload BYTEA NOT NULL DEFAULT '',
+ADD COLUMN iv BYTEA,
+ADD COLUMN cipher_algorithm VARCHAR(50) NOT NULL DEFAULT '',
+ADD COLUMN cipher_mode VARCHAR(50) NOT NULL DEFAULT '',
+ADD COLUMN cipher_padding VARCHAR(50) NOT NULL DEFAULT '',
+ADD COLUMN attachment_ids VARCHAR(255)[] NOT NULL DEFAULT '{}';
+
+UPDATE scheduled_messages SET
+	payload = convert_to(regexp_replace(content, '^\[FILE:[^:\]]+:.*:[0-9]+\]', ''), 'UTF8'),
+	kind = CASE WHEN file_id IS NULL THEN 0 ELSE 1 END,
+	attachment_ids = CASE WHEN file_id IS NULL THEN '{}' ELSE ARRAY[file_id] END;
+
+ALTER TABLE scheduled_messages
+DROP COLUMN content,
+DROP COLUMN file_id;
@@ -0,0 +1,10 @@
+-- Challenge-токены второго фактора: токен одноразовый, а число попыток ввести код ограничено
+CREATE TABLE totp_challenges (
+    id VARCHAR(64) PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    attempts SMALLINT NOT NULL DEFAULT 0,
+    used_at TIMESTAMP WITH TIME ZONE,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE INDEX idx_totp_challenges_user_id ON totp_challenges(user_id);
@@ -0,0 +1,3 @@
+-- Пользователь может скрыть время последнего посещения от других пользователей
+ALTER TABLE user_profiles
+ADD COLUMN hide_last_seen BOOLEAN NOT NULL DEFAULT FALSE;
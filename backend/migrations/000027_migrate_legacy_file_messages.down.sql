@@ -0,0 +1,11 @@
+-- Записи, созданные для файлов моста WebSocket, узнаются по пути без каталога чата и пустой контрольной сумме
+UPDATE messages m SET
+	kind = 0,
+	legacy_content = '[FILE:' || f.file_id || ':' || f.file_name || ':' || f.size || ']' || convert_from(m.payload, 'UTF8')
+FROM message_attachments a
+JOIN files f ON f.file_id = a.file_id
+WHERE a.message_id = m.id
+	AND f.path = 'storage/files/' || f.file_id
+	AND f.checksum = '';
+
+DELETE FROM files WHERE path = 'storage/files/' || file_id AND checksum = '';
@@ -0,0 +1,39 @@
+-- Файлы, загруженные через мост WebSocket до 000024, хранились на диске как storage/files/<file_id>
+-- без записи в files, и их сообщения остались с исходным текстом в legacy_content. Для таких файлов
+-- создается запись в чате сообщения от имени отправителя; тип файла на диске не сохранялся.
+INSERT INTO files (file_id, file_name, mime_type, size, path, uploaded_by, chat_id, checksum, created_at)
+SELECT DISTINCT ON (parsed.file_id)
+	parsed.file_id, parsed.file_name, 'application/octet-stream', parsed.size,
+	'storage/files/' || parsed.file_id, parsed.sender_id, parsed.chat_id, '', parsed.sent_at
+FROM (
+	SELECT m.id, m.chat_id, m.sender_id, m.sent_at,
+		substring(m.legacy_content from '^\[FILE:([^:\]]+):.*:[0-9]+\]') AS file_id,
+		substring(m.legacy_content from '^\[FILE:[^:\]]+:(.*):[0-9]+\]') AS file_name,
+		substring(m.legacy_content from '^\[FILE:[^:\]]+:.*:([0-9]+)\]')::BIGINT AS size
+	FROM messages m
+	WHERE m.legacy_content ~ '^\[FILE:[^:\]]+:.*:[0-9]+\]'
+) parsed
+ORDER BY parsed.file_id, parsed.id
+ON CONFLICT (file_id) DO NOTHING;
+
+-- Файл, уже записанный в другом чате, не прикладывается: участники этого чата не получат к нему доступ
+INSERT INTO message_attachments (message_id, position, file_id)
+SELECT m.id, 0, f.file_id
+FROM messages m
+JOIN files f ON f.chat_id = m.chat_id
+	AND f.file_id = substring(m.legacy_content from '^\[FILE:([^:\]]+):.*:[0-9]+\]')
+WHERE m.legacy_content ~ '^\[FILE:[^:\]]+:.*:[0-9]+\]';
+
+UPDATE messages m SET kind = 1, legacy_content = NULL
+WHERE m.legacy_content IS NOT NULL
+	AND EXISTS (SELECT 1 FROM message_attachments a WHERE a.message_id = m.id);
+
+DO $$
+DECLARE
+	skipped INTEGER;
+BEGIN
+	SELECT COUNT(*) INTO skipped FROM messages WHERE legacy_content IS NOT NULL;
+	IF skipped > 0 THEN
+		RAISE NOTICE '% legacy file messages reference files of other chats, see messages.legacy_content', skipped;
+	END IF;
+END $$;
@@ -0,0 +1,5 @@
+-- Хеш refresh-токена, замененного последней ротацией. Повторное предъявление этого токена
+-- означает, что он украден, и сессия отзывается.
+ALTER TABLE sessions ADD COLUMN previous_refresh_token_hash VARCHAR(255);
+
+CREATE INDEX idx_sessions_previous_refresh_token_hash ON sessions(previous_refresh_token_hash);
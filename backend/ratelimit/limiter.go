@@ -0,0 +1,36 @@
+package ratelimit
+
+import (
+	"context"
+	"time"
+)
+
+// Limiter ограничивает частоту запросов и блокирует ключи после серии неудачных попыток.
+// Ключ — произвольная строка, например "ip:10.0.0.1" или "user:alice".
+type Limiter interface {
+	// Take расходует один токен ключа. Если запрос нужно отклонить,
+	// возвращает время, через которое можно повторить попытку.
+	Take(ctx context.Context, key string) (time.Duration, error)
+
+	// Fail фиксирует неудачную попытку; после MaxFailures ключ блокируется на LockoutDuration
+	Fail(ctx context.Context, key string) error
+
+	// Reset сбрасывает счетчик неудачных попыток ключа
+	Reset(ctx context.Context, key string) error
+}
+
+// Config задает параметры token bucket и блокировки
+type Config struct {
+	Rate            float64       // пополнение токенов в секунду
+	Burst           float64       // емкость корзины
+	MaxFailures     int           // неудачных попыток до блокировки
+	LockoutDuration time.Duration // длительность блокировки
+}
+
+// DefaultConfig — 5 попыток подряд, затем одна раз в 6 секунд; блокировка на 15 минут после 5 ошибок
+var DefaultConfig = Config{
+	Rate:            1.0 / 6,
+	Burst:           5,
+	MaxFailures:     5,
+	LockoutDuration: 15 * time.Minute,
+}
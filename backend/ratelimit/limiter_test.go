@@ -0,0 +1,137 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+)
+
+// Пополнение раз в час, чтобы за время теста корзина заметно не восстанавливалась
+var testConfig = Config{
+	Rate:            1.0 / 3600,
+	Burst:           3,
+	MaxFailures:     3,
+	LockoutDuration: time.Minute,
+}
+
+type limiterOp int
+
+const (
+	opTake limiterOp = iota
+	opFail
+	opReset
+)
+
+type limiterStep struct {
+	op    limiterOp
+	key   string // пусто — основной ключ сценария
+	times int    // сколько раз повторить; 0 — один раз
+	// Допустимое время ожидания после последнего Take
+	minWait time.Duration
+	maxWait time.Duration
+}
+
+var limiterScenarios = []struct {
+	name  string
+	steps []limiterStep
+}{
+	{
+		name: "burst is allowed",
+		steps: []limiterStep{
+			{op: opTake, times: 3},
+		},
+	},
+	{
+		name: "request over burst waits for one token",
+		steps: []limiterStep{
+			{op: opTake, times: 3},
+			{op: opTake, minWait: 3590 * time.Second, maxWait: time.Hour},
+		},
+	},
+	{
+		// Отклоненный запрос не расходует токен, поэтому ожидание не растет
+		name: "rejections do not extend the wait",
+		steps: []limiterStep{
+			{op: opTake, times: 3},
+			{op: opTake, times: 5, minWait: 3590 * time.Second, maxWait: time.Hour},
+		},
+	},
+	{
+		name: "failures below the limit do not lock",
+		steps: []limiterStep{
+			{op: opFail, times: 2},
+			{op: opTake},
+		},
+	},
+	{
+		name: "lockout after max failures",
+		steps: []limiterStep{
+			{op: opFail, times: 3},
+			{op: opTake, minWait: 50 * time.Second, maxWait: time.Minute},
+		},
+	},
+	{
+		name: "reset lifts the lockout",
+		steps: []limiterStep{
+			{op: opFail, times: 3},
+			{op: opReset},
+			{op: opTake},
+		},
+	},
+	{
+		name: "keys are independent",
+		steps: []limiterStep{
+			{op: opTake, times: 3},
+			{op: opFail, times: 3},
+			{op: opTake, key: "other", times: 3},
+			{op: opTake, minWait: time.Second, maxWait: time.Hour},
+		},
+	},
+}
+
+// runLimiterScenarios проверяет общее поведение ограничителя; новый ограничитель создается на каждый сценарий
+func runLimiterScenarios(t *testing.T, newLimiter func(t *testing.T, cfg Config) Limiter) {
+	ctx := context.Background()
+
+	for _, scenario := range limiterScenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			limiter := newLimiter(t, testConfig)
+			// Ключи уникальны, чтобы сценарии не делили строки в общей базе
+			prefix := fmt.Sprintf("test:%s:%d:", t.Name(), time.Now().UnixNano())
+
+			for i, step := range scenario.steps {
+				key := prefix + "main"
+				if step.key != "" {
+					key = prefix + step.key
+				}
+
+				times := step.times
+				if times == 0 {
+					times = 1
+				}
+
+				for n := 0; n < times; n++ {
+					switch step.op {
+					case opTake:
+						wait, err := limiter.Take(ctx, key)
+						if err != nil {
+							t.Fatalf("step %d: Take: %v", i, err)
+						}
+						if wait < step.minWait || wait > step.maxWait {
+							t.Fatalf("step %d, take %d: wait %v, want between %v and %v", i, n+1, wait, step.minWait, step.maxWait)
+						}
+					case opFail:
+						if err := limiter.Fail(ctx, key); err != nil {
+							t.Fatalf("step %d: Fail: %v", i, err)
+						}
+					case opReset:
+						if err := limiter.Reset(ctx, key); err != nil {
+							t.Fatalf("step %d: Reset: %v", i, err)
+						}
+					}
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,117 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+const sweepInterval = time.Minute
+
+type bucket struct {
+	tokens      float64
+	updatedAt   time.Time
+	failures    int
+	lockedUntil time.Time
+}
+
+type memoryLimiter struct {
+	cfg       Config
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+}
+
+// NewMemoryLimiter создает ограничитель, хранящий состояние в памяти процесса.
+// Подходит для одного экземпляра сервера.
+func NewMemoryLimiter(cfg Config) Limiter {
+	return &memoryLimiter{
+		cfg:       cfg,
+		buckets:   make(map[string]*bucket),
+		lastSweep: time.Now(),
+	}
+}
+
+func (l *memoryLimiter) Take(ctx context.Context, key string) (time.Duration, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	l.sweep(now)
+
+	b := l.refill(key, now)
+
+	if now.Before(b.lockedUntil) {
+		return b.lockedUntil.Sub(now), nil
+	}
+
+	if b.tokens < 1 {
+		wait := (1 - b.tokens) / l.cfg.Rate
+		return time.Duration(math.Ceil(wait * float64(time.Second))), nil
+	}
+
+	b.tokens--
+	return 0, nil
+}
+
+func (l *memoryLimiter) Fail(ctx context.Context, key string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	b := l.refill(key, now)
+
+	b.failures++
+	if b.failures >= l.cfg.MaxFailures {
+		b.lockedUntil = now.Add(l.cfg.LockoutDuration)
+		b.failures = 0
+	}
+
+	return nil
+}
+
+func (l *memoryLimiter) Reset(ctx context.Context, key string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if b, ok := l.buckets[key]; ok {
+		b.failures = 0
+		b.lockedUntil = time.Time{}
+	}
+
+	return nil
+}
+
+// refill возвращает корзину ключа, пополненную на момент now
+func (l *memoryLimiter) refill(key string, now time.Time) *bucket {
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{tokens: l.cfg.Burst, updatedAt: now}
+		l.buckets[key] = b
+		return b
+	}
+
+	elapsed := now.Sub(b.updatedAt).Seconds()
+	b.tokens = math.Min(l.cfg.Burst, b.tokens+elapsed*l.cfg.Rate)
+	b.updatedAt = now
+
+	return b
+}
+
+// sweep удаляет корзины, которые полностью восстановились и не заблокированы.
+// Неудачные попытки забываются, если ключ не использовался дольше LockoutDuration.
+func (l *memoryLimiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < sweepInterval {
+		return
+	}
+	l.lastSweep = now
+
+	for key, b := range l.buckets {
+		idle := now.Sub(b.updatedAt)
+		full := b.tokens+idle.Seconds()*l.cfg.Rate >= l.cfg.Burst
+		if full && now.After(b.lockedUntil) && (b.failures == 0 || idle > l.cfg.LockoutDuration) {
+			delete(l.buckets, key)
+		}
+	}
+}
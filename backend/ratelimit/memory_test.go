@@ -0,0 +1,9 @@
+package ratelimit
+
+import "testing"
+
+func TestMemoryLimiter(t *testing.T) {
+	runLimiterScenarios(t, func(t *testing.T, cfg Config) Limiter {
+		return NewMemoryLimiter(cfg)
+	})
+}
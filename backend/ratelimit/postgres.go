@@ -0,0 +1,98 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type postgresLimiter struct {
+	cfg Config
+	db  *sqlx.DB
+}
+
+// NewPostgresLimiter создает ограничитель, хранящий состояние в таблице rate_limits.
+// Состояние общее для всех экземпляров сервера, подключенных к одной базе.
+func NewPostgresLimiter(db *sqlx.DB, cfg Config) Limiter {
+	return &postgresLimiter{cfg: cfg, db: db}
+}
+
+func (l *postgresLimiter) Take(ctx context.Context, key string) (time.Duration, error) {
+	// Пополнение и списание токена выполняются одним запросом, как в памяти: баланс пополняется
+	// всегда, а токен списывается, только если запрос разрешен. Запрос возвращает баланс до списания.
+	// Если строку ключа одновременно вставил другой запрос, bucket пуст и токен просто списывается.
+	query := `
+		WITH bucket AS (
+			SELECT
+				LEAST($2, tokens + EXTRACT(EPOCH FROM NOW() - updated_at) * $3) AS tokens,
+				COALESCE(locked_until > NOW(), FALSE) AS locked,
+				GREATEST(EXTRACT(EPOCH FROM locked_until - NOW()), 0) AS locked_for
+			FROM rate_limits
+			WHERE key = $1
+			FOR UPDATE
+		), taken AS (
+			INSERT INTO rate_limits (key, tokens, updated_at)
+			VALUES ($1, $2 - 1, NOW())
+			ON CONFLICT (key) DO UPDATE SET
+				tokens = COALESCE(
+					(SELECT CASE WHEN NOT locked AND tokens >= 1 THEN tokens - 1 ELSE tokens END FROM bucket),
+					rate_limits.tokens - 1
+				),
+				updated_at = NOW()
+		)
+		SELECT
+			COALESCE((SELECT tokens FROM bucket), $2),
+			COALESCE((SELECT locked_for FROM bucket), 0)
+	`
+
+	var tokens, lockedFor float64
+	err := l.db.QueryRowxContext(ctx, query, key, l.cfg.Burst, l.cfg.Rate).Scan(&tokens, &lockedFor)
+	if err != nil {
+		return 0, fmt.Errorf("failed to take rate limit token: %w", err)
+	}
+
+	if lockedFor > 0 {
+		return time.Duration(math.Ceil(lockedFor * float64(time.Second))), nil
+	}
+
+	if tokens < 1 {
+		wait := (1 - tokens) / l.cfg.Rate
+		return time.Duration(math.Ceil(wait * float64(time.Second))), nil
+	}
+
+	return 0, nil
+}
+
+func (l *postgresLimiter) Fail(ctx context.Context, key string) error {
+	query := `
+		INSERT INTO rate_limits (key, tokens, updated_at, failures)
+		VALUES ($1, $2, NOW(), 1)
+		ON CONFLICT (key) DO UPDATE SET
+			failures = CASE WHEN rate_limits.failures + 1 >= $3 THEN 0 ELSE rate_limits.failures + 1 END,
+			locked_until = CASE
+				WHEN rate_limits.failures + 1 >= $3 THEN NOW() + make_interval(secs => $4)
+				ELSE rate_limits.locked_until
+			END
+	`
+
+	_, err := l.db.ExecContext(ctx, query, key, l.cfg.Burst, l.cfg.MaxFailures, l.cfg.LockoutDuration.Seconds())
+	if err != nil {
+		return fmt.Errorf("failed to record failed attempt: %w", err)
+	}
+
+	return nil
+}
+
+func (l *postgresLimiter) Reset(ctx context.Context, key string) error {
+	query := `UPDATE rate_limits SET failures = 0, locked_until = NULL WHERE key = $1`
+
+	_, err := l.db.ExecContext(ctx, query, key)
+	if err != nil {
+		return fmt.Errorf("failed to reset rate limit: %w", err)
+	}
+
+	return nil
+}
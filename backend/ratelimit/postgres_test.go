@@ -0,0 +1,27 @@
+package ratelimit
+
+import (
+	"os"
+	"testing"
+
+	"github.com/jmoiron/sqlx"
+	_ "github.com/lib/pq"
+)
+
+// TestPostgresLimiter запускается только с TEST_DATABASE_URL, указывающим на базу с примененными миграциями
+func TestPostgresLimiter(t *testing.T) {
+	dsn := os.Getenv("TEST_DATABASE_URL")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_URL is not set")
+	}
+
+	db, err := sqlx.Connect("postgres", dsn)
+	if err != nil {
+		t.Fatalf("failed to connect to database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	runLimiterScenarios(t, func(t *testing.T, cfg Config) Limiter {
+		return NewPostgresLimiter(db, cfg)
+	})
+}
@@ -0,0 +1,206 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"gRPCWebServer/backend/entities"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// AccountRepository интерфейс для удаления аккаунта и выгрузки данных пользователя
+type AccountRepository interface {
+	// Удаляет пользователя вместе с его чатами, сообщениями, обменами ключами и файлами.
+	// Возвращает пути файлов и ID чатов, чтобы вызывающий удалил данные с диска.
+	Delete(ctx context.Context, userID uint64) (filePaths []string, chatIDs []uint64, err error)
+
+	// Возвращает чаты пользователя вместе с параметрами шифрования
+	ListChats(ctx context.Context, userID uint64) ([]entities.Chat, error)
+
+	// Возвращает группы, в которых состоит пользователь
+	ListGroups(ctx context.Context, userID uint64) ([]entities.Group, error)
+
+	// Возвращает все сообщения чата в порядке отправки
+	ListMessages(ctx context.Context, chatID uint64) ([]entities.Message, error)
+
+	// Возвращает личные файлы пользователя, файлы из его чатов и групп и файлы старых сообщений
+	// его чатов, загруженные через WebSocket в другой чат
+	ListFiles(ctx context.Context, userID uint64) ([]entities.File, error)
+}
+
+type accountRepository struct {
+	db *sqlx.DB
+}
+
+// NewAccountRepository создает новый экземпляр репозитория аккаунтов
+func NewAccountRepository(db *sqlx.DB) AccountRepository {
+	return &accountRepository{db: db}
+}
+
+const userChatsCondition = `SELECT id FROM chats WHERE user_1_id = $1 OR user_2_id = $1`
+
+const userGroupsCondition = `SELECT chat_id FROM chat_members WHERE user_id = $1`
+
+// Delete удаляет пользователя и все связанные с ним записи в одной транзакции.
+// Сессии, профиль, 2FA, контакты и блокировки удаляются каскадно вместе с пользователем.
+func (r *accountRepository) Delete(ctx context.Context, userID uint64) ([]string, []uint64, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var chatIDs []uint64
+	if err := tx.SelectContext(ctx, &chatIDs, userChatsCondition, userID); err != nil {
+		return nil, nil, fmt.Errorf("failed to get chats: %w", err)
+	}
+
+	var filePaths []string
+	query := `SELECT path FROM files WHERE uploaded_by = $1 OR chat_id IN (` + userChatsCondition + `)`
+	if err := tx.SelectContext(ctx, &filePaths, query, userID); err != nil {
+		return nil, nil, fmt.Errorf("failed to get files: %w", err)
+	}
+
+	var tempPaths []string
+	query = `SELECT temp_path FROM file_uploads WHERE user_id = $1 OR chat_id IN (` + userChatsCondition + `)`
+	if err := tx.SelectContext(ctx, &tempPaths, query, userID); err != nil {
+		return nil, nil, fmt.Errorf("failed to get file uploads: %w", err)
+	}
+	filePaths = append(filePaths, tempPaths...)
+
+	var groupIDs []uint64
+	if err := tx.SelectContext(ctx, &groupIDs, `SELECT chat_id FROM chat_members WHERE user_id = $1`, userID); err != nil {
+		return nil, nil, fmt.Errorf("failed to get groups: %w", err)
+	}
+
+	statements := []string{
+		`DELETE FROM file_uploads WHERE user_id = $1 OR chat_id IN (` + userChatsCondition + `)`,
+		`DELETE FROM files WHERE uploaded_by = $1 OR chat_id IN (` + userChatsCondition + `)`,
+		`DELETE FROM dh_key_exchanges WHERE initiator_id = $1 OR recipient_id = $1`,
+		`DELETE FROM chats WHERE user_1_id = $1 OR user_2_id = $1`,
+		`DELETE FROM chat_members WHERE user_id = $1`,
+	}
+	for _, statement := range statements {
+		if _, err := tx.ExecContext(ctx, statement, userID); err != nil {
+			return nil, nil, fmt.Errorf("failed to delete account: %w", err)
+		}
+	}
+
+	// Группы остаются остальным участникам; опустевшие группы удаляются
+	for _, groupID := range groupIDs {
+		groupPaths, deleted, err := settleGroupAfterLeave(ctx, tx, groupID)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		filePaths = append(filePaths, groupPaths...)
+		if deleted {
+			chatIDs = append(chatIDs, groupID)
+		}
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM users WHERE id = $1`, userID); err != nil {
+		return nil, nil, fmt.Errorf("failed to delete account: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return filePaths, chatIDs, nil
+}
+
+// ListChats возвращает чаты пользователя
+func (r *accountRepository) ListChats(ctx context.Context, userID uint64) ([]entities.Chat, error) {
+	query := `
+	SELECT
+		c.id, c.user_1_id AS first_user_id, c.user_2_id AS second_user_id,
+		u1.username AS first_username, u2.username AS second_username,
+		COALESCE(c.encryption_algorithm, '') AS encryption_algorithm,
+		COALESCE(c.encryption_mode, '') AS encryption_mode,
+		COALESCE(c.encryption_padding, '') AS encryption_padding
+	FROM chats c
+	JOIN users u1 ON u1.id = c.user_1_id
+	JOIN users u2 ON u2.id = c.user_2_id
+	WHERE c.user_1_id = $1 OR c.user_2_id = $1
+	ORDER BY c.id`
+
+	var chats []entities.Chat
+	if err := r.db.SelectContext(ctx, &chats, query, userID); err != nil {
+		return nil, fmt.Errorf("failed to list chats: %w", err)
+	}
+
+	return chats, nil
+}
+
+// ListGroups возвращает группы пользователя
+func (r *accountRepository) ListGroups(ctx context.Context, userID uint64) ([]entities.Group, error) {
+	query := `
+		SELECT c.id, c.title, c.encryption_algorithm, c.encryption_mode, c.encryption_padding, m.role, c.created_at
+		FROM chats c
+		JOIN chat_members m ON m.chat_id = c.id
+		WHERE m.user_id = $1 AND c.is_group
+		ORDER BY c.id
+	`
+
+	var groups []entities.Group
+	if err := r.db.SelectContext(ctx, &groups, query, userID); err != nil {
+		return nil, fmt.Errorf("failed to list groups: %w", err)
+	}
+
+	return groups, nil
+}
+
+// ListMessages возвращает все сообщения чата
+func (r *accountRepository) ListMessages(ctx context.Context, chatID uint64) ([]entities.Message, error) {
+	query := `
+		SELECT id, chat_id, sender_id, COALESCE(receiver_id, 0) AS receiver_id, timestamp,
+			kind, payload, iv, cipher_algorithm, cipher_mode, cipher_padding
+		FROM messages
+		WHERE chat_id = $1
+		ORDER BY timestamp, id
+	`
+
+	var messages []entities.Message
+	if err := r.db.SelectContext(ctx, &messages, query, chatID); err != nil {
+		return nil, fmt.Errorf("failed to list messages: %w", err)
+	}
+
+	page := make([]*entities.Message, 0, len(messages))
+	for i := range messages {
+		page = append(page, &messages[i])
+	}
+	if err := loadAttachments(ctx, r.db, page); err != nil {
+		return nil, err
+	}
+
+	return messages, nil
+}
+
+// ListFiles возвращает файлы, доступные пользователю
+func (r *accountRepository) ListFiles(ctx context.Context, userID uint64) ([]entities.File, error) {
+	query := `
+		SELECT id, file_id, file_name, mime_type, size, path, uploaded_by, chat_id, checksum, created_at
+		FROM files
+		WHERE (
+			uploaded_by = $1
+			OR chat_id IN (` + userChatsCondition + `)
+			OR chat_id IN (` + userGroupsCondition + `)
+			-- 000027 не приложила к сообщению файл, записанный в другом чате: он остался в legacy_content
+			OR file_id IN (
+				SELECT substring(m.legacy_content from '^\[FILE:([^:\]]+):.*:[0-9]+\]')
+				FROM messages m
+				WHERE m.legacy_content IS NOT NULL
+					AND (m.chat_id IN (` + userChatsCondition + `) OR m.chat_id IN (` + userGroupsCondition + `))
+			)
+		) AND deleted_at IS NULL
+		ORDER BY created_at
+	`
+
+	var files []entities.File
+	if err := r.db.SelectContext(ctx, &files, query, userID); err != nil {
+		return nil, fmt.Errorf("failed to list files: %w", err)
+	}
+
+	return files, nil
+}
@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"gRPCWebServer/backend/entities"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// BlockRepository интерфейс для работы со списком заблокированных пользователей
+type BlockRepository interface {
+	// Блокирует пользователя; повторная блокировка ничего не меняет
+	Block(ctx context.Context, blockerID, blockedID uint64) error
+
+	// Снимает блокировку; возвращает false, если пользователь не был заблокирован
+	Unblock(ctx context.Context, blockerID, blockedID uint64) (bool, error)
+
+	// Возвращает профили пользователей, заблокированных пользователем
+	ListBlocked(ctx context.Context, blockerID uint64) ([]entities.UserProfile, error)
+
+	// Проверяет, заблокировал ли кто-либо из двух пользователей другого
+	IsBlocked(ctx context.Context, userID1, userID2 uint64) (bool, error)
+}
+
+type blockRepository struct {
+	db *sqlx.DB
+}
+
+// NewBlockRepository создает новый экземпляр репозитория блокировок
+func NewBlockRepository(db *sqlx.DB) BlockRepository {
+	return &blockRepository{db: db}
+}
+
+// Block блокирует пользователя
+func (r *blockRepository) Block(ctx context.Context, blockerID, blockedID uint64) error {
+	query := `INSERT INTO user_blocks (blocker_id, blocked_id) VALUES ($1, $2) ON CONFLICT DO NOTHING`
+
+	if _, err := r.db.ExecContext(ctx, query, blockerID, blockedID); err != nil {
+		return fmt.Errorf("failed to block user: %w", err)
+	}
+
+	return nil
+}
+
+// Unblock снимает блокировку
+func (r *blockRepository) Unblock(ctx context.Context, blockerID, blockedID uint64) (bool, error) {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM user_blocks WHERE blocker_id = $1 AND blocked_id = $2`, blockerID, blockedID)
+	if err != nil {
+		return false, fmt.Errorf("failed to unblock user: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rowsAffected > 0, nil
+}
+
+// ListBlocked возвращает профили заблокированных пользователей
+func (r *blockRepository) ListBlocked(ctx context.Context, blockerID uint64) ([]entities.UserProfile, error) {
+	query := profileSelect + `
+		JOIN user_blocks b ON b.blocked_id = u.id
+		WHERE b.blocker_id = $1
+		ORDER BY b.created_at DESC
+	`
+
+	var profiles []entities.UserProfile
+	if err := r.db.SelectContext(ctx, &profiles, query, blockerID); err != nil {
+		return nil, fmt.Errorf("failed to list blocked users: %w", err)
+	}
+
+	return profiles, nil
+}
+
+// IsBlocked проверяет блокировку в любом направлении
+func (r *blockRepository) IsBlocked(ctx context.Context, userID1, userID2 uint64) (bool, error) {
+	query := `
+		SELECT EXISTS (
+			SELECT 1 FROM user_blocks
+			WHERE (blocker_id = $1 AND blocked_id = $2) OR (blocker_id = $2 AND blocked_id = $1)
+		)
+	`
+
+	var blocked bool
+	if err := r.db.GetContext(ctx, &blocked, query, userID1, userID2); err != nil {
+		return false, fmt.Errorf("failed to check block: %w", err)
+	}
+
+	return blocked, nil
+}
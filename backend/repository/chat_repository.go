@@ -2,6 +2,8 @@ package repository
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
 	"gRPCWebServer/backend/entities"
 
@@ -14,8 +16,11 @@ type ChatRepository interface {
 	GetChatsByUserId(ctx context.Context, userId uint64) ([]entities.ChatInfoDTO, error)
 	SendMessage(ctx context.Context, chatId, senderId uint64, content string) error
 	DeleteChat(ctx context.Context, chatId uint64) error
-	GetChatByUsername(ctx context.Context, username string) (*entities.Chat, error)
+	// Личный чат пользователя userID с пользователем username; nil, если чата нет
+	GetChatByUsername(ctx context.Context, userID uint64, username string) (*entities.Chat, error)
+	// Личный или групповой чат по ID; nil, если чата нет
 	GetChatByID(ctx context.Context, chatID uint64) (*entities.Chat, error)
+	SetMessageTTL(ctx context.Context, chatID uint64, ttlSeconds int) error
 }
 
 type chatRepository struct {
@@ -59,10 +64,15 @@ func (cr *chatRepository) GetChatsByUserId(ctx context.Context, userId uint64) (
 		END AS username,
 		c.encryption_algorithm,
 		c.encryption_mode,
-		c.encryption_padding
+		c.encryption_padding,
+		COALESCE(p.display_name, '') AS display_name,
+		p.avatar_file_id,
+		(` + unreadCountQuery + `) AS unread_count,
+		c.message_ttl_seconds
 	FROM chats c
 	JOIN users u1 ON u1.id = c.user_1_id
 	JOIN users u2 ON u2.id = c.user_2_id
+	LEFT JOIN user_profiles p ON p.user_id = CASE WHEN c.user_1_id = $1 THEN c.user_2_id ELSE c.user_1_id END
 	WHERE c.user_1_id = $1 OR c.user_2_id = $1`
 
 	err := cr.db.SelectContext(ctx, &chats, query, userId)
@@ -102,38 +112,56 @@ func (cr *chatRepository) SendMessage(ctx context.Context, chatId, senderId uint
 	return nil
 }
 
-func (cr *chatRepository) GetChatByUsername(ctx context.Context, username string) (*entities.Chat, error) {
+func (cr *chatRepository) GetChatByUsername(ctx context.Context, userID uint64, username string) (*entities.Chat, error) {
 	query := `
 	SELECT 
 		c.id, c.user_1_id as first_user_id, c.user_2_id as second_user_id,
-		u1.username as first_username, u2.username as second_username
+		u1.username as first_username, u2.username as second_username, c.is_group
 	FROM chats c
 	JOIN users u1 ON u1.id = c.user_1_id
 	JOIN users u2 ON u2.id = c.user_2_id
-	WHERE u1.username = $1 OR u2.username = $1`
+	WHERE NOT c.is_group
+		AND ((c.user_1_id = $1 AND u2.username = $2) OR (c.user_2_id = $1 AND u1.username = $2))`
 
 	var chat entities.Chat
-	if err := cr.db.GetContext(ctx, &chat, query, username); err != nil {
+	if err := cr.db.GetContext(ctx, &chat, query, userID, username); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
 		return nil, fmt.Errorf("failed to get chat by username: %w", err)
 	}
 
 	return &chat, nil
 }
 
+// GetChatByID возвращает личный или групповой чат; у группы нет пары участников, и их поля пусты
 func (cr *chatRepository) GetChatByID(ctx context.Context, chatID uint64) (*entities.Chat, error) {
 	query := `
 	SELECT 
-		c.id, c.user_1_id as first_user_id, c.user_2_id as second_user_id,
-		u1.username as first_username, u2.username as second_username
+		c.id, COALESCE(c.user_1_id, 0) as first_user_id, COALESCE(c.user_2_id, 0) as second_user_id,
+		COALESCE(u1.username, '') as first_username, COALESCE(u2.username, '') as second_username, c.is_group
 	FROM chats c
-	JOIN users u1 ON u1.id = c.user_1_id
-	JOIN users u2 ON u2.id = c.user_2_id
+	LEFT JOIN users u1 ON u1.id = c.user_1_id
+	LEFT JOIN users u2 ON u2.id = c.user_2_id
 	WHERE c.id = $1`
 
 	var chat entities.Chat
 	if err := cr.db.GetContext(ctx, &chat, query, chatID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
 		return nil, fmt.Errorf("failed to get chat by ID: %w", err)
 	}
 
 	return &chat, nil
 }
+
+// SetMessageTTL задает срок жизни новых сообщений чата; 0 отключает исчезающие сообщения
+func (cr *chatRepository) SetMessageTTL(ctx context.Context, chatID uint64, ttlSeconds int) error {
+	query := `UPDATE chats SET message_ttl_seconds = NULLIF($2, 0) WHERE id = $1`
+	if _, err := cr.db.ExecContext(ctx, query, chatID, ttlSeconds); err != nil {
+		return fmt.Errorf("failed to set message ttl: %w", err)
+	}
+
+	return nil
+}
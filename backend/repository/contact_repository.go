@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"gRPCWebServer/backend/entities"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// ContactRepository интерфейс для работы со списком контактов
+type ContactRepository interface {
+	// Добавляет пользователя в контакты; повторное добавление ничего не меняет
+	Add(ctx context.Context, userID, contactID uint64) error
+
+	// Удаляет пользователя из контактов; возвращает false, если его там не было
+	Remove(ctx context.Context, userID, contactID uint64) (bool, error)
+
+	// Возвращает профили контактов пользователя
+	List(ctx context.Context, userID uint64) ([]entities.UserProfile, error)
+}
+
+type contactRepository struct {
+	db *sqlx.DB
+}
+
+// NewContactRepository создает новый экземпляр репозитория контактов
+func NewContactRepository(db *sqlx.DB) ContactRepository {
+	return &contactRepository{db: db}
+}
+
+// Add добавляет пользователя в контакты
+func (r *contactRepository) Add(ctx context.Context, userID, contactID uint64) error {
+	query := `INSERT INTO contacts (user_id, contact_id) VALUES ($1, $2) ON CONFLICT DO NOTHING`
+
+	if _, err := r.db.ExecContext(ctx, query, userID, contactID); err != nil {
+		return fmt.Errorf("failed to add contact: %w", err)
+	}
+
+	return nil
+}
+
+// Remove удаляет пользователя из контактов
+func (r *contactRepository) Remove(ctx context.Context, userID, contactID uint64) (bool, error) {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM contacts WHERE user_id = $1 AND contact_id = $2`, userID, contactID)
+	if err != nil {
+		return false, fmt.Errorf("failed to remove contact: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rowsAffected > 0, nil
+}
+
+// List возвращает профили контактов, отсортированные по имени
+func (r *contactRepository) List(ctx context.Context, userID uint64) ([]entities.UserProfile, error) {
+	query := profileSelect + `
+		JOIN contacts c ON c.contact_id = u.id
+		WHERE c.user_id = $1
+		ORDER BY COALESCE(NULLIF(p.display_name, ''), u.username)
+	`
+
+	var profiles []entities.UserProfile
+	if err := r.db.SelectContext(ctx, &profiles, query, userID); err != nil {
+		return nil, fmt.Errorf("failed to list contacts: %w", err)
+	}
+
+	return profiles, nil
+}
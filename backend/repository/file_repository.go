@@ -22,6 +22,8 @@ type FileRepository interface {
 	GetFilesByChat(ctx context.Context, chatID uint64, page, pageSize int) ([]*entities.File, int, error)
 	DeleteFile(ctx context.Context, fileID string) error
 	UpdateFilePath(ctx context.Context, fileID string, newPath string) error
+	// IsProfileAvatar сообщает, стоит ли файл аватаром в чьем-либо профиле
+	IsProfileAvatar(ctx context.Context, fileID string) (bool, error)
 }
 
 type fileRepository struct {
@@ -202,3 +204,16 @@ func (fr *fileRepository) UpdateFilePath(ctx context.Context, fileID string, new
 	_, err := fr.db.ExecContext(ctx, query, newPath, fileID)
 	return err
 }
+
+// IsProfileAvatar проверяет, указан ли файл аватаром хотя бы в одном профиле
+func (fr *fileRepository) IsProfileAvatar(ctx context.Context, fileID string) (bool, error) {
+	query := `SELECT EXISTS (SELECT 1 FROM user_profiles WHERE avatar_file_id = $1)`
+
+	var exists bool
+	err := fr.db.GetContext(ctx, &exists, query, fileID)
+	if err != nil {
+		return false, err
+	}
+
+	return exists, nil
+}
@@ -0,0 +1,319 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"gRPCWebServer/backend/entities"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// GroupRepository интерфейс для работы с групповыми чатами и их участниками
+type GroupRepository interface {
+	// Создает группу; создатель становится владельцем, остальные — участниками
+	CreateGroup(ctx context.Context, ownerID uint64, title, encAlgorithm, encMode, encPadding string, memberIDs []uint64) (uint64, error)
+
+	// Получает группу по ID; nil, если группа не найдена
+	GetGroup(ctx context.Context, groupID uint64) (*entities.Group, error)
+
+	// Возвращает группы пользователя вместе с его ролью в каждой
+	ListByUser(ctx context.Context, userID uint64) ([]entities.Group, error)
+
+	// Получает участника группы; nil, если пользователь не состоит в группе
+	GetMember(ctx context.Context, groupID, userID uint64) (*entities.GroupMember, error)
+
+	// Возвращает участников группы
+	ListMembers(ctx context.Context, groupID uint64) ([]entities.GroupMember, error)
+
+	// Возвращает количество участников группы
+	CountMembers(ctx context.Context, groupID uint64) (int, error)
+
+	// Добавляет участника; повторное добавление ничего не меняет
+	AddMember(ctx context.Context, groupID, userID uint64, role string) error
+
+	// Удаляет участника. Если уходит владелец, владельцем становится самый давний администратор
+	// или участник; группа без участников удаляется. Возвращает пути файлов удаленной группы,
+	// чтобы вызывающий удалил их с диска.
+	RemoveMember(ctx context.Context, groupID, userID uint64) (removed bool, filePaths []string, err error)
+
+	// Меняет роль участника
+	SetRole(ctx context.Context, groupID, userID uint64, role string) error
+
+	// Передает владение группой другому участнику; прежний владелец становится администратором
+	TransferOwnership(ctx context.Context, groupID, fromUserID, toUserID uint64) error
+}
+
+type groupRepository struct {
+	db *sqlx.DB
+}
+
+// NewGroupRepository создает новый экземпляр репозитория групп
+func NewGroupRepository(db *sqlx.DB) GroupRepository {
+	return &groupRepository{db: db}
+}
+
+// CreateGroup создает группу вместе с участниками в одной транзакции
+func (r *groupRepository) CreateGroup(ctx context.Context, ownerID uint64, title, encAlgorithm, encMode, encPadding string, memberIDs []uint64) (uint64, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var groupID uint64
+	query := `
+		INSERT INTO chats (is_group, title, encryption_algorithm, encryption_mode, encryption_padding)
+		VALUES (TRUE, $1, $2, $3, $4)
+		RETURNING id
+	`
+	if err := tx.GetContext(ctx, &groupID, query, title, encAlgorithm, encMode, encPadding); err != nil {
+		return 0, fmt.Errorf("failed to create group: %w", err)
+	}
+
+	memberQuery := `INSERT INTO chat_members (chat_id, user_id, role) VALUES ($1, $2, $3) ON CONFLICT DO NOTHING`
+	if _, err := tx.ExecContext(ctx, memberQuery, groupID, ownerID, entities.RoleOwner); err != nil {
+		return 0, fmt.Errorf("failed to add group owner: %w", err)
+	}
+
+	for _, memberID := range memberIDs {
+		if _, err := tx.ExecContext(ctx, memberQuery, groupID, memberID, entities.RoleMember); err != nil {
+			return 0, fmt.Errorf("failed to add group member: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return groupID, nil
+}
+
+// GetGroup получает группу по ID
+func (r *groupRepository) GetGroup(ctx context.Context, groupID uint64) (*entities.Group, error) {
+	query := `
+		SELECT id, title, encryption_algorithm, encryption_mode, encryption_padding, created_at
+		FROM chats
+		WHERE id = $1 AND is_group
+	`
+
+	var group entities.Group
+	if err := r.db.GetContext(ctx, &group, query, groupID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get group: %w", err)
+	}
+
+	return &group, nil
+}
+
+// ListByUser возвращает группы, в которых состоит пользователь
+func (r *groupRepository) ListByUser(ctx context.Context, userID uint64) ([]entities.Group, error) {
+	query := `
+		SELECT c.id, c.title, c.encryption_algorithm, c.encryption_mode, c.encryption_padding, m.role, c.created_at,
+			(` + unreadCountQuery + `) AS unread_count, c.message_ttl_seconds
+		FROM chats c
+		JOIN chat_members m ON m.chat_id = c.id
+		WHERE m.user_id = $1 AND c.is_group
+		ORDER BY c.created_at
+	`
+
+	var groups []entities.Group
+	if err := r.db.SelectContext(ctx, &groups, query, userID); err != nil {
+		return nil, fmt.Errorf("failed to list groups: %w", err)
+	}
+
+	return groups, nil
+}
+
+// GetMember получает участника группы
+func (r *groupRepository) GetMember(ctx context.Context, groupID, userID uint64) (*entities.GroupMember, error) {
+	query := `
+		SELECT m.chat_id, m.user_id, u.username, m.role, m.joined_at
+		FROM chat_members m
+		JOIN users u ON u.id = m.user_id
+		WHERE m.chat_id = $1 AND m.user_id = $2
+	`
+
+	var member entities.GroupMember
+	if err := r.db.GetContext(ctx, &member, query, groupID, userID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get group member: %w", err)
+	}
+
+	return &member, nil
+}
+
+// ListMembers возвращает участников группы: сначала владелец и администраторы
+func (r *groupRepository) ListMembers(ctx context.Context, groupID uint64) ([]entities.GroupMember, error) {
+	query := `
+		SELECT m.chat_id, m.user_id, u.username, m.role, m.joined_at
+		FROM chat_members m
+		JOIN users u ON u.id = m.user_id
+		WHERE m.chat_id = $1
+		ORDER BY CASE m.role WHEN 'owner' THEN 0 WHEN 'admin' THEN 1 ELSE 2 END, m.joined_at
+	`
+
+	var members []entities.GroupMember
+	if err := r.db.SelectContext(ctx, &members, query, groupID); err != nil {
+		return nil, fmt.Errorf("failed to list group members: %w", err)
+	}
+
+	return members, nil
+}
+
+// CountMembers возвращает количество участников группы
+func (r *groupRepository) CountMembers(ctx context.Context, groupID uint64) (int, error) {
+	var count int
+	if err := r.db.GetContext(ctx, &count, `SELECT COUNT(*) FROM chat_members WHERE chat_id = $1`, groupID); err != nil {
+		return 0, fmt.Errorf("failed to count group members: %w", err)
+	}
+
+	return count, nil
+}
+
+// AddMember добавляет участника в группу и увеличивает версию ее состава
+func (r *groupRepository) AddMember(ctx context.Context, groupID, userID uint64, role string) error {
+	query := `
+		WITH inserted AS (
+			INSERT INTO chat_members (chat_id, user_id, role) VALUES ($1, $2, $3)
+			ON CONFLICT DO NOTHING
+			RETURNING chat_id
+		)
+		UPDATE chats SET membership_version = membership_version + 1
+		WHERE id IN (SELECT chat_id FROM inserted)
+	`
+
+	if _, err := r.db.ExecContext(ctx, query, groupID, userID, role); err != nil {
+		return fmt.Errorf("failed to add group member: %w", err)
+	}
+
+	return nil
+}
+
+// Назначает владельцем самого давнего администратора, а если их нет — самого давнего участника.
+// $1 — ID группы; выполняется, только если в группе не осталось владельца.
+const promoteSuccessorQuery = `
+	UPDATE chat_members SET role = 'owner'
+	WHERE chat_id = $1
+	AND NOT EXISTS (SELECT 1 FROM chat_members WHERE chat_id = $1 AND role = 'owner')
+	AND user_id = (
+		SELECT user_id FROM chat_members
+		WHERE chat_id = $1
+		ORDER BY role = 'admin' DESC, joined_at
+		LIMIT 1
+	)
+`
+
+// RemoveMember удаляет участника и при необходимости назначает нового владельца
+func (r *groupRepository) RemoveMember(ctx context.Context, groupID, userID uint64) (bool, []string, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return false, nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, `DELETE FROM chat_members WHERE chat_id = $1 AND user_id = $2`, groupID, userID)
+	if err != nil {
+		return false, nil, fmt.Errorf("failed to remove group member: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, nil, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return false, nil, nil
+	}
+
+	filePaths, _, err := settleGroupAfterLeave(ctx, tx, groupID)
+	if err != nil {
+		return false, nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return true, filePaths, nil
+}
+
+const membershipChangedQuery = `UPDATE chats SET membership_version = membership_version + 1 WHERE id = $1`
+
+// settleGroupAfterLeave вызывается после ухода участника: увеличивает версию состава группы,
+// назначает нового владельца, а если участников не осталось — удаляет группу вместе с ее файлами.
+// Возвращает пути удаленных файлов и признак удаления группы.
+func settleGroupAfterLeave(ctx context.Context, tx *sqlx.Tx, groupID uint64) ([]string, bool, error) {
+	// Ушедший участник не должен получать новые ключи отправителей
+	if _, err := tx.ExecContext(ctx, membershipChangedQuery, groupID); err != nil {
+		return nil, false, fmt.Errorf("failed to update group membership version: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, promoteSuccessorQuery, groupID); err != nil {
+		return nil, false, fmt.Errorf("failed to promote new group owner: %w", err)
+	}
+
+	var count int
+	if err := tx.GetContext(ctx, &count, `SELECT COUNT(*) FROM chat_members WHERE chat_id = $1`, groupID); err != nil {
+		return nil, false, fmt.Errorf("failed to count group members: %w", err)
+	}
+
+	if count > 0 {
+		return nil, false, nil
+	}
+
+	var filePaths []string
+	query := `SELECT path FROM files WHERE chat_id = $1 UNION ALL SELECT temp_path FROM file_uploads WHERE chat_id = $1`
+	if err := tx.SelectContext(ctx, &filePaths, query, groupID); err != nil {
+		return nil, false, fmt.Errorf("failed to get group files: %w", err)
+	}
+
+	statements := []string{
+		`DELETE FROM file_uploads WHERE chat_id = $1`,
+		`DELETE FROM files WHERE chat_id = $1`,
+		`DELETE FROM chats WHERE id = $1 AND is_group`,
+	}
+	for _, statement := range statements {
+		if _, err := tx.ExecContext(ctx, statement, groupID); err != nil {
+			return nil, false, fmt.Errorf("failed to delete empty group: %w", err)
+		}
+	}
+
+	return filePaths, true, nil
+}
+
+// SetRole меняет роль участника
+func (r *groupRepository) SetRole(ctx context.Context, groupID, userID uint64, role string) error {
+	query := `UPDATE chat_members SET role = $3 WHERE chat_id = $1 AND user_id = $2`
+
+	if _, err := r.db.ExecContext(ctx, query, groupID, userID, role); err != nil {
+		return fmt.Errorf("failed to set group member role: %w", err)
+	}
+
+	return nil
+}
+
+// TransferOwnership передает владение группой
+func (r *groupRepository) TransferOwnership(ctx context.Context, groupID, fromUserID, toUserID uint64) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `UPDATE chat_members SET role = $3 WHERE chat_id = $1 AND user_id = $2`
+	if _, err := tx.ExecContext(ctx, query, groupID, fromUserID, entities.RoleAdmin); err != nil {
+		return fmt.Errorf("failed to demote group owner: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, query, groupID, toUserID, entities.RoleOwner); err != nil {
+		return fmt.Errorf("failed to promote group owner: %w", err)
+	}
+
+	return tx.Commit()
+}
@@ -2,15 +2,50 @@ package repository
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
 	"gRPCWebServer/backend/entities"
+	"strings"
+	"time"
 
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 )
 
 type MessageRepository interface {
 	SaveMessage(message *entities.Message) error
-	GetHistory(ctx context.Context, chatId uint64, limit int) ([]entities.Message, error)
+	// Возвращает страницу истории от старых сообщений к новым и признак того,
+	// что в направлении листания есть еще сообщения
+	GetHistory(ctx context.Context, query entities.HistoryQuery) ([]entities.Message, bool, error)
+
+	// Получает сообщение по ID; nil, если сообщение не найдено
+	GetByID(ctx context.Context, messageId uint64) (*entities.Message, error)
+
+	// Заменяет тело сообщения, его IV и параметры шифра, сохраняя предыдущую версию;
+	// возвращает время редактирования
+	Edit(ctx context.Context, messageId uint64, envelope entities.Envelope) (time.Time, error)
+
+	// Удаляет тело сообщения, вложения, историю правок, реакции, закрепление и отметки избранного,
+	// оставляя запись-надгробие
+	Delete(ctx context.Context, messageId uint64) (time.Time, error)
+
+	// Возвращает предыдущие версии сообщения от старых к новым
+	ListEdits(ctx context.Context, messageId uint64) ([]entities.MessageEdit, error)
+
+	// Сохраняет сообщение для отправки в deliverAt и заполняет его ScheduledID
+	Schedule(ctx context.Context, message *entities.Message, deliverAt time.Time) error
+
+	// Переносит в messages до limit отложенных сообщений, время которых наступило, и возвращает их.
+	// Сообщения тех, кто вышел из группы или оказался заблокирован, отбрасываются.
+	ReleaseDue(ctx context.Context, limit int) ([]entities.Message, error)
+
+	// Удаляет до limit истекших исчезающих сообщений вместе с приложенными файлами.
+	// Возвращает число удаленных сообщений и пути файлов, чтобы вызывающий удалил их с диска.
+	DeleteExpired(ctx context.Context, limit int) (int, []string, error)
+
+	// Возвращает описания файлов, загруженных в чат, в порядке fileIds; остальные файлы пропускаются
+	FindAttachments(ctx context.Context, chatId uint64, fileIds []string) ([]entities.Attachment, error)
 }
 
 type messageRepository struct {
@@ -32,44 +67,409 @@ func NewMessageRepository(db *sqlx.DB) *messageRepository {
 
 //		return messageId, nil
 //	}
+
+// saveMessageQuery сохраняет сообщение вместе с вложениями и записями о его состоянии для получателя
+// или участников группы. У сообщений в группе нет получателя: ReceiverId == 0 сохраняется как NULL.
+// Срок жизни задает отправитель, а если он его не задал — настройка чата. Вложения, файлов которых
+// уже нет в чате (например, удаленных до отправки отложенного сообщения), пропускаются.
+const saveMessageQuery = `WITH inserted AS (
+		  	INSERT INTO messages (chat_id, sender_id, receiver_id, kind, payload, iv, cipher_algorithm, cipher_mode, cipher_padding,
+		  		timestamp, reply_to_id, thread_root_id, expires_at)
+		  	SELECT $1, $2, NULLIF($3, 0), $4, COALESCE($5, ''::bytea), $6, $7, $8, $9, $10, NULLIF($11, 0), NULLIF($12, 0),
+		  		$10::timestamptz + COALESCE(NULLIF($13, 0), c.message_ttl_seconds) * INTERVAL '1 second'
+		  	FROM chats c WHERE c.id = $1
+		  	RETURNING id, chat_id, sender_id, receiver_id, expires_at
+		  ), receipts AS (
+		  	INSERT INTO message_receipts (message_id, user_id)
+		  	SELECT id, receiver_id FROM inserted WHERE receiver_id IS NOT NULL
+		  	UNION ALL
+		  	SELECT i.id, cm.user_id FROM inserted i
+		  	JOIN chat_members cm ON cm.chat_id = i.chat_id
+		  	WHERE i.receiver_id IS NULL AND cm.user_id <> i.sender_id
+		  ), attachments AS (
+		  	INSERT INTO message_attachments (message_id, position, file_id)
+		  	SELECT i.id, a.position - 1, a.file_id
+		  	FROM inserted i
+		  	CROSS JOIN unnest($14::varchar[]) WITH ORDINALITY AS a(file_id, position)
+		  	JOIN files f ON f.file_id = a.file_id AND f.chat_id = i.chat_id AND f.deleted_at IS NULL
+		  )
+		  SELECT id, expires_at FROM inserted`
+
 func (mr *messageRepository) SaveMessage(message *entities.Message) error {
-	query := `INSERT INTO messages (chat_id, sender_id, receiver_id, content, timestamp)
-			  VALUES ($1, $2, $3, $4, $5)`
-	_, err := mr.db.Exec(query, message.ChatID, message.SenderId, message.ReceiverId, message.Content, message.Timestamp)
-	if err != nil {
-		return err
+	return saveMessage(context.Background(), mr.db, message)
+}
+
+// saveMessage сохраняет сообщение и заполняет его ID и время удаления
+func saveMessage(ctx context.Context, q sqlx.QueryerContext, message *entities.Message) error {
+	return q.QueryRowxContext(ctx, saveMessageQuery, message.ChatID, message.SenderId, message.ReceiverId,
+		message.Kind, message.Payload, message.IV, message.CipherAlgorithm, message.CipherMode, message.CipherPadding,
+		message.Timestamp, message.ReplyToID, message.ThreadRootID, message.TTLSeconds, attachmentIDs(message.Attachments)).
+		Scan(&message.ID, &message.ExpiresAt)
+}
+
+func attachmentIDs(attachments []entities.Attachment) pq.StringArray {
+	ids := make(pq.StringArray, 0, len(attachments))
+	for _, attachment := range attachments {
+		ids = append(ids, attachment.FileID)
+	}
+
+	return ids
+}
+
+// loadAttachments заполняет вложения сообщений описаниями загруженных файлов
+func loadAttachments(ctx context.Context, q sqlx.QueryerContext, messages []*entities.Message) error {
+	if len(messages) == 0 {
+		return nil
+	}
+
+	ids := make([]int64, 0, len(messages))
+	byID := make(map[uint64]*entities.Message, len(messages))
+	for _, message := range messages {
+		ids = append(ids, int64(message.ID))
+		byID[message.ID] = message
+	}
+
+	query := `SELECT a.message_id, f.file_id, f.file_name, f.size, f.mime_type
+			  FROM message_attachments a
+			  JOIN files f ON f.file_id = a.file_id
+			  WHERE a.message_id = ANY($1)
+			  ORDER BY a.message_id, a.position`
+
+	var attachments []entities.Attachment
+	if err := sqlx.SelectContext(ctx, q, &attachments, query, pq.Array(ids)); err != nil {
+		return fmt.Errorf("failed to load message attachments: %v", err)
+	}
+
+	for _, attachment := range attachments {
+		message := byID[attachment.MessageID]
+		message.Attachments = append(message.Attachments, attachment)
 	}
 
 	return nil
 }
 
-func (mr *messageRepository) GetHistory(ctx context.Context, chatId uint64, limit int) ([]entities.Message, error) {
-	query := `SELECT id, chat_id, sender_id, receiver_id, content, timestamp
-			  FROM (
-			  		SELECT id, chat_id, sender_id, receiver_id, content, timestamp
-					FROM messages WHERE chat_id = $1 ORDER BY timestamp DESC LIMIT $2
-					) subquery
-			   ORDER BY timestamp ASC;`
+func (mr *messageRepository) GetHistory(ctx context.Context, query entities.HistoryQuery) ([]entities.Message, bool, error) {
+	// Истекшие исчезающие сообщения скрыты, даже если планировщик еще не успел их удалить
+	conditions := []string{"m.chat_id = $1", "(m.expires_at IS NULL OR m.expires_at > NOW())"}
+	args := []interface{}{query.ChatID}
 
-	rows, err := mr.db.QueryContext(ctx, query, chatId, limit)
-	if err != nil {
-		return nil, fmt.Errorf("failed to query messages: %v", err)
+	addCondition := func(condition string, arg interface{}) {
+		args = append(args, arg)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
 	}
 
-	defer rows.Close()
+	if query.ThreadRootID != 0 {
+		addCondition("m.thread_root_id = $%d", query.ThreadRootID)
+	}
+	if query.BeforeID != 0 {
+		addCondition("m.id < $%d", query.BeforeID)
+	}
+	if query.AfterID != 0 {
+		addCondition("m.id > $%d", query.AfterID)
+	}
+	if query.Since != nil {
+		addCondition("m.timestamp >= $%d", *query.Since)
+	}
+	if query.Until != nil {
+		addCondition("m.timestamp < $%d", *query.Until)
+	}
+
+	// Вперед листаем только от after_id; в остальных случаях берем самые новые сообщения страницы
+	forward := query.AfterID != 0 && query.BeforeID == 0
+	order := "DESC"
+	if forward {
+		order = "ASC"
+	}
+
+	// Лишняя запись показывает, есть ли еще сообщения за пределами страницы
+	args = append(args, query.Limit+1)
+	sqlQuery := fmt.Sprintf(`SELECT m.id, m.chat_id, m.sender_id, u.username AS sender_username,
+			  	COALESCE(m.receiver_id, 0) AS receiver_id, m.timestamp, m.edited_at, m.deleted_at,
+			  	m.kind, m.payload, m.iv, m.cipher_algorithm, m.cipher_mode, m.cipher_padding,
+			  	COALESCE(m.reply_to_id, 0) AS reply_to_id, COALESCE(m.thread_root_id, 0) AS thread_root_id, m.expires_at,
+			  	(SELECT COUNT(*) FROM messages t WHERE t.thread_root_id = m.id) AS reply_count,
+			  	(SELECT CASE
+			  		WHEN bool_and(r.read_at IS NOT NULL) THEN %d
+			  		WHEN bool_and(r.delivered_at IS NOT NULL) THEN %d
+			  		ELSE %d
+			  	END FROM message_receipts r WHERE r.message_id = m.id) AS status
+			  FROM messages m
+			  JOIN users u ON u.id = m.sender_id
+			  WHERE %s
+			  ORDER BY m.id %s
+			  LIMIT $%d`, entities.MessageRead, entities.MessageDelivered, entities.MessageSent,
+		strings.Join(conditions, " AND "), order, len(args))
 
 	var messages []entities.Message
-	for rows.Next() {
-		var message entities.Message
-		if err := rows.Scan(&message.ID, &message.ChatID, &message.SenderId, &message.ReceiverId, &message.Content, &message.Timestamp); err != nil {
-			return nil, fmt.Errorf("failed to scan message: %v", err)
+	if err := mr.db.SelectContext(ctx, &messages, sqlQuery, args...); err != nil {
+		return nil, false, fmt.Errorf("failed to query messages: %v", err)
+	}
+
+	hasMore := len(messages) > query.Limit
+	if hasMore {
+		messages = messages[:query.Limit]
+	}
+
+	if !forward {
+		for i, j := 0, len(messages)-1; i < j; i, j = i+1, j-1 {
+			messages[i], messages[j] = messages[j], messages[i]
+		}
+	}
+
+	page := make([]*entities.Message, 0, len(messages))
+	for i := range messages {
+		page = append(page, &messages[i])
+	}
+	if err := loadAttachments(ctx, mr.db, page); err != nil {
+		return nil, false, err
+	}
+
+	return messages, hasMore, nil
+}
+
+func (mr *messageRepository) GetByID(ctx context.Context, messageId uint64) (*entities.Message, error) {
+	query := `SELECT id, chat_id, sender_id, COALESCE(receiver_id, 0) AS receiver_id, timestamp, edited_at, deleted_at,
+			  	kind, payload, iv, cipher_algorithm, cipher_mode, cipher_padding,
+			  	COALESCE(reply_to_id, 0) AS reply_to_id, COALESCE(thread_root_id, 0) AS thread_root_id, expires_at
+			  FROM messages WHERE id = $1`
+
+	var message entities.Message
+	if err := mr.db.GetContext(ctx, &message, query, messageId); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
 		}
-		messages = append(messages, message)
+		return nil, fmt.Errorf("failed to get message: %v", err)
 	}
 
-	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("error iterating over rows: %v", err)
+	if err := loadAttachments(ctx, mr.db, []*entities.Message{&message}); err != nil {
+		return nil, err
+	}
+
+	return &message, nil
+}
+
+func (mr *messageRepository) Edit(ctx context.Context, messageId uint64, envelope entities.Envelope) (time.Time, error) {
+	tx, err := mr.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to begin transaction: %v", err)
+	}
+	defer tx.Rollback()
+
+	query := `INSERT INTO message_edits (message_id, payload, iv, cipher_algorithm, cipher_mode, cipher_padding)
+			  SELECT id, payload, iv, cipher_algorithm, cipher_mode, cipher_padding
+			  FROM messages WHERE id = $1 AND deleted_at IS NULL`
+	if _, err := tx.ExecContext(ctx, query, messageId); err != nil {
+		return time.Time{}, fmt.Errorf("failed to save previous message version: %v", err)
+	}
+
+	var editedAt time.Time
+	query = `UPDATE messages
+			 SET payload = COALESCE($2, ''::bytea), iv = $3, cipher_algorithm = $4, cipher_mode = $5, cipher_padding = $6,
+			 	edited_at = NOW()
+			 WHERE id = $1 AND deleted_at IS NULL
+			 RETURNING edited_at`
+	if err := tx.GetContext(ctx, &editedAt, query, messageId, envelope.Payload, envelope.IV,
+		envelope.CipherAlgorithm, envelope.CipherMode, envelope.CipherPadding); err != nil {
+		return time.Time{}, fmt.Errorf("failed to edit message: %v", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return time.Time{}, fmt.Errorf("failed to commit transaction: %v", err)
+	}
+
+	return editedAt, nil
+}
+
+func (mr *messageRepository) Delete(ctx context.Context, messageId uint64) (time.Time, error) {
+	tx, err := mr.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to begin transaction: %v", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM message_edits WHERE message_id = $1`, messageId); err != nil {
+		return time.Time{}, fmt.Errorf("failed to delete message edits: %v", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM message_reactions WHERE message_id = $1`, messageId); err != nil {
+		return time.Time{}, fmt.Errorf("failed to delete message reactions: %v", err)
+	}
+
+	// Удаленное сообщение больше нельзя найти ни среди закрепленных, ни в избранном
+	if _, err := tx.ExecContext(ctx, `DELETE FROM pinned_messages WHERE message_id = $1`, messageId); err != nil {
+		return time.Time{}, fmt.Errorf("failed to unpin message: %v", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM starred_messages WHERE message_id = $1`, messageId); err != nil {
+		return time.Time{}, fmt.Errorf("failed to unstar message: %v", err)
+	}
+
+	// Файлы остаются в чате, но к надгробию больше не приложены
+	if _, err := tx.ExecContext(ctx, `DELETE FROM message_attachments WHERE message_id = $1`, messageId); err != nil {
+		return time.Time{}, fmt.Errorf("failed to delete message attachments: %v", err)
+	}
+
+	var deletedAt time.Time
+	query := `UPDATE messages
+			  SET payload = '', iv = NULL, cipher_algorithm = '', cipher_mode = '', cipher_padding = '',
+			  	deleted_at = COALESCE(deleted_at, NOW())
+			  WHERE id = $1
+			  RETURNING deleted_at`
+	if err := tx.GetContext(ctx, &deletedAt, query, messageId); err != nil {
+		return time.Time{}, fmt.Errorf("failed to delete message: %v", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return time.Time{}, fmt.Errorf("failed to commit transaction: %v", err)
+	}
+
+	return deletedAt, nil
+}
+
+func (mr *messageRepository) ListEdits(ctx context.Context, messageId uint64) ([]entities.MessageEdit, error) {
+	query := `SELECT message_id, payload, iv, cipher_algorithm, cipher_mode, cipher_padding, edited_at FROM message_edits
+			  WHERE message_id = $1 ORDER BY edited_at, id`
+
+	var edits []entities.MessageEdit
+	if err := mr.db.SelectContext(ctx, &edits, query, messageId); err != nil {
+		return nil, fmt.Errorf("failed to list message edits: %v", err)
+	}
+
+	return edits, nil
+}
+
+func (mr *messageRepository) Schedule(ctx context.Context, message *entities.Message, deliverAt time.Time) error {
+	query := `INSERT INTO scheduled_messages (chat_id, sender_id, receiver_id, kind, payload, iv, cipher_algorithm, cipher_mode, cipher_padding,
+			  	attachment_ids, reply_to_id, thread_root_id, ttl_seconds, deliver_at)
+			  VALUES ($1, $2, NULLIF($3, 0), $4, COALESCE($5, ''::bytea), $6, $7, $8, $9, $10, NULLIF($11, 0), NULLIF($12, 0), NULLIF($13, 0), $14)
+			  RETURNING id`
+
+	err := mr.db.GetContext(ctx, &message.ScheduledID, query, message.ChatID, message.SenderId, message.ReceiverId,
+		message.Kind, message.Payload, message.IV, message.CipherAlgorithm, message.CipherMode, message.CipherPadding,
+		attachmentIDs(message.Attachments), message.ReplyToID, message.ThreadRootID, message.TTLSeconds, deliverAt)
+	if err != nil {
+		return fmt.Errorf("failed to schedule message: %v", err)
+	}
+
+	return nil
+}
+
+func (mr *messageRepository) ReleaseDue(ctx context.Context, limit int) ([]entities.Message, error) {
+	tx, err := mr.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %v", err)
+	}
+	defer tx.Rollback()
+
+	// SKIP LOCKED позволяет нескольким экземплярам сервера разбирать отложенные сообщения, не мешая друг другу
+	query := `SELECT id AS scheduled_id, chat_id, sender_id, COALESCE(receiver_id, 0) AS receiver_id,
+			  	kind, payload, iv, cipher_algorithm, cipher_mode, cipher_padding, attachment_ids,
+			  	COALESCE(reply_to_id, 0) AS reply_to_id, COALESCE(thread_root_id, 0) AS thread_root_id,
+			  	COALESCE(ttl_seconds, 0) AS ttl_seconds
+			  FROM scheduled_messages
+			  WHERE deliver_at <= NOW()
+			  ORDER BY deliver_at, id
+			  LIMIT $1
+			  FOR UPDATE SKIP LOCKED`
+
+	var due []struct {
+		entities.Message
+		AttachmentIDs pq.StringArray `db:"attachment_ids"`
+	}
+	if err := tx.SelectContext(ctx, &due, query, limit); err != nil {
+		return nil, fmt.Errorf("failed to get due scheduled messages: %v", err)
+	}
+
+	deliverableQuery := `SELECT CASE WHEN $3 = 0
+			  	THEN EXISTS (SELECT 1 FROM chat_members WHERE chat_id = $1 AND user_id = $2)
+			  	ELSE NOT EXISTS (
+			  		SELECT 1 FROM user_blocks
+			  		WHERE (blocker_id = $2 AND blocked_id = $3) OR (blocker_id = $3 AND blocked_id = $2)
+			  	)
+			  END`
+
+	released := make([]entities.Message, 0, len(due))
+	for _, scheduled := range due {
+		message := scheduled.Message
+		if _, err := tx.ExecContext(ctx, `DELETE FROM scheduled_messages WHERE id = $1`, message.ScheduledID); err != nil {
+			return nil, fmt.Errorf("failed to delete scheduled message: %v", err)
+		}
+
+		var deliverable bool
+		if err := tx.GetContext(ctx, &deliverable, deliverableQuery, message.ChatID, message.SenderId, message.ReceiverId); err != nil {
+			return nil, fmt.Errorf("failed to check scheduled message recipients: %v", err)
+		}
+		if !deliverable {
+			continue
+		}
+
+		for _, fileId := range scheduled.AttachmentIDs {
+			message.Attachments = append(message.Attachments, entities.Attachment{FileID: fileId})
+		}
+
+		message.Timestamp = time.Now()
+		if err := saveMessage(ctx, tx, &message); err != nil {
+			return nil, fmt.Errorf("failed to save scheduled message: %v", err)
+		}
+		message.Attachments = nil
+		released = append(released, message)
+	}
+
+	// Описания вложений берутся из сохраненных сообщений: файлы могли удалить до отправки
+	messages := make([]*entities.Message, 0, len(released))
+	for i := range released {
+		messages = append(messages, &released[i])
+	}
+	if err := loadAttachments(ctx, tx, messages); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %v", err)
+	}
+
+	return released, nil
+}
+
+func (mr *messageRepository) DeleteExpired(ctx context.Context, limit int) (int, []string, error) {
+	// Файл удаляется, только если он загружен в тот же чат, что и сообщение.
+	// Вложения читаются до удаления сообщений: все части запроса видят один снимок данных.
+	query := `WITH expired AS (
+			  	DELETE FROM messages WHERE id IN (
+			  		SELECT id FROM messages
+			  		WHERE expires_at <= NOW()
+			  		ORDER BY expires_at
+			  		LIMIT $1
+			  		FOR UPDATE SKIP LOCKED
+			  	)
+			  	RETURNING id, chat_id
+			  ), deleted_files AS (
+			  	DELETE FROM files f USING expired e, message_attachments a
+			  	WHERE a.message_id = e.id AND f.file_id = a.file_id AND f.chat_id = e.chat_id
+			  	RETURNING f.path
+			  )
+			  SELECT (SELECT COUNT(*) FROM expired), COALESCE((SELECT array_agg(path) FROM deleted_files), '{}')`
+
+	var count int
+	var paths []string
+	if err := mr.db.QueryRowxContext(ctx, query, limit).Scan(&count, pq.Array(&paths)); err != nil {
+		return 0, nil, fmt.Errorf("failed to delete expired messages: %v", err)
+	}
+
+	return count, paths, nil
+}
+
+func (mr *messageRepository) FindAttachments(ctx context.Context, chatId uint64, fileIds []string) ([]entities.Attachment, error) {
+	query := `SELECT f.file_id, f.file_name, f.size, f.mime_type
+			  FROM unnest($2::varchar[]) WITH ORDINALITY AS a(file_id, position)
+			  JOIN files f ON f.file_id = a.file_id
+			  WHERE f.chat_id = $1 AND f.deleted_at IS NULL
+			  ORDER BY a.position`
+
+	var attachments []entities.Attachment
+	if err := mr.db.SelectContext(ctx, &attachments, query, chatId, pq.StringArray(fileIds)); err != nil {
+		return nil, fmt.Errorf("failed to find attachments: %v", err)
 	}
 
-	return messages, nil
+	return attachments, nil
 }
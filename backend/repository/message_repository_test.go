@@ -0,0 +1,176 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"gRPCWebServer/backend/entities"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+// testDB подключается к базе из TEST_DATABASE_URL с примененными миграциями; без нее тест пропускается
+func testDB(t *testing.T) *sqlx.DB {
+	t.Helper()
+
+	dsn := os.Getenv("TEST_DATABASE_URL")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_URL is not set")
+	}
+
+	db, err := sqlx.Connect("postgres", dsn)
+	if err != nil {
+		t.Fatalf("failed to connect to database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	return db
+}
+
+// testFixture создает пользователей и чаты теста и удаляет их вместе с сообщениями после теста
+type testFixture struct {
+	t       *testing.T
+	db      *sqlx.DB
+	userIds []int64
+}
+
+func newTestFixture(t *testing.T, db *sqlx.DB) *testFixture {
+	f := &testFixture{t: t, db: db}
+	t.Cleanup(func() {
+		if _, err := db.Exec(`DELETE FROM users WHERE id = ANY($1)`, pq.Array(f.userIds)); err != nil {
+			t.Errorf("failed to delete test users: %v", err)
+		}
+	})
+
+	return f
+}
+
+func (f *testFixture) exec(query string, args ...interface{}) {
+	f.t.Helper()
+
+	if _, err := f.db.Exec(query, args...); err != nil {
+		f.t.Fatalf("failed to prepare test data: %v", err)
+	}
+}
+
+func (f *testFixture) user(name string) uint64 {
+	f.t.Helper()
+
+	var id int64
+	username := fmt.Sprintf("test-%s-%d", name, time.Now().UnixNano())
+	if err := f.db.Get(&id, `INSERT INTO users (username, password_hash) VALUES ($1, '') RETURNING id`, username); err != nil {
+		f.t.Fatalf("failed to create user: %v", err)
+	}
+	f.userIds = append(f.userIds, id)
+
+	return uint64(id)
+}
+
+func (f *testFixture) directChat(user1, user2 uint64) uint64 {
+	f.t.Helper()
+
+	var id uint64
+	if err := f.db.Get(&id, `INSERT INTO chats (user_1_id, user_2_id) VALUES ($1, $2) RETURNING id`, user1, user2); err != nil {
+		f.t.Fatalf("failed to create chat: %v", err)
+	}
+
+	return id
+}
+
+func (f *testFixture) group(members ...uint64) uint64 {
+	f.t.Helper()
+
+	var id uint64
+	if err := f.db.Get(&id, `INSERT INTO chats (is_group, title) VALUES (TRUE, 'test') RETURNING id`); err != nil {
+		f.t.Fatalf("failed to create group: %v", err)
+	}
+	for _, userId := range members {
+		f.exec(`INSERT INTO chat_members (chat_id, user_id) VALUES ($1, $2)`, id, userId)
+	}
+
+	return id
+}
+
+func TestMessageRepositoryReleaseDue(t *testing.T) {
+	db := testDB(t)
+	f := newTestFixture(t, db)
+	repo := NewMessageRepository(db)
+	ctx := context.Background()
+
+	sender := f.user("sender")
+	friend := f.user("friend")
+	blockedBySender := f.user("blocked")
+	blocker := f.user("blocker")
+
+	f.exec(`INSERT INTO user_blocks (blocker_id, blocked_id) VALUES ($1, $2)`, sender, blockedBySender)
+	f.exec(`INSERT INTO user_blocks (blocker_id, blocked_id) VALUES ($1, $2)`, blocker, sender)
+
+	past := time.Now().Add(-time.Minute)
+	tests := []struct {
+		name         string
+		chatId       uint64
+		receiverId   uint64
+		deliverAt    time.Time
+		wantReleased bool
+		wantKept     bool // остается в scheduled_messages
+	}{
+		{"direct chat", f.directChat(sender, friend), friend, past, true, false},
+		{"receiver blocked by sender", f.directChat(sender, blockedBySender), blockedBySender, past, false, false},
+		{"sender blocked by receiver", f.directChat(sender, blocker), blocker, past, false, false},
+		{"group member", f.group(sender, friend), 0, past, true, false},
+		{"sender left the group", f.group(friend), 0, past, false, false},
+		{"not due yet", f.directChat(sender, friend), friend, time.Now().Add(time.Hour), false, true},
+	}
+
+	scheduledIds := make([]uint64, len(tests))
+	for i, tt := range tests {
+		message := &entities.Message{
+			ChatID:     tt.chatId,
+			SenderId:   sender,
+			ReceiverId: tt.receiverId,
+			Envelope:   entities.Envelope{Kind: entities.KindText, Payload: []byte(tt.name)},
+		}
+		if err := repo.Schedule(ctx, message, tt.deliverAt); err != nil {
+			t.Fatalf("Schedule: %v", err)
+		}
+		scheduledIds[i] = message.ScheduledID
+	}
+
+	// Кроме сообщений теста в базе могут быть и другие отложенные сообщения
+	released := make(map[uint64]entities.Message)
+	for {
+		messages, err := repo.ReleaseDue(ctx, 100)
+		if err != nil {
+			t.Fatalf("ReleaseDue: %v", err)
+		}
+		if len(messages) == 0 {
+			break
+		}
+		for _, message := range messages {
+			released[message.ScheduledID] = message
+		}
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			message, isReleased := released[scheduledIds[i]]
+			if isReleased != tt.wantReleased {
+				t.Errorf("released = %v, want %v", isReleased, tt.wantReleased)
+			}
+			if isReleased && (message.ID == 0 || string(message.Payload) != tt.name) {
+				t.Errorf("released message = %+v", message)
+			}
+
+			var kept bool
+			if err := db.Get(&kept, `SELECT EXISTS (SELECT 1 FROM scheduled_messages WHERE id = $1)`, scheduledIds[i]); err != nil {
+				t.Fatalf("failed to check scheduled message: %v", err)
+			}
+			if kept != tt.wantKept {
+				t.Errorf("kept in scheduled_messages = %v, want %v", kept, tt.wantKept)
+			}
+		})
+	}
+}
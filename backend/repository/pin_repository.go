@@ -0,0 +1,189 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"gRPCWebServer/backend/entities"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// PinRepository интерфейс для закрепленных и избранных сообщений
+type PinRepository interface {
+	// Закрепляет сообщение в чате; false, если оно уже закреплено
+	Pin(ctx context.Context, chatID, messageID, userID uint64) (bool, error)
+
+	// Открепляет сообщение; false, если оно не было закреплено
+	Unpin(ctx context.Context, chatID, messageID uint64) (bool, error)
+
+	// Возвращает количество закрепленных сообщений чата
+	CountPinned(ctx context.Context, chatID uint64) (int, error)
+
+	// Возвращает закрепленные сообщения чата, начиная с последнего закрепленного
+	ListPinned(ctx context.Context, chatID uint64) ([]entities.PinnedMessage, error)
+
+	// Добавляет сообщение в избранное пользователя
+	Star(ctx context.Context, userID, messageID uint64) error
+
+	// Убирает сообщение из избранного пользователя
+	Unstar(ctx context.Context, userID, messageID uint64) error
+
+	// Возвращает количество избранных сообщений пользователя
+	CountStarred(ctx context.Context, userID uint64) (int, error)
+
+	// Возвращает избранные сообщения пользователя из чатов, в которых он состоит, начиная с последнего добавленного
+	ListStarred(ctx context.Context, userID uint64) ([]entities.StarredMessage, error)
+}
+
+type pinRepository struct {
+	db *sqlx.DB
+}
+
+// NewPinRepository создает новый экземпляр репозитория закрепленных сообщений
+func NewPinRepository(db *sqlx.DB) PinRepository {
+	return &pinRepository{db: db}
+}
+
+// Pin закрепляет сообщение
+func (r *pinRepository) Pin(ctx context.Context, chatID, messageID, userID uint64) (bool, error) {
+	query := `INSERT INTO pinned_messages (chat_id, message_id, pinned_by) VALUES ($1, $2, $3) ON CONFLICT DO NOTHING`
+
+	result, err := r.db.ExecContext(ctx, query, chatID, messageID, userID)
+	if err != nil {
+		return false, fmt.Errorf("failed to pin message: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rowsAffected > 0, nil
+}
+
+// Unpin открепляет сообщение
+func (r *pinRepository) Unpin(ctx context.Context, chatID, messageID uint64) (bool, error) {
+	query := `DELETE FROM pinned_messages WHERE chat_id = $1 AND message_id = $2`
+
+	result, err := r.db.ExecContext(ctx, query, chatID, messageID)
+	if err != nil {
+		return false, fmt.Errorf("failed to unpin message: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rowsAffected > 0, nil
+}
+
+// CountPinned считает закрепленные сообщения чата
+func (r *pinRepository) CountPinned(ctx context.Context, chatID uint64) (int, error) {
+	var count int
+	if err := r.db.GetContext(ctx, &count, `SELECT COUNT(*) FROM pinned_messages WHERE chat_id = $1`, chatID); err != nil {
+		return 0, fmt.Errorf("failed to count pinned messages: %w", err)
+	}
+
+	return count, nil
+}
+
+// ListPinned возвращает закрепленные сообщения чата
+func (r *pinRepository) ListPinned(ctx context.Context, chatID uint64) ([]entities.PinnedMessage, error) {
+	query := `
+		SELECT m.id, m.chat_id, m.sender_id, u.username AS sender_username,
+			COALESCE(m.receiver_id, 0) AS receiver_id, m.timestamp, m.edited_at, m.deleted_at,
+			m.kind, m.payload, m.iv, m.cipher_algorithm, m.cipher_mode, m.cipher_padding,
+			COALESCE(m.reply_to_id, 0) AS reply_to_id, COALESCE(m.thread_root_id, 0) AS thread_root_id,
+			COALESCE(pu.username, '') AS pinned_by, p.pinned_at
+		FROM pinned_messages p
+		JOIN messages m ON m.id = p.message_id
+		JOIN users u ON u.id = m.sender_id
+		LEFT JOIN users pu ON pu.id = p.pinned_by
+		WHERE p.chat_id = $1
+		ORDER BY p.pinned_at DESC, m.id DESC
+	`
+
+	var pinned []entities.PinnedMessage
+	if err := r.db.SelectContext(ctx, &pinned, query, chatID); err != nil {
+		return nil, fmt.Errorf("failed to list pinned messages: %w", err)
+	}
+
+	messages := make([]*entities.Message, 0, len(pinned))
+	for i := range pinned {
+		messages = append(messages, &pinned[i].Message)
+	}
+	if err := loadAttachments(ctx, r.db, messages); err != nil {
+		return nil, err
+	}
+
+	return pinned, nil
+}
+
+// Star добавляет сообщение в избранное; повторное добавление ничего не меняет
+func (r *pinRepository) Star(ctx context.Context, userID, messageID uint64) error {
+	query := `INSERT INTO starred_messages (user_id, message_id) VALUES ($1, $2) ON CONFLICT DO NOTHING`
+
+	if _, err := r.db.ExecContext(ctx, query, userID, messageID); err != nil {
+		return fmt.Errorf("failed to star message: %w", err)
+	}
+
+	return nil
+}
+
+// Unstar убирает сообщение из избранного
+func (r *pinRepository) Unstar(ctx context.Context, userID, messageID uint64) error {
+	query := `DELETE FROM starred_messages WHERE user_id = $1 AND message_id = $2`
+
+	if _, err := r.db.ExecContext(ctx, query, userID, messageID); err != nil {
+		return fmt.Errorf("failed to unstar message: %w", err)
+	}
+
+	return nil
+}
+
+// CountStarred считает избранные сообщения пользователя
+func (r *pinRepository) CountStarred(ctx context.Context, userID uint64) (int, error) {
+	var count int
+	if err := r.db.GetContext(ctx, &count, `SELECT COUNT(*) FROM starred_messages WHERE user_id = $1`, userID); err != nil {
+		return 0, fmt.Errorf("failed to count starred messages: %w", err)
+	}
+
+	return count, nil
+}
+
+// ListStarred возвращает избранные сообщения пользователя. Сообщения групп,
+// из которых пользователь вышел, остаются в избранном, но не возвращаются.
+func (r *pinRepository) ListStarred(ctx context.Context, userID uint64) ([]entities.StarredMessage, error) {
+	query := `
+		SELECT m.id, m.chat_id, m.sender_id, u.username AS sender_username,
+			COALESCE(m.receiver_id, 0) AS receiver_id, m.timestamp, m.edited_at, m.deleted_at,
+			m.kind, m.payload, m.iv, m.cipher_algorithm, m.cipher_mode, m.cipher_padding,
+			COALESCE(m.reply_to_id, 0) AS reply_to_id, COALESCE(m.thread_root_id, 0) AS thread_root_id,
+			COALESCE(peer.username, '') AS peer_username, s.starred_at
+		FROM starred_messages s
+		JOIN messages m ON m.id = s.message_id
+		JOIN users u ON u.id = m.sender_id
+		LEFT JOIN users peer ON m.receiver_id IS NOT NULL
+			AND peer.id = CASE WHEN m.sender_id = $1 THEN m.receiver_id ELSE m.sender_id END
+		WHERE s.user_id = $1
+			AND (m.receiver_id IS NOT NULL
+				OR EXISTS (SELECT 1 FROM chat_members cm WHERE cm.chat_id = m.chat_id AND cm.user_id = $1))
+		ORDER BY s.starred_at DESC, m.id DESC
+	`
+
+	var starred []entities.StarredMessage
+	if err := r.db.SelectContext(ctx, &starred, query, userID); err != nil {
+		return nil, fmt.Errorf("failed to list starred messages: %w", err)
+	}
+
+	messages := make([]*entities.Message, 0, len(starred))
+	for i := range starred {
+		messages = append(messages, &starred[i].Message)
+	}
+	if err := loadAttachments(ctx, r.db, messages); err != nil {
+		return nil, err
+	}
+
+	return starred, nil
+}
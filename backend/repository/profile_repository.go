@@ -0,0 +1,141 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"gRPCWebServer/backend/entities"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// ProfileRepository интерфейс для работы с профилями пользователей
+type ProfileRepository interface {
+	// Получает профиль по имени пользователя; nil, если пользователь не найден
+	GetByUsername(ctx context.Context, username string) (*entities.UserProfile, error)
+
+	// Получает профиль по ID пользователя; nil, если пользователь не найден
+	GetByUserID(ctx context.Context, userID uint64) (*entities.UserProfile, error)
+
+	// Сохраняет отображаемое имя, описание, аватар и видимость в поиске
+	Update(ctx context.Context, profile *entities.UserProfile) error
+
+	// Ищет пользователей по префиксу и похожести имени; возвращает страницу и общее количество
+	Search(ctx context.Context, userID uint64, query string, page, pageSize int) ([]entities.UserProfile, int, error)
+}
+
+type profileRepository struct {
+	db *sqlx.DB
+}
+
+// NewProfileRepository создает новый экземпляр репозитория профилей
+func NewProfileRepository(db *sqlx.DB) ProfileRepository {
+	return &profileRepository{db: db}
+}
+
+// Профиль может еще не существовать, поэтому пользователи соединяются с ним через LEFT JOIN
+const profileSelect = `
+	SELECT
+		u.id AS user_id,
+		u.username,
+		COALESCE(p.display_name, '') AS display_name,
+		COALESCE(p.bio, '') AS bio,
+		p.avatar_file_id,
+		COALESCE(p.hidden_from_search, FALSE) AS hidden_from_search,
+		COALESCE(p.hide_last_seen, FALSE) AS hide_last_seen,
+		(SELECT MAX(s.last_seen_at) FROM sessions s WHERE s.user_id = u.id) AS last_seen_at
+	FROM users u
+	LEFT JOIN user_profiles p ON p.user_id = u.id
+`
+
+// GetByUsername получает профиль по имени пользователя
+func (r *profileRepository) GetByUsername(ctx context.Context, username string) (*entities.UserProfile, error) {
+	return r.get(ctx, profileSelect+` WHERE u.username = $1`, username)
+}
+
+// GetByUserID получает профиль по ID пользователя
+func (r *profileRepository) GetByUserID(ctx context.Context, userID uint64) (*entities.UserProfile, error) {
+	return r.get(ctx, profileSelect+` WHERE u.id = $1`, userID)
+}
+
+func (r *profileRepository) get(ctx context.Context, query string, arg interface{}) (*entities.UserProfile, error) {
+	var profile entities.UserProfile
+	err := r.db.GetContext(ctx, &profile, query, arg)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get profile: %w", err)
+	}
+
+	return &profile, nil
+}
+
+// Update создает или обновляет профиль пользователя
+func (r *profileRepository) Update(ctx context.Context, profile *entities.UserProfile) error {
+	query := `
+		INSERT INTO user_profiles (user_id, display_name, bio, avatar_file_id, hidden_from_search, hide_last_seen, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, NOW())
+		ON CONFLICT (user_id) DO UPDATE
+		SET display_name = $2, bio = $3, avatar_file_id = $4, hidden_from_search = $5, hide_last_seen = $6, updated_at = NOW()
+	`
+
+	_, err := r.db.ExecContext(ctx, query, profile.UserID, profile.DisplayName, profile.Bio,
+		profile.AvatarFileID, profile.HiddenFromSearch, profile.HideLastSeen)
+	if err != nil {
+		return fmt.Errorf("failed to update profile: %w", err)
+	}
+
+	return nil
+}
+
+// Условие поиска: $1 — ищущий пользователь, $2 — запрос, $3 — запрос как префикс для LIKE.
+// Скрытые пользователи попадают в выдачу только при точном совпадении имени.
+const searchCondition = `
+	WHERE u.id <> $1 AND (
+		LOWER(u.username) = LOWER($2)
+		OR (
+			COALESCE(p.hidden_from_search, FALSE) = FALSE AND (
+				u.username ILIKE $3 OR p.display_name ILIKE $3
+				OR u.username % $2 OR p.display_name % $2
+			)
+		)
+	)
+`
+
+// Search ищет пользователей по имени и отображаемому имени с пагинацией
+func (r *profileRepository) Search(ctx context.Context, userID uint64, query string, page, pageSize int) ([]entities.UserProfile, int, error) {
+	prefix := escapeLike(query) + "%"
+
+	countQuery := `SELECT COUNT(*) FROM users u LEFT JOIN user_profiles p ON p.user_id = u.id` + searchCondition
+
+	var totalCount int
+	if err := r.db.GetContext(ctx, &totalCount, countQuery, userID, query, prefix); err != nil {
+		return nil, 0, fmt.Errorf("failed to count users: %w", err)
+	}
+
+	// Сначала точное совпадение, затем совпадения по префиксу, затем по похожести
+	offset := (page - 1) * pageSize
+	selectQuery := profileSelect + searchCondition + `
+		ORDER BY
+			LOWER(u.username) = LOWER($2) DESC,
+			COALESCE(u.username ILIKE $3 OR p.display_name ILIKE $3, FALSE) DESC,
+			GREATEST(similarity(u.username, $2), similarity(COALESCE(p.display_name, ''), $2)) DESC,
+			u.username
+		LIMIT $4 OFFSET $5
+	`
+
+	var profiles []entities.UserProfile
+	if err := r.db.SelectContext(ctx, &profiles, selectQuery, userID, query, prefix, pageSize, offset); err != nil {
+		return nil, 0, fmt.Errorf("failed to search users: %w", err)
+	}
+
+	return profiles, totalCount, nil
+}
+
+// escapeLike экранирует спецсимволы шаблона LIKE
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
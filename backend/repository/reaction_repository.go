@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"gRPCWebServer/backend/entities"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+// ReactionRepository интерфейс для реакций на сообщения
+type ReactionRepository interface {
+	// Ставит реакцию; false, если пользователь уже поставил этот эмодзи
+	Add(ctx context.Context, messageID, userID uint64, emoji string) (bool, error)
+
+	// Снимает реакцию; false, если ее не было
+	Remove(ctx context.Context, messageID, userID uint64, emoji string) (bool, error)
+
+	// Возвращает сводку реакций на каждое из сообщений в порядке первой реакции
+	Summarize(ctx context.Context, messageIDs []uint64, userID uint64) (map[uint64][]entities.ReactionCount, error)
+}
+
+type reactionRepository struct {
+	db *sqlx.DB
+}
+
+// NewReactionRepository создает новый экземпляр репозитория реакций
+func NewReactionRepository(db *sqlx.DB) ReactionRepository {
+	return &reactionRepository{db: db}
+}
+
+// Add ставит реакцию на сообщение
+func (r *reactionRepository) Add(ctx context.Context, messageID, userID uint64, emoji string) (bool, error) {
+	query := `INSERT INTO message_reactions (message_id, user_id, emoji) VALUES ($1, $2, $3) ON CONFLICT DO NOTHING`
+
+	result, err := r.db.ExecContext(ctx, query, messageID, userID, emoji)
+	if err != nil {
+		return false, fmt.Errorf("failed to add reaction: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rowsAffected > 0, nil
+}
+
+// Remove снимает реакцию с сообщения
+func (r *reactionRepository) Remove(ctx context.Context, messageID, userID uint64, emoji string) (bool, error) {
+	query := `DELETE FROM message_reactions WHERE message_id = $1 AND user_id = $2 AND emoji = $3`
+
+	result, err := r.db.ExecContext(ctx, query, messageID, userID, emoji)
+	if err != nil {
+		return false, fmt.Errorf("failed to remove reaction: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rowsAffected > 0, nil
+}
+
+// Summarize считает реакции на сообщения страницы истории
+func (r *reactionRepository) Summarize(ctx context.Context, messageIDs []uint64, userID uint64) (map[uint64][]entities.ReactionCount, error) {
+	summary := make(map[uint64][]entities.ReactionCount)
+	if len(messageIDs) == 0 {
+		return summary, nil
+	}
+
+	ids := make([]int64, 0, len(messageIDs))
+	for _, id := range messageIDs {
+		ids = append(ids, int64(id))
+	}
+
+	query := `
+		SELECT message_id, emoji, COUNT(*) AS count, bool_or(user_id = $2) AS reacted
+		FROM message_reactions
+		WHERE message_id = ANY($1)
+		GROUP BY message_id, emoji
+		ORDER BY message_id, MIN(created_at), emoji
+	`
+
+	var counts []entities.ReactionCount
+	if err := r.db.SelectContext(ctx, &counts, query, pq.Array(ids), userID); err != nil {
+		return nil, fmt.Errorf("failed to summarize reactions: %w", err)
+	}
+
+	for _, count := range counts {
+		summary[count.MessageID] = append(summary[count.MessageID], count)
+	}
+
+	return summary, nil
+}
@@ -0,0 +1,89 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"gRPCWebServer/backend/entities"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// ReceiptRepository интерфейс для отметок о доставке и прочтении сообщений
+type ReceiptRepository interface {
+	// Отмечает сообщение доставленным получателю; false, если оно уже было отмечено
+	MarkDelivered(ctx context.Context, messageID, userID uint64) (bool, error)
+
+	// Отмечает прочитанными сообщения чата до upToMessageID включительно.
+	// Возвращает для каждого отправителя наибольший ID из впервые прочитанных сообщений.
+	MarkRead(ctx context.Context, chatID, userID, upToMessageID uint64) ([]entities.ReadReceipt, error)
+
+	// Возвращает количество непрочитанных сообщений пользователя в чате
+	CountUnread(ctx context.Context, chatID, userID uint64) (int, error)
+}
+
+type receiptRepository struct {
+	db *sqlx.DB
+}
+
+// NewReceiptRepository создает новый экземпляр репозитория отметок о доставке
+func NewReceiptRepository(db *sqlx.DB) ReceiptRepository {
+	return &receiptRepository{db: db}
+}
+
+// unreadCountQuery считает непрочитанные пользователем ($1) сообщения чата c
+const unreadCountQuery = `
+	SELECT COUNT(*) FROM message_receipts r
+	JOIN messages m ON m.id = r.message_id
+	WHERE m.chat_id = c.id AND r.user_id = $1 AND r.read_at IS NULL AND m.deleted_at IS NULL
+`
+
+// MarkDelivered отмечает сообщение доставленным
+func (r *receiptRepository) MarkDelivered(ctx context.Context, messageID, userID uint64) (bool, error) {
+	query := `UPDATE message_receipts SET delivered_at = NOW() WHERE message_id = $1 AND user_id = $2 AND delivered_at IS NULL`
+
+	result, err := r.db.ExecContext(ctx, query, messageID, userID)
+	if err != nil {
+		return false, fmt.Errorf("failed to mark message delivered: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rowsAffected > 0, nil
+}
+
+// MarkRead отмечает сообщения прочитанными; прочитанное сообщение считается и доставленным
+func (r *receiptRepository) MarkRead(ctx context.Context, chatID, userID, upToMessageID uint64) ([]entities.ReadReceipt, error) {
+	query := `
+		WITH updated AS (
+			UPDATE message_receipts r
+			SET read_at = NOW(), delivered_at = COALESCE(r.delivered_at, NOW())
+			FROM messages m
+			WHERE m.id = r.message_id AND m.chat_id = $1
+			AND r.user_id = $2 AND r.message_id <= $3 AND r.read_at IS NULL
+			RETURNING r.message_id, m.sender_id
+		)
+		SELECT sender_id, MAX(message_id) AS message_id FROM updated GROUP BY sender_id
+	`
+
+	var receipts []entities.ReadReceipt
+	if err := r.db.SelectContext(ctx, &receipts, query, chatID, userID, upToMessageID); err != nil {
+		return nil, fmt.Errorf("failed to mark messages read: %w", err)
+	}
+
+	return receipts, nil
+}
+
+// CountUnread возвращает количество непрочитанных сообщений в чате
+func (r *receiptRepository) CountUnread(ctx context.Context, chatID, userID uint64) (int, error) {
+	query := `SELECT (` + unreadCountQuery + `) FROM chats c WHERE c.id = $2`
+
+	var count int
+	if err := r.db.GetContext(ctx, &count, query, userID, chatID); err != nil {
+		return 0, fmt.Errorf("failed to count unread messages: %w", err)
+	}
+
+	return count, nil
+}
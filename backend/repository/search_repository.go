@@ -0,0 +1,157 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"gRPCWebServer/backend/entities"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+// SearchRepository интерфейс для поиска сообщений
+type SearchRepository interface {
+	// Ищет сообщения и возвращает признак того, что есть еще более старые результаты
+	Search(ctx context.Context, query entities.SearchQuery) ([]entities.SearchResult, bool, error)
+
+	// Добавляет токены слепого индекса пользователя для сообщения
+	AddTokens(ctx context.Context, userID, messageID uint64, tokens [][]byte) error
+
+	// Удаляет слепой индекс пользователя
+	ClearTokens(ctx context.Context, userID uint64) error
+}
+
+type searchRepository struct {
+	db *sqlx.DB
+}
+
+// NewSearchRepository создает новый экземпляр репозитория поиска
+func NewSearchRepository(db *sqlx.DB) SearchRepository {
+	return &searchRepository{db: db}
+}
+
+// likeEscaper экранирует спецсимволы шаблона LIKE в подстроке поиска
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// Search ищет сообщения в чатах пользователя; удаленные и истекшие сообщения не находятся
+func (r *searchRepository) Search(ctx context.Context, query entities.SearchQuery) ([]entities.SearchResult, bool, error) {
+	conditions := []string{
+		`(m.sender_id = $1 OR m.receiver_id = $1
+			OR (m.receiver_id IS NULL AND EXISTS (SELECT 1 FROM chat_members cm WHERE cm.chat_id = m.chat_id AND cm.user_id = $1)))`,
+		"m.deleted_at IS NULL",
+		"(m.expires_at IS NULL OR m.expires_at > NOW())",
+	}
+	args := []interface{}{query.UserID}
+
+	addCondition := func(condition string, arg interface{}) {
+		args = append(args, arg)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+
+	if query.ChatID != 0 {
+		addCondition("m.chat_id = $%d", query.ChatID)
+	}
+	if query.SenderID != 0 {
+		addCondition("m.sender_id = $%d", query.SenderID)
+	}
+	if query.Since != nil {
+		addCondition("m.timestamp >= $%d", *query.Since)
+	}
+	if query.Until != nil {
+		addCondition("m.timestamp < $%d", *query.Until)
+	}
+
+	// Имя и тип должны совпасть у одного и того же вложения
+	fileConditions := []string{"a.message_id = m.id"}
+	if query.FileName != "" {
+		args = append(args, likeEscaper.Replace(query.FileName))
+		fileConditions = append(fileConditions, fmt.Sprintf("f.file_name ILIKE '%%' || $%d || '%%'", len(args)))
+	}
+	if query.MimeTypePrefix != "" {
+		args = append(args, likeEscaper.Replace(query.MimeTypePrefix))
+		fileConditions = append(fileConditions, fmt.Sprintf("f.mime_type LIKE $%d || '%%'", len(args)))
+	}
+	if query.HasAttachment || len(fileConditions) > 1 {
+		conditions = append(conditions, fmt.Sprintf(`EXISTS (
+			SELECT 1 FROM message_attachments a
+			JOIN files f ON f.file_id = a.file_id
+			WHERE %s)`, strings.Join(fileConditions, " AND ")))
+	}
+
+	if query.BeforeID != 0 {
+		addCondition("m.id < $%d", query.BeforeID)
+	}
+	if len(query.Tokens) > 0 {
+		args = append(args, pq.ByteaArray(query.Tokens), len(query.Tokens))
+		conditions = append(conditions, fmt.Sprintf(`m.id IN (
+			SELECT message_id FROM message_search_tokens
+			WHERE user_id = $1 AND token = ANY($%d)
+			GROUP BY message_id
+			HAVING COUNT(DISTINCT token) = $%d)`, len(args)-1, len(args)))
+	}
+
+	// Лишняя запись показывает, есть ли еще результаты
+	args = append(args, query.Limit+1)
+	sqlQuery := fmt.Sprintf(`
+		SELECT m.id, m.chat_id, m.sender_id, u.username AS sender_username,
+			COALESCE(m.receiver_id, 0) AS receiver_id, m.timestamp, m.edited_at, m.deleted_at,
+			m.kind, m.payload, m.iv, m.cipher_algorithm, m.cipher_mode, m.cipher_padding,
+			COALESCE(m.reply_to_id, 0) AS reply_to_id, COALESCE(m.thread_root_id, 0) AS thread_root_id,
+			m.expires_at, COALESCE(peer.username, '') AS peer_username
+		FROM messages m
+		JOIN users u ON u.id = m.sender_id
+		LEFT JOIN users peer ON m.receiver_id IS NOT NULL
+			AND peer.id = CASE WHEN m.sender_id = $1 THEN m.receiver_id ELSE m.sender_id END
+		WHERE %s
+		ORDER BY m.id DESC
+		LIMIT $%d`, strings.Join(conditions, " AND "), len(args))
+
+	var results []entities.SearchResult
+	if err := r.db.SelectContext(ctx, &results, sqlQuery, args...); err != nil {
+		return nil, false, fmt.Errorf("failed to search messages: %w", err)
+	}
+
+	hasMore := len(results) > query.Limit
+	if hasMore {
+		results = results[:query.Limit]
+	}
+
+	messages := make([]*entities.Message, 0, len(results))
+	for i := range results {
+		messages = append(messages, &results[i].Message)
+	}
+	if err := loadAttachments(ctx, r.db, messages); err != nil {
+		return nil, false, err
+	}
+
+	return results, hasMore, nil
+}
+
+// AddTokens добавляет токены; уже известные токены сообщения пропускаются
+func (r *searchRepository) AddTokens(ctx context.Context, userID, messageID uint64, tokens [][]byte) error {
+	if len(tokens) == 0 {
+		return nil
+	}
+
+	query := `
+		INSERT INTO message_search_tokens (user_id, message_id, token)
+		SELECT $1, $2, token FROM unnest($3::bytea[]) AS token
+		ON CONFLICT DO NOTHING
+	`
+
+	if _, err := r.db.ExecContext(ctx, query, userID, messageID, pq.ByteaArray(tokens)); err != nil {
+		return fmt.Errorf("failed to add search tokens: %w", err)
+	}
+
+	return nil
+}
+
+// ClearTokens удаляет все токены пользователя
+func (r *searchRepository) ClearTokens(ctx context.Context, userID uint64) error {
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM message_search_tokens WHERE user_id = $1`, userID); err != nil {
+		return fmt.Errorf("failed to clear search tokens: %w", err)
+	}
+
+	return nil
+}
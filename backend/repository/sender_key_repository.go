@@ -0,0 +1,141 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"gRPCWebServer/backend/entities"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// SenderKeyRepository интерфейс для хранения ключей отправителей в группах
+type SenderKeyRepository interface {
+	// Возвращает последнюю эпоху ключа отправителя в группе; nil, если ключ еще не рассылался
+	GetCurrentEpoch(ctx context.Context, chatID, senderID uint64) (*entities.SenderKeyEpoch, error)
+
+	// Сохраняет обертки ключа для получателей, создавая эпоху при необходимости.
+	// Повторно переданная обертка для того же получателя заменяет прежнюю.
+	SaveBundles(ctx context.Context, chatID, senderID uint64, epoch uint32, bundles []entities.SenderKeyBundle) error
+
+	// Возвращает текущих участников группы (кроме отправителя) с состоянием доставки ключа эпохи
+	ListRecipients(ctx context.Context, chatID, senderID uint64, epoch uint32) ([]entities.SenderKeyRecipient, error)
+
+	// Возвращает все ключи, зашифрованные для получателя в группе, и отмечает их доставленными
+	FetchForRecipient(ctx context.Context, chatID, recipientID uint64) ([]entities.SenderKeyBundle, error)
+}
+
+type senderKeyRepository struct {
+	db *sqlx.DB
+}
+
+// NewSenderKeyRepository создает новый экземпляр репозитория ключей отправителей
+func NewSenderKeyRepository(db *sqlx.DB) SenderKeyRepository {
+	return &senderKeyRepository{db: db}
+}
+
+// GetCurrentEpoch возвращает последнюю эпоху ключа отправителя
+func (r *senderKeyRepository) GetCurrentEpoch(ctx context.Context, chatID, senderID uint64) (*entities.SenderKeyEpoch, error) {
+	query := `
+		SELECT e.chat_id, e.sender_id, e.epoch, e.membership_version,
+			e.membership_version < c.membership_version AS stale, e.created_at
+		FROM sender_key_epochs e
+		JOIN chats c ON c.id = e.chat_id
+		WHERE e.chat_id = $1 AND e.sender_id = $2
+		ORDER BY e.epoch DESC
+		LIMIT 1
+	`
+
+	var epoch entities.SenderKeyEpoch
+	if err := r.db.GetContext(ctx, &epoch, query, chatID, senderID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get sender key epoch: %w", err)
+	}
+
+	return &epoch, nil
+}
+
+// SaveBundles сохраняет обертки ключа в одной транзакции
+func (r *senderKeyRepository) SaveBundles(ctx context.Context, chatID, senderID uint64, epoch uint32, bundles []entities.SenderKeyBundle) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO sender_key_epochs (chat_id, sender_id, epoch, membership_version)
+		SELECT id, $2, $3, membership_version FROM chats WHERE id = $1
+		ON CONFLICT DO NOTHING
+	`
+	if _, err := tx.ExecContext(ctx, query, chatID, senderID, epoch); err != nil {
+		return fmt.Errorf("failed to create sender key epoch: %w", err)
+	}
+
+	query = `
+		INSERT INTO sender_key_bundles (chat_id, sender_id, epoch, recipient_id, wrapped_key)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (chat_id, sender_id, epoch, recipient_id)
+		DO UPDATE SET wrapped_key = EXCLUDED.wrapped_key, created_at = NOW(), delivered_at = NULL
+	`
+	for _, bundle := range bundles {
+		if _, err := tx.ExecContext(ctx, query, chatID, senderID, epoch, bundle.RecipientID, bundle.WrappedKey); err != nil {
+			return fmt.Errorf("failed to save sender key bundle: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// ListRecipients возвращает участников группы с состоянием доставки ключа
+func (r *senderKeyRepository) ListRecipients(ctx context.Context, chatID, senderID uint64, epoch uint32) ([]entities.SenderKeyRecipient, error) {
+	query := `
+		SELECT m.user_id, u.username,
+			b.recipient_id IS NOT NULL AS has_key,
+			b.delivered_at IS NOT NULL AS delivered
+		FROM chat_members m
+		JOIN users u ON u.id = m.user_id
+		LEFT JOIN sender_key_bundles b
+			ON b.chat_id = m.chat_id AND b.sender_id = $2 AND b.epoch = $3 AND b.recipient_id = m.user_id
+		WHERE m.chat_id = $1 AND m.user_id <> $2
+		ORDER BY u.username
+	`
+
+	var recipients []entities.SenderKeyRecipient
+	if err := r.db.SelectContext(ctx, &recipients, query, chatID, senderID, epoch); err != nil {
+		return nil, fmt.Errorf("failed to list sender key recipients: %w", err)
+	}
+
+	return recipients, nil
+}
+
+// FetchForRecipient возвращает ключи, зашифрованные для получателя, от старых эпох к новым.
+// Отметка о доставке ставится тем же запросом, чтобы не отметить ключ, который не был выдан.
+func (r *senderKeyRepository) FetchForRecipient(ctx context.Context, chatID, recipientID uint64) ([]entities.SenderKeyBundle, error) {
+	query := `
+		WITH fetched AS (
+			UPDATE sender_key_bundles SET delivered_at = COALESCE(delivered_at, NOW())
+			WHERE chat_id = $1 AND recipient_id = $2
+			RETURNING chat_id, sender_id, epoch, recipient_id, wrapped_key, created_at, delivered_at
+		)
+		SELECT f.chat_id, f.sender_id, u.username AS sender_username, f.epoch, f.recipient_id,
+			f.wrapped_key, f.created_at, f.delivered_at
+		FROM fetched f
+		JOIN users u ON u.id = f.sender_id
+		ORDER BY u.username, f.epoch
+	`
+
+	var bundles []entities.SenderKeyBundle
+	if err := r.db.SelectContext(ctx, &bundles, query, chatID, recipientID); err != nil {
+		return nil, fmt.Errorf("failed to get sender keys: %w", err)
+	}
+
+	return bundles, nil
+}
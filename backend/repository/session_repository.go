@@ -0,0 +1,203 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"gRPCWebServer/backend/entities"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// SessionRepository интерфейс для работы с сессиями пользователей
+type SessionRepository interface {
+	// Создает новую сессию
+	Create(ctx context.Context, session *entities.Session) error
+
+	// Получает активную сессию по хешу refresh-токена
+	GetByRefreshTokenHash(ctx context.Context, tokenHash string) (*entities.Session, error)
+
+	// Заменяет refresh-токен сессии новым и продлевает срок ее действия, если токен сессии все еще oldTokenHash.
+	// Возвращает sql.ErrNoRows, если сессия отозвана или токен уже заменен параллельным запросом.
+	RotateRefreshToken(ctx context.Context, sessionID, oldTokenHash, newTokenHash string, expiresAt time.Time) error
+
+	// Отзывает активную сессию, refresh-токен которой был заменен токеном с хешем tokenHash.
+	// Возвращает false, если такой сессии нет.
+	RevokeByPreviousRefreshTokenHash(ctx context.Context, tokenHash string) (bool, error)
+
+	// Отзывает сессию
+	Revoke(ctx context.Context, sessionID string) error
+
+	// Отзывает сессию, только если она принадлежит пользователю
+	RevokeForUser(ctx context.Context, userID uint64, sessionID string) error
+
+	// Возвращает активные сессии пользователя
+	ListActiveByUser(ctx context.Context, userID uint64) ([]entities.Session, error)
+
+	// Отмечает активность в сессии; возвращает false, если сессия отозвана, истекла или не существует
+	Touch(ctx context.Context, sessionID, ipAddress string) (bool, error)
+}
+
+type sessionRepository struct {
+	db *sqlx.DB
+}
+
+// NewSessionRepository создает новый экземпляр репозитория сессий
+func NewSessionRepository(db *sqlx.DB) SessionRepository {
+	return &sessionRepository{db: db}
+}
+
+// Create создает новую сессию
+func (r *sessionRepository) Create(ctx context.Context, session *entities.Session) error {
+	query := `
+		INSERT INTO sessions (session_id, user_id, refresh_token_hash, device_label, ip_address, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, created_at, last_seen_at
+	`
+
+	err := r.db.QueryRowxContext(ctx, query, session.SessionID, session.UserID, session.RefreshTokenHash,
+		session.DeviceLabel, session.IPAddress, session.ExpiresAt).
+		Scan(&session.ID, &session.CreatedAt, &session.LastSeenAt)
+	if err != nil {
+		return fmt.Errorf("failed to create session: %w", err)
+	}
+
+	return nil
+}
+
+// GetByRefreshTokenHash получает активную сессию по хешу refresh-токена
+func (r *sessionRepository) GetByRefreshTokenHash(ctx context.Context, tokenHash string) (*entities.Session, error) {
+	query := `
+		SELECT id, session_id, user_id, refresh_token_hash, device_label, ip_address,
+		expires_at, revoked_at, created_at, last_seen_at
+		FROM sessions
+		WHERE refresh_token_hash = $1 AND revoked_at IS NULL
+	`
+
+	var session entities.Session
+	err := r.db.GetContext(ctx, &session, query, tokenHash)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, fmt.Errorf("failed to get session by refresh token: %w", err)
+	}
+
+	return &session, nil
+}
+
+// RotateRefreshToken заменяет refresh-токен сессии новым и запоминает хеш замененного
+func (r *sessionRepository) RotateRefreshToken(ctx context.Context, sessionID, oldTokenHash, newTokenHash string, expiresAt time.Time) error {
+	query := `
+		UPDATE sessions
+		SET previous_refresh_token_hash = refresh_token_hash, refresh_token_hash = $1, expires_at = $2
+		WHERE session_id = $3 AND refresh_token_hash = $4 AND revoked_at IS NULL
+	`
+
+	result, err := r.db.ExecContext(ctx, query, newTokenHash, expiresAt, sessionID, oldTokenHash)
+	if err != nil {
+		return fmt.Errorf("failed to rotate refresh token: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// RevokeByPreviousRefreshTokenHash отзывает сессию, в которой предъявлен уже замененный refresh-токен
+func (r *sessionRepository) RevokeByPreviousRefreshTokenHash(ctx context.Context, tokenHash string) (bool, error) {
+	query := `UPDATE sessions SET revoked_at = NOW() WHERE previous_refresh_token_hash = $1 AND revoked_at IS NULL`
+
+	result, err := r.db.ExecContext(ctx, query, tokenHash)
+	if err != nil {
+		return false, fmt.Errorf("failed to revoke session: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rowsAffected > 0, nil
+}
+
+// Revoke отзывает сессию
+func (r *sessionRepository) Revoke(ctx context.Context, sessionID string) error {
+	query := `UPDATE sessions SET revoked_at = NOW() WHERE session_id = $1 AND revoked_at IS NULL`
+
+	_, err := r.db.ExecContext(ctx, query, sessionID)
+	if err != nil {
+		return fmt.Errorf("failed to revoke session: %w", err)
+	}
+
+	return nil
+}
+
+// RevokeForUser отзывает сессию пользователя
+func (r *sessionRepository) RevokeForUser(ctx context.Context, userID uint64, sessionID string) error {
+	query := `UPDATE sessions SET revoked_at = NOW() WHERE session_id = $1 AND user_id = $2 AND revoked_at IS NULL`
+
+	result, err := r.db.ExecContext(ctx, query, sessionID, userID)
+	if err != nil {
+		return fmt.Errorf("failed to revoke session: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// ListActiveByUser возвращает активные сессии пользователя, начиная с последней активной
+func (r *sessionRepository) ListActiveByUser(ctx context.Context, userID uint64) ([]entities.Session, error) {
+	query := `
+		SELECT id, session_id, user_id, refresh_token_hash, device_label, ip_address,
+		expires_at, revoked_at, created_at, last_seen_at
+		FROM sessions
+		WHERE user_id = $1 AND revoked_at IS NULL AND expires_at > NOW()
+		ORDER BY last_seen_at DESC
+	`
+
+	var sessions []entities.Session
+	if err := r.db.SelectContext(ctx, &sessions, query, userID); err != nil {
+		return nil, fmt.Errorf("failed to list sessions: %w", err)
+	}
+
+	return sessions, nil
+}
+
+// Touch обновляет время последней активности и IP-адрес сессии
+func (r *sessionRepository) Touch(ctx context.Context, sessionID, ipAddress string) (bool, error) {
+	query := `
+		UPDATE sessions
+		SET last_seen_at = NOW(), ip_address = $2
+		WHERE session_id = $1 AND revoked_at IS NULL AND expires_at > NOW()
+	`
+
+	result, err := r.db.ExecContext(ctx, query, sessionID, ipAddress)
+	if err != nil {
+		return false, fmt.Errorf("failed to touch session: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rowsAffected > 0, nil
+}
@@ -0,0 +1,225 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"gRPCWebServer/backend/entities"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// TOTPRepository интерфейс для работы с настройками двухфакторной аутентификации
+type TOTPRepository interface {
+	// Получает настройки TOTP пользователя; nil, если 2FA не настраивалась
+	GetSettings(ctx context.Context, userID uint64) (*entities.TOTPSettings, error)
+
+	// Сохраняет новый неподтвержденный секрет и заменяет коды восстановления
+	SavePending(ctx context.Context, userID uint64, secret string, recoveryCodeHashes []string) error
+
+	// Включает 2FA после подтверждения кода
+	Enable(ctx context.Context, userID uint64) error
+
+	// Отключает 2FA и удаляет коды восстановления
+	Disable(ctx context.Context, userID uint64) error
+
+	// Запоминает использованный временной шаг; возвращает false, если шаг уже был использован
+	UseStep(ctx context.Context, userID uint64, step int64) (bool, error)
+
+	// Возвращает неиспользованные коды восстановления
+	GetUnusedRecoveryCodes(ctx context.Context, userID uint64) ([]entities.RecoveryCode, error)
+
+	// Помечает код восстановления использованным; возвращает false, если он уже был использован
+	UseRecoveryCode(ctx context.Context, codeID uint64) (bool, error)
+
+	// Сохраняет challenge-токен входа и удаляет просроченные токены пользователя
+	CreateChallenge(ctx context.Context, id string, userID uint64, expiresAt time.Time) error
+
+	// Засчитывает попытку ввода кода; возвращает false, если токен использован, просрочен или попытки исчерпаны
+	TakeChallengeAttempt(ctx context.Context, id string, userID uint64, maxAttempts int) (bool, error)
+
+	// Помечает challenge-токен использованным; возвращает false, если он уже был использован
+	ConsumeChallenge(ctx context.Context, id string) (bool, error)
+}
+
+type totpRepository struct {
+	db *sqlx.DB
+}
+
+// NewTOTPRepository создает новый экземпляр репозитория TOTP
+func NewTOTPRepository(db *sqlx.DB) TOTPRepository {
+	return &totpRepository{db: db}
+}
+
+// GetSettings получает настройки TOTP пользователя
+func (r *totpRepository) GetSettings(ctx context.Context, userID uint64) (*entities.TOTPSettings, error) {
+	query := `SELECT user_id, secret, enabled, last_used_step, created_at FROM user_totp WHERE user_id = $1`
+
+	var settings entities.TOTPSettings
+	err := r.db.GetContext(ctx, &settings, query, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get TOTP settings: %w", err)
+	}
+
+	return &settings, nil
+}
+
+// SavePending сохраняет неподтвержденный секрет вместе с новыми кодами восстановления
+func (r *totpRepository) SavePending(ctx context.Context, userID uint64, secret string, recoveryCodeHashes []string) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO user_totp (user_id, secret, enabled, last_used_step, created_at)
+		VALUES ($1, $2, FALSE, 0, NOW())
+		ON CONFLICT (user_id) DO UPDATE SET secret = $2, enabled = FALSE, last_used_step = 0, created_at = NOW()
+	`
+	if _, err := tx.ExecContext(ctx, query, userID, secret); err != nil {
+		return fmt.Errorf("failed to save TOTP secret: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM totp_recovery_codes WHERE user_id = $1`, userID); err != nil {
+		return fmt.Errorf("failed to delete old recovery codes: %w", err)
+	}
+
+	for _, hash := range recoveryCodeHashes {
+		if _, err := tx.ExecContext(ctx, `INSERT INTO totp_recovery_codes (user_id, code_hash) VALUES ($1, $2)`, userID, hash); err != nil {
+			return fmt.Errorf("failed to save recovery code: %w", err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+// Enable включает 2FA
+func (r *totpRepository) Enable(ctx context.Context, userID uint64) error {
+	_, err := r.db.ExecContext(ctx, `UPDATE user_totp SET enabled = TRUE WHERE user_id = $1`, userID)
+	if err != nil {
+		return fmt.Errorf("failed to enable TOTP: %w", err)
+	}
+	return nil
+}
+
+// Disable отключает 2FA
+func (r *totpRepository) Disable(ctx context.Context, userID uint64) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM totp_recovery_codes WHERE user_id = $1`, userID); err != nil {
+		return fmt.Errorf("failed to delete recovery codes: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM user_totp WHERE user_id = $1`, userID); err != nil {
+		return fmt.Errorf("failed to disable TOTP: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+// UseStep атомарно запоминает временной шаг, если он новее последнего использованного
+func (r *totpRepository) UseStep(ctx context.Context, userID uint64, step int64) (bool, error) {
+	query := `UPDATE user_totp SET last_used_step = $2 WHERE user_id = $1 AND last_used_step < $2`
+
+	result, err := r.db.ExecContext(ctx, query, userID, step)
+	if err != nil {
+		return false, fmt.Errorf("failed to update TOTP step: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rowsAffected > 0, nil
+}
+
+// GetUnusedRecoveryCodes возвращает неиспользованные коды восстановления
+func (r *totpRepository) GetUnusedRecoveryCodes(ctx context.Context, userID uint64) ([]entities.RecoveryCode, error) {
+	query := `SELECT id, user_id, code_hash, used_at FROM totp_recovery_codes WHERE user_id = $1 AND used_at IS NULL`
+
+	var codes []entities.RecoveryCode
+	if err := r.db.SelectContext(ctx, &codes, query, userID); err != nil {
+		return nil, fmt.Errorf("failed to get recovery codes: %w", err)
+	}
+
+	return codes, nil
+}
+
+// UseRecoveryCode помечает код восстановления использованным
+func (r *totpRepository) UseRecoveryCode(ctx context.Context, codeID uint64) (bool, error) {
+	query := `UPDATE totp_recovery_codes SET used_at = NOW() WHERE id = $1 AND used_at IS NULL`
+
+	result, err := r.db.ExecContext(ctx, query, codeID)
+	if err != nil {
+		return false, fmt.Errorf("failed to use recovery code: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rowsAffected > 0, nil
+}
+
+// CreateChallenge сохраняет challenge-токен входа
+func (r *totpRepository) CreateChallenge(ctx context.Context, id string, userID uint64, expiresAt time.Time) error {
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM totp_challenges WHERE user_id = $1 AND expires_at <= NOW()`, userID); err != nil {
+		return fmt.Errorf("failed to delete expired challenges: %w", err)
+	}
+
+	query := `INSERT INTO totp_challenges (id, user_id, expires_at) VALUES ($1, $2, $3)`
+	if _, err := r.db.ExecContext(ctx, query, id, userID, expiresAt); err != nil {
+		return fmt.Errorf("failed to save challenge: %w", err)
+	}
+
+	return nil
+}
+
+// TakeChallengeAttempt атомарно увеличивает счетчик попыток действующего challenge-токена
+func (r *totpRepository) TakeChallengeAttempt(ctx context.Context, id string, userID uint64, maxAttempts int) (bool, error) {
+	query := `
+		UPDATE totp_challenges SET attempts = attempts + 1
+		WHERE id = $1 AND user_id = $2 AND used_at IS NULL AND expires_at > NOW() AND attempts < $3
+	`
+
+	result, err := r.db.ExecContext(ctx, query, id, userID, maxAttempts)
+	if err != nil {
+		return false, fmt.Errorf("failed to take challenge attempt: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rowsAffected > 0, nil
+}
+
+// ConsumeChallenge помечает challenge-токен использованным
+func (r *totpRepository) ConsumeChallenge(ctx context.Context, id string) (bool, error) {
+	query := `UPDATE totp_challenges SET used_at = NOW() WHERE id = $1 AND used_at IS NULL`
+
+	result, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return false, fmt.Errorf("failed to consume challenge: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rowsAffected > 0, nil
+}
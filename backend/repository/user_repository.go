@@ -15,6 +15,7 @@ type UserRepository interface {
 	GetByUsername(ctx context.Context, username string) (*entities.User, error)
 	GetUserNameById(ctx context.Context, userId uint64) (string, error)
 	GetByID(ctx context.Context, userID uint64) (*entities.User, error)
+	UpdatePasswordHash(ctx context.Context, userID uint64, passwordHash string) error
 }
 
 type userRepo struct {
@@ -74,3 +75,13 @@ func (ur *userRepo) GetByID(ctx context.Context, userID uint64) (*entities.User,
 	}
 	return &user, nil
 }
+
+func (ur *userRepo) UpdatePasswordHash(ctx context.Context, userID uint64, passwordHash string) error {
+	query := `UPDATE users SET password_hash = $1 WHERE id = $2`
+
+	_, err := ur.db.ExecContext(ctx, query, passwordHash, userID)
+	if err != nil {
+		return fmt.Errorf("failed to update password hash: %v", err)
+	}
+	return nil
+}
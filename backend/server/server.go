@@ -1,10 +1,14 @@
 package server
 
 import (
+	"encoding/json"
 	"fmt"
 	pb "gRPCWebServer/backend/generated"
 	"gRPCWebServer/backend/middleware"
+	"gRPCWebServer/backend/ratelimit"
+	"gRPCWebServer/backend/repository"
 	"gRPCWebServer/backend/transport"
+	"gRPCWebServer/backend/utils"
 	"log"
 	"net"
 	"net/http"
@@ -17,18 +21,21 @@ import (
 type Server struct {
 	grpcServer       *grpc.Server
 	webSocketHandler *transport.WebSocketHandler
+	rateLimiter      *middleware.RateLimitInterceptor
 }
 
-func NewServer(wsHandler *transport.WebSocketHandler) *Server {
-	authMiddleWare := middleware.NewAuthInterceptor()
+func NewServer(wsHandler *transport.WebSocketHandler, sessionRepo repository.SessionRepository, limiter ratelimit.Limiter) *Server {
+	authMiddleWare := middleware.NewAuthInterceptor(sessionRepo)
+	rateLimitMiddleWare := middleware.NewRateLimitInterceptor(limiter)
 	grpcServer := grpc.NewServer(
-		grpc.UnaryInterceptor(authMiddleWare.UnaryInterceptor()),
+		grpc.ChainUnaryInterceptor(rateLimitMiddleWare.UnaryInterceptor(), authMiddleWare.UnaryInterceptor()),
 		grpc.StreamInterceptor(authMiddleWare.StreamInterceptor()),
 	)
 
 	return &Server{
 		grpcServer:       grpcServer,
 		webSocketHandler: wsHandler,
+		rateLimiter:      rateLimitMiddleWare,
 	}
 }
 
@@ -65,7 +72,7 @@ func (s *Server) Start(grpcAddr, httpAddr string) error {
 			w.Header().Set("Access-Control-Allow-Origin", "*")
 			w.Header().Set("Access-Control-Allow-Methods", "POST, GET, OPTIONS")
 			w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-Requested-With, Accept, grpc-status, grpc-message, grpc-web, x-grpc-web, x-user-agent")
-			w.Header().Set("Access-Control-Expose-Headers", "grpc-status, grpc-message")
+			w.Header().Set("Access-Control-Expose-Headers", "grpc-status, grpc-message, retry-after")
 			w.Header().Set("Access-Control-Allow-Credentials", "true")
 
 			if r.Method == http.MethodOptions {
@@ -74,7 +81,21 @@ func (s *Server) Start(grpcAddr, httpAddr string) error {
 				return
 			}
 
+			if r.URL.Path == "/.well-known/jwks.json" {
+				w.Header().Set("Content-Type", "application/json")
+				if err := json.NewEncoder(w).Encode(utils.JWKS()); err != nil {
+					log.Printf("Failed to write JWKS: %v", err)
+				}
+				return
+			}
+
 			if r.URL.Path == "/ws" {
+				if retryAfter := s.rateLimiter.Allow(r.Context(), "ws:ip:"+middleware.HTTPClientIP(r)); retryAfter > 0 {
+					w.Header().Set("Retry-After", middleware.RetryAfterSeconds(retryAfter))
+					http.Error(w, "too many connection attempts", http.StatusTooManyRequests)
+					return
+				}
+
 				log.Printf("Handling WebSocket request for %s", r.URL.Path)
 				s.webSocketHandler.Handle(w, r)
 				return
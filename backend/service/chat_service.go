@@ -15,6 +15,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/google/uuid"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
@@ -25,34 +26,71 @@ type ChatService interface {
 	Chat(stream pb.ChatService_ChatServer) error
 }
 
-type MessageStreamProvider interface {
-	GetReceiveChannel(username string) (chan *pb.ReceiveMessagesResponse, error)
-}
-
 type chatService struct {
 	pb.UnimplementedChatServiceServer
 	chatRepo      repository.ChatRepository
 	userRepo      repository.UserRepository
 	messageRepo   repository.MessageRepository
+	blockRepo     repository.BlockRepository
+	groupRepo     repository.GroupRepository
+	receiptRepo   repository.ReceiptRepository
+	reactionRepo  repository.ReactionRepository
+	pinRepo       repository.PinRepository
+	searchRepo    repository.SearchRepository
 	broker        broker.MessageBroker
+	cluster       broker.ClusterBroker
 	streamManager manager.StreamManager3
+	presence      manager.PresenceManager
+	// Сколько последних сообщений отправлять при открытии потока Chat; 0 — не отправлять
+	streamHistorySize int
+	// Идентификатор экземпляра сервера в рассылке состояния пользователей
+	instanceId string
 }
 
+// Клиенты загружают историю через GetHistory, поэтому поток Chat по умолчанию ее не отправляет
+const defaultStreamHistorySize = 0
+
 func NewChatService(
 	chatRepo repository.ChatRepository,
 	userRepo repository.UserRepository,
 	messageRepo repository.MessageRepository,
+	blockRepo repository.BlockRepository,
+	groupRepo repository.GroupRepository,
+	receiptRepo repository.ReceiptRepository,
+	reactionRepo repository.ReactionRepository,
+	pinRepo repository.PinRepository,
+	searchRepo repository.SearchRepository,
 	broker broker.MessageBroker,
+	cluster broker.ClusterBroker,
 ) *chatService {
+	presence := manager.NewPresenceManager()
+
 	return &chatService{
 		chatRepo:      chatRepo,
 		userRepo:      userRepo,
 		messageRepo:   messageRepo,
+		blockRepo:     blockRepo,
+		groupRepo:     groupRepo,
+		receiptRepo:   receiptRepo,
+		reactionRepo:  reactionRepo,
+		pinRepo:       pinRepo,
+		searchRepo:    searchRepo,
 		broker:        broker,
-		streamManager: manager.NewStreamManager3(),
+		cluster:       cluster,
+		streamManager: manager.NewStreamManager3(presence, cluster),
+		presence:      presence,
+
+		streamHistorySize: defaultStreamHistorySize,
+		instanceId:        uuid.New().String(),
 	}
 }
 
+// SetStreamHistorySize задает, сколько последних сообщений поток Chat отправляет при подключении.
+// 0 отключает отправку; ненулевое значение нужно только клиентам, не вызывающим GetHistory.
+func (s *chatService) SetStreamHistorySize(size int) {
+	s.streamHistorySize = size
+}
+
 func (cs *chatService) CreateChat(ctx context.Context, req *pb.CreateChatRequest) (*pb.CreateChatResponse, error) {
 	userId, ok := ctx.Value(middleware.TokenKey("user_id")).(uint64)
 	if !ok {
@@ -69,6 +107,10 @@ func (cs *chatService) CreateChat(ctx context.Context, req *pb.CreateChatRequest
 		return nil, status.Errorf(codes.InvalidArgument, "cannot create a chat with yourself")
 	}
 
+	if err := checkNotBlocked(ctx, cs.blockRepo, userId, targerUser.ID); err != nil {
+		return nil, err
+	}
+
 	chatId, err := cs.chatRepo.GetChatByUserIds(ctx, userId, targerUser.ID)
 	if err != sql.ErrNoRows && err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to check existing chat: %v", err)
@@ -134,15 +176,35 @@ func (cs *chatService) GetChats(ctx context.Context, req *pb.GetChatsRequst) (*p
 			encPadding = *chat.EncryptionPadding
 		}
 
+		avatarFileID := ""
+		if chat.AvatarFileID != nil {
+			avatarFileID = *chat.AvatarFileID
+		}
+
 		chatInfo := &pb.ChatInfo{
 			Username:            chat.Username,
 			EncryptionAlgorithm: encAlgorithm,
 			EncryptionMode:      encMode,
 			EncryptionPadding:   encPadding,
+			DisplayName:         chat.DisplayName,
+			AvatarFileId:        avatarFileID,
+			UnreadCount:         uint32(chat.UnreadCount),
+		}
+		if chat.MessageTTLSeconds != nil {
+			chatInfo.MessageTtlSeconds = uint32(*chat.MessageTTLSeconds)
 		}
 		response.Chats = append(response.Chats, chatInfo)
 	}
 
+	groups, err := cs.groupRepo.ListByUser(ctx, userId)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to fetch groups: %v", err)
+	}
+
+	for _, group := range groups {
+		response.Chats = append(response.Chats, groupToChatInfo(&group))
+	}
+
 	return response, nil
 }
 
@@ -181,8 +243,9 @@ func (s *chatService) ConnectToChat(ctx context.Context, req *pb.ConnectRequest)
 		return nil, status.Errorf(codes.Unauthenticated, "User ID is missing in contenxt")
 	}
 
-	s.streamManager.ClearConnectionAndStream(senderId)
-	log.Printf("Connection and stream are cleared")
+	if req.GetGroupId() != 0 {
+		return s.connectToGroup(ctx, senderId, req.GetGroupId())
+	}
 
 	receiverUsername := req.GetReceiverusername()
 	if receiverUsername == "" {
@@ -194,17 +257,19 @@ func (s *chatService) ConnectToChat(ctx context.Context, req *pb.ConnectRequest)
 		return nil, status.Errorf(codes.NotFound, "User '%s' does not exists", receiverUsername)
 	}
 
+	if err := checkNotBlocked(ctx, s.blockRepo, senderId, receiver.ID); err != nil {
+		return nil, err
+	}
+
 	_, err = s.chatRepo.GetChatByUserIds(ctx, senderId, receiver.ID)
 	if err != nil {
 		return nil, status.Errorf(codes.NotFound, "Chat with '%s' not found", receiverUsername)
 	}
 
-	err = s.streamManager.AddConnection(senderId, receiver.ID)
-	if err != nil {
-		return nil, status.Errorf(codes.AlreadyExists, "Chat with senderID=%d and receiverID=%d already exists", senderId, receiver.ID)
-	}
+	deviceId := middleware.DeviceID(ctx)
+	s.streamManager.Connect(senderId, deviceId, manager.Subscription{PeerID: receiver.ID})
 
-	log.Printf("User %d connected to chat with receiver '%s'", senderId, receiverUsername)
+	log.Printf("User %d connected to chat with receiver '%s' on device %q", senderId, receiverUsername, deviceId)
 
 	return &pb.ConnectResponse{
 		Success: true,
@@ -221,9 +286,20 @@ func (s *chatService) Chat(stream pb.ChatService_ChatServer) error {
 		return status.Errorf(codes.Unauthenticated, "user ID is missing in context")
 	}
 
-	receiverId, err := s.streamManager.GetConnectionData(senderId)
-	if err != nil {
-		return status.Errorf(codes.NotFound, "connection error: %v", err)
+	// Чат потока выбирается заранее через ConnectToChat на этом же устройстве
+	deviceId := middleware.DeviceID(ctx)
+	sub, ok := s.streamManager.PendingConnection(senderId, deviceId)
+	if !ok {
+		return status.Errorf(codes.NotFound, "connection error: no chat selected for userID=%d on device %q", senderId, deviceId)
+	}
+
+	if sub.GroupID != 0 {
+		return s.groupChat(stream, senderId, deviceId, sub.GroupID)
+	}
+	receiverId := sub.PeerID
+
+	if err := checkNotBlocked(ctx, s.blockRepo, senderId, receiverId); err != nil {
+		return err
 	}
 
 	senderUsername, err := s.userRepo.GetUserNameById(ctx, senderId)
@@ -236,153 +312,278 @@ func (s *chatService) Chat(stream pb.ChatService_ChatServer) error {
 		return status.Errorf(codes.NotFound, "failed to get receiver username: %v", err)
 	}
 
-	s.streamManager.AddStream(senderId, stream)
-	defer s.streamManager.ClearConnectionAndStream(senderId)
+	session := s.streamManager.Open(senderId, deviceId, sub, stream)
+	defer s.streamManager.Close(session)
 
 	chatID, err := s.chatRepo.GetChatByUserIds(ctx, senderId, receiverId)
 	if err != nil {
 		return status.Errorf(codes.Internal, "error checking chat existence: %v", err)
 	}
 
-	log.Printf("User %d started chatting with user %d", senderId, receiverId)
+	log.Printf("User %d started chatting with user %d on device %q", senderId, receiverId, deviceId)
 
-	messages, err := s.getHistory(stream.Context(), chatID)
-	if err != nil {
-		return status.Errorf(codes.Internal, "%v", err)
+	if err := s.sendStreamHistory(ctx, session, senderId, chatID, 0); err != nil {
+		return err
 	}
 
-	for _, message := range messages {
-		senderUsername, err := s.userRepo.GetUserNameById(stream.Context(), message.SenderId)
-		if err != nil {
-			return status.Errorf(codes.Internal, "failed to get sender username from message")
-		}
+	s.deliverQueuedMessages(ctx, session, senderId, senderUsername)
 
-		resp := &pb.ChatResponse{
-			Senderusername: senderUsername,
-			Content:        message.Content,
-			Timestamp:      message.Timestamp.Unix(),
-		}
+	messageWG := &sync.WaitGroup{}
+	defer messageWG.Wait()
 
-		err = stream.Send(resp)
-		if err != nil {
-			log.Printf("Failed to send message from history to user")
-			return status.Error(codes.Internal, "failed to send history message")
+	for {
+		select {
+		case <-ctx.Done():
+			log.Printf("Chat session for userID=%d ended", senderId)
+			return nil
+
+		default:
+			req, err := stream.Recv()
+			if err != nil {
+				if errors.Is(ctx.Err(), context.Canceled) {
+					log.Printf("Stream closed by user %d", senderId)
+					return nil
+				}
+				return status.Errorf(codes.Internal, "failed to receive message: %v", err)
+			}
+
+			if req.GetSignal() != pb.ChatSignal_SIGNAL_NONE {
+				if err := s.handleSignal(ctx, senderId, senderUsername, receiverId, 0, req.GetSignal()); err != nil {
+					return err
+				}
+				continue
+			}
+
+			confirmation, err := s.sendDirectMessage(ctx, chatID, senderId, senderUsername, receiverId, receiverUsername, req, messageWG)
+			if err != nil {
+				log.Printf("Rejected message from user %d: %v", senderId, err)
+				confirmation = rejectedResponse(req.GetClientId(), err)
+			}
+
+			if err := session.Send(confirmation); err != nil {
+				log.Printf("Failed to confirm message %d to sender %d: %v", confirmation.MessageId, senderId, err)
+			}
 		}
 	}
+}
+
+// sendDirectMessage сохраняет личное сообщение из потока Chat или SendMessage и рассылает его в фоне,
+// учитывая рассылку в wg. Возвращает подтверждение для отправителя: MESSAGE_SAVED или MESSAGE_SCHEDULED.
+func (s *chatService) sendDirectMessage(ctx context.Context, chatID, senderId uint64, senderUsername string, receiverId uint64, receiverUsername string,
+	req *pb.ChatMessage, wg *sync.WaitGroup) (*pb.ChatResponse, error) {
+	envelope, err := s.messageEnvelope(ctx, chatID, req.GetEnvelope(), req.GetContent())
+	if err != nil {
+		return nil, err
+	}
 
-	log.Printf("finished loading history")
+	if err := checkNotBlocked(ctx, s.blockRepo, senderId, receiverId); err != nil {
+		return nil, err
+	}
 
-	handleMessage := func(senderUsername, content string, timestamp time.Time) error {
+	message := &entities.Message{
+		ChatID:     chatID,
+		SenderId:   senderId,
+		ReceiverId: receiverId,
+		Timestamp:  time.Now(),
+		Envelope:   envelope,
+	}
 
-		resp := &pb.ChatResponse{
-			Senderusername: senderUsername,
-			Content:        content,
-			Timestamp:      timestamp.Unix(),
+	if replyToId := req.GetReplyToMessageId(); replyToId != 0 {
+		threadRootId, err := s.replyThread(ctx, chatID, replyToId)
+		if err != nil {
+			return nil, err
 		}
+		message.ReplyToID = replyToId
+		message.ThreadRootID = threadRootId
+	}
 
-		if err := stream.Send(resp); err != nil {
-			log.Printf("Failed to send message: %v", err)
+	deliverAt, err := prepareMessage(message, req)
+	if err != nil {
+		return nil, err
+	}
 
-			return fmt.Errorf("stream.Send failed: %v", err)
-		}
+	if deliverAt != nil {
+		return s.scheduleMessage(ctx, message, *deliverAt, req.GetClientId())
+	}
 
-		log.Printf("Message sent to client: %s", content)
-		return nil
+	// ID нужен получателю и отправителю, поэтому сообщение сохраняется до рассылки
+	if err := s.messageRepo.SaveMessage(message); err != nil {
+		log.Printf("Failed to save message: %v", err)
+		return nil, status.Errorf(codes.Internal, "failed to save message")
 	}
+	s.indexSentMessage(ctx, message, req.GetSearchTokens())
 
-	func() {
-		defer log.Printf("Offline message processor for user %d stopped", senderId)
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		s.deliverDirectMessage(ctx, message, senderUsername, receiverUsername)
+	}()
 
-		receiverQueue := fmt.Sprintf("chat_queue_%s", senderUsername)
+	return savedResponse(message, req.GetClientId()), nil
+}
 
-		hasMessages, err := s.broker.CheckMessages(receiverQueue)
-		if err != nil {
-			log.Printf("Error checking messages for queue %s", receiverQueue)
-			return
-		}
+// deliverDirectMessage отправляет сохраненное личное сообщение получателю в поток,
+// а если он не в сети — в его очередь
+func (s *chatService) deliverDirectMessage(ctx context.Context, message *entities.Message, senderUsername, receiverUsername string) {
+	queued := queuedMessage(message, senderUsername)
+	s.routeEvent(message.ReceiverId, receiverUsername, message.SenderId, message.SenderId, messageToResponse(message, senderUsername), &queued)
+}
 
-		if !hasMessages {
-			log.Printf("User %s has no messages", senderUsername)
-			return
-		}
+// queuedEventKey отличает событие в очереди пользователя от его копий, положенных другими экземплярами
+type queuedEventKey struct {
+	event     string
+	messageId uint64
+	sender    string
+	timestamp int64
+}
 
-		if err := s.broker.ProcessMessages(receiverQueue, handleMessage); err != nil {
-			log.Printf("Error checking queue %s messages", receiverQueue)
+// deliverQueuedMessages отправляет в поток сообщения и события, накопившиеся в очереди пользователя,
+// пока он был не в сети. События чатов, которые не открыты в потоке, остаются в очереди до подключения
+// потока этого чата или потока ReceiveMessages. Личные сообщения от заблокированных пользователей
+// подтверждаются без доставки.
+func (s *chatService) deliverQueuedMessages(ctx context.Context, session *manager.Session, userId uint64, username string) {
+	defer log.Printf("Offline message processor for user %d stopped", userId)
+
+	// ID отправителей; 0 — отправитель удален, и его сообщения отбрасываются
+	senderIds := make(map[string]uint64)
+	// Событие, чат которого не открыт ни в одном потоке, кладет в очередь каждый экземпляр с потоками пользователя
+	seen := make(map[queuedEventKey]bool)
+	handleMessage := func(message broker.QueuedMessage) error {
+		key := queuedEventKey{message.Event, message.MessageID, message.SenderUsername, message.Timestamp.UnixNano()}
+		if message.MessageID != 0 && seen[key] {
+			return nil
 		}
-	}()
 
-	messageWG := &sync.WaitGroup{}
-	defer messageWG.Wait()
+		senderId, checked := senderIds[message.SenderUsername]
+		if !checked {
+			sender, err := s.userRepo.GetByUsername(ctx, message.SenderUsername)
+			if err != nil && !errors.Is(err, sql.ErrNoRows) {
+				return err
+			}
+			if err == nil {
+				senderId = sender.ID
+			}
+			senderIds[message.SenderUsername] = senderId
+		}
 
-	for {
-		select {
-		case <-ctx.Done():
-			s.streamManager.ClearConnectionAndStream(senderId)
-			log.Printf("Chat session for userID=%d ended", senderId)
+		if senderId == 0 {
+			log.Printf("Dropping queued message from unknown sender %s", message.SenderUsername)
 			return nil
+		}
 
-		default:
-			req, err := stream.Recv()
+		// Собеседник личного чата неизвестен только у собственных событий пользователя,
+		// например у отправленного отложенного сообщения; они показываются в любом потоке
+		target := manager.Subscription{GroupID: message.GroupID}
+		if message.GroupID == 0 {
+			target.PeerID = senderId
+		}
+		if senderId != userId && !session.Subscription.Watches(target) {
+			// Одна копия остается в очереди, остальные подтверждаются как повторы
+			seen[key] = true
+			return broker.ErrSkipMessage
+		}
+
+		// Блокировка проверяется для каждого сообщения: пользователь может изменить список, пока поток открыт
+		if message.GroupID == 0 {
+			blocked, err := s.blockRepo.IsBlocked(ctx, userId, senderId)
 			if err != nil {
-				if errors.Is(ctx.Err(), context.Canceled) {
-					s.streamManager.ClearConnectionAndStream(senderId)
-					log.Printf("Stream closed by user %d", senderId)
-					return nil
-				}
-				return status.Errorf(codes.Internal, "failed to receive message: %v", err)
+				return err
+			}
+
+			if blocked {
+				log.Printf("Dropping queued message from blocked user %s", message.SenderUsername)
+				return nil
 			}
+		}
 
-			content := req.GetContent()
-			if content == "" {
-				return status.Errorf(codes.InvalidArgument, "message content cannot be empty")
+		resp := &pb.ChatResponse{
+			Senderusername:   message.SenderUsername,
+			Timestamp:        message.Timestamp.Unix(),
+			GroupId:          message.GroupID,
+			MessageId:        message.MessageID,
+			ReplyToMessageId: message.ReplyToID,
+			ThreadRootId:     message.ThreadRootID,
+			ScheduledId:      message.ScheduledID,
+		}
+
+		if message.ExpiresAt != nil {
+			if message.ExpiresAt.Before(time.Now()) {
+				log.Printf("Dropping expired queued message %d", message.MessageID)
+				return nil
 			}
+			resp.ExpiresAt = message.ExpiresAt.Unix()
+		}
 
-			message := &entities.Message{
-				ChatID:     chatID,
-				SenderId:   senderId,
-				ReceiverId: receiverId,
-				Content:    content,
-				Timestamp:  time.Now(),
+		switch message.Event {
+		case broker.EventMessage, broker.EventEdited, broker.EventReleased:
+			envelope, err := queuedEnvelope(message)
+			if err != nil {
+				log.Printf("Dropping queued message %d with malformed envelope: %v", message.MessageID, err)
+				return nil
 			}
+			resp.Envelope = envelope
+		}
 
-			messageWG.Add(1)
-			// need to refactor
-			go func() {
-				defer messageWG.Done()
-				if err := s.messageRepo.SaveMessage(message); err != nil {
-					log.Printf("Failed to save message: %v", err)
-				}
-			}()
-
-			messageWG.Add(1)
-			go func() {
-				defer messageWG.Done()
-				recvStream, err := s.streamManager.GetStream(receiverId)
-				if err == nil {
-					if err := recvStream.Send(&pb.ChatResponse{
-						Senderusername: senderUsername,
-						Content:        content,
-						Timestamp:      message.Timestamp.Unix(),
-					}); err != nil {
-						log.Printf("Failed to send message to receiver %d: %v", receiverId, err)
-						s.broker.PublishMessage(senderUsername, receiverUsername, content, message.Timestamp)
-					}
-				} else {
-					if err := s.broker.PublishMessage(senderUsername, receiverUsername, content, message.Timestamp); err != nil {
-						log.Printf("Failed to publish message to queue: %v", err)
-					}
-				}
-			}()
+		switch message.Event {
+		case broker.EventEdited:
+			resp.Event = pb.ChatEventType_MESSAGE_EDITED
+			resp.EditedAt = message.Timestamp.Unix()
+		case broker.EventDeleted:
+			resp.Event = pb.ChatEventType_MESSAGE_DELETED
+			resp.Deleted = true
+		case broker.EventDelivered, broker.EventRead:
+			// Отметку прислал не автор сообщения, а его получатель
+			resp.Event = pb.ChatEventType_MESSAGE_DELIVERED
+			if message.Event == broker.EventRead {
+				resp.Event = pb.ChatEventType_MESSAGE_READ
+			}
+			resp.Senderusername = ""
+			resp.ReceiptFrom = message.SenderUsername
+		case broker.EventReactionAdded, broker.EventReactionRemoved:
+			resp.Event = pb.ChatEventType_REACTION_ADDED
+			if message.Event == broker.EventReactionRemoved {
+				resp.Event = pb.ChatEventType_REACTION_REMOVED
+			}
+			resp.Reaction = message.Content
+		case broker.EventReleased:
+			// Свое отложенное сообщение, отправленное, пока пользователь был не в сети
+			resp.Event = pb.ChatEventType_MESSAGE_SAVED
+			resp.Senderusername = ""
+		case broker.EventPinned:
+			resp.Event = pb.ChatEventType_MESSAGE_PINNED
+		case broker.EventUnpinned:
+			resp.Event = pb.ChatEventType_MESSAGE_UNPINNED
+		}
+
+		if err := session.Send(resp); err != nil {
+			log.Printf("Failed to send message: %v", err)
+
+			return fmt.Errorf("stream.Send failed: %v", err)
+		}
+
+		seen[key] = true
+		if message.Event == broker.EventMessage && message.MessageID != 0 {
+			s.markDelivered(ctx, message.MessageID, message.GroupID, userId, username, senderId)
 		}
+
+		log.Printf("Queued event %s for message %d sent to client", message.Event, message.MessageID)
+		return nil
 	}
-}
 
-func (s *chatService) getHistory(ctx context.Context, chatId uint64) ([]entities.Message, error) {
-	limit := 100
-	messages, err := s.messageRepo.GetHistory(ctx, chatId, limit)
+	receiverQueue := fmt.Sprintf("chat_queue_%s", username)
+
+	hasMessages, err := s.broker.CheckMessages(receiverQueue)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get last 100 messages: %v", err)
+		log.Printf("Error checking messages for queue %s", receiverQueue)
+		return
+	}
+
+	if !hasMessages {
+		log.Printf("User %s has no messages", username)
+		return
 	}
 
-	return messages, nil
+	if err := s.broker.ProcessMessages(receiverQueue, handleMessage); err != nil {
+		log.Printf("Error checking queue %s messages", receiverQueue)
+	}
 }
@@ -0,0 +1,198 @@
+package service
+
+import (
+	"context"
+	"gRPCWebServer/backend/broker"
+	pb "gRPCWebServer/backend/generated"
+	"gRPCWebServer/backend/manager"
+	"log"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// Пауза между попытками восстановить соединение с RabbitMQ
+const clusterReconnectDelay = 5 * time.Second
+
+// Как часто экземпляр рассылает полный снимок своих пользователей в сети. Экземпляр,
+// не приславший ни одного снимка за presenceExpiry, считается остановленным.
+const (
+	presenceRefreshInterval = 30 * time.Second
+	presenceExpiry          = 3 * presenceRefreshInterval
+)
+
+// RunClusterFanOut доставляет в потоки этого экземпляра события, пересланные через обмен кластера,
+// и принимает состояния пользователей с остальных экземпляров. Когда соединение с RabbitMQ
+// закрывается, переподключается и заново привязывает пользователей, у которых открыты потоки
+// на этом экземпляре.
+func (s *chatService) RunClusterFanOut() {
+	for {
+		// Остальные экземпляры пришлют свои снимки в очередь, которая уже объявлена
+		s.publishPresenceSnapshot(true)
+
+		err := s.cluster.Consume(s.deliverClusterEvent, s.applyPresenceEvent)
+		log.Printf("Cluster fan-out stopped: %v", err)
+
+		for {
+			err := s.cluster.Reconnect()
+			if err == nil {
+				break
+			}
+			log.Printf("Failed to reconnect to cluster exchange: %v", err)
+			time.Sleep(clusterReconnectDelay)
+		}
+
+		s.streamManager.Rebind()
+		log.Printf("Cluster fan-out reconnected")
+	}
+}
+
+// routeEvent отправляет событие в потоки пользователя на этом экземпляре сервера и всегда публикует его
+// в обмен кластера: у пользователя могут быть открыты потоки и на других экземплярах.
+// Событие личного чата с peerId получают только потоки этого чата и потоки ReceiveMessages.
+// Если потоков нет, событие с queued кладется в очередь пользователя; сигнал (queued == nil) теряется.
+func (s *chatService) routeEvent(userId uint64, username string, senderId, peerId uint64, resp *pb.ChatResponse, queued *broker.QueuedMessage) {
+	data, err := proto.Marshal(resp)
+	if err != nil {
+		log.Printf("Failed to marshal event for user %d: %v", userId, err)
+		return
+	}
+
+	event := broker.ClusterEvent{
+		ReceiverID:       userId,
+		ReceiverUsername: username,
+		SenderID:         senderId,
+		PeerID:           peerId,
+		Response:         data,
+		Signal:           queued == nil,
+		Queued:           queued,
+		Instance:         s.instanceId,
+	}
+	event.Watched = s.deliverLocalEvent(event)
+
+	err = s.cluster.Publish(event)
+	if err == nil {
+		return
+	}
+	log.Printf("Failed to route event to user %d: %v", userId, err)
+
+	if queued == nil || event.Watched {
+		return
+	}
+
+	if err := s.broker.PublishEvent([]string{username}, *queued); err != nil {
+		log.Printf("Failed to publish event to queue: %v", err)
+	}
+}
+
+// deliverClusterEvent доставляет событие, пришедшее через обмен кластера. Свою копию экземпляр
+// пропускает: routeEvent уже поставил событие в его потоки. Возвращает false, если событие нужно
+// положить в очередь пользователя: ни в одном потоке этого экземпляра и отправившего его не открыт чат события.
+func (s *chatService) deliverClusterEvent(event broker.ClusterEvent) bool {
+	if event.Instance == s.instanceId {
+		return event.Watched
+	}
+
+	return s.deliverLocalEvent(event) || event.Watched
+}
+
+// deliverLocalEvent ставит событие в потоки пользователя на этом экземпляре и отмечает доставленным
+// сообщение, попавшее хотя бы в один поток. Возвращает false, если ни в одном потоке не открыт чат события.
+func (s *chatService) deliverLocalEvent(event broker.ClusterEvent) bool {
+	resp := &pb.ChatResponse{}
+	if err := proto.Unmarshal(event.Response, resp); err != nil {
+		log.Printf("Failed to unmarshal event for user %d: %v", event.ReceiverID, err)
+		return false
+	}
+
+	// Событие показывается в потоках, где открыт его чат, и в потоках ReceiveMessages
+	sub := manager.Subscription{GroupID: resp.GroupId}
+	if resp.GroupId == 0 {
+		sub.PeerID = event.PeerID
+	}
+
+	delivery := s.streamManager.DeliverWatching(event.ReceiverID, sub, resp)
+	if !delivery.Watched() {
+		return false
+	}
+
+	// В очередь событие попадает только ради потоков, не принявших его: повторная
+	// рассылка через routeEvent отправила бы его принявшим потокам еще раз
+	if delivery.Overflowed > 0 && event.Queued != nil {
+		if err := s.broker.PublishEvent([]string{event.ReceiverUsername}, *event.Queued); err != nil {
+			log.Printf("Failed to queue event for overflowed streams of user %d: %v", event.ReceiverID, err)
+		}
+	}
+
+	if event.Signal || delivery.Accepted == 0 {
+		return true
+	}
+
+	if event.Queued != nil && event.Queued.Event == broker.EventMessage {
+		s.markDelivered(context.Background(), event.Queued.MessageID, event.Queued.GroupID, event.ReceiverID, event.ReceiverUsername, event.SenderID)
+	}
+
+	return true
+}
+
+// RunPresenceSync рассылает остальным экземплярам изменения состояния пользователей этого экземпляра
+// и периодически — полный снимок, по которому остальные забывают остановленные экземпляры
+func (s *chatService) RunPresenceSync() {
+	ticker := time.NewTicker(presenceRefreshInterval)
+	defer ticker.Stop()
+
+	changes := s.presence.LocalChanges()
+	for {
+		select {
+		case update := <-changes:
+			err := s.cluster.PublishPresence(broker.PresenceEvent{
+				Instance: s.instanceId,
+				Statuses: map[uint64]int{update.UserID: int(update.Status)},
+			})
+			if err != nil {
+				log.Printf("Failed to publish presence of user %d: %v", update.UserID, err)
+			}
+
+		case <-ticker.C:
+			s.publishPresenceSnapshot(false)
+			s.presence.ExpireRemote(time.Now().Add(-presenceExpiry))
+		}
+	}
+}
+
+// publishPresenceSnapshot рассылает всех пользователей в сети на этом экземпляре
+func (s *chatService) publishPresenceSnapshot(syncRequest bool) {
+	local := s.presence.LocalStatuses()
+	statuses := make(map[uint64]int, len(local))
+	for userId, status := range local {
+		statuses[userId] = int(status)
+	}
+
+	err := s.cluster.PublishPresence(broker.PresenceEvent{
+		Instance:    s.instanceId,
+		Statuses:    statuses,
+		Full:        true,
+		SyncRequest: syncRequest,
+	})
+	if err != nil {
+		log.Printf("Failed to publish presence snapshot: %v", err)
+	}
+}
+
+// applyPresenceEvent учитывает состояние пользователей другого экземпляра
+func (s *chatService) applyPresenceEvent(event broker.PresenceEvent) {
+	if event.Instance == s.instanceId {
+		return
+	}
+
+	statuses := make(map[uint64]manager.PresenceStatus, len(event.Statuses))
+	for userId, status := range event.Statuses {
+		statuses[userId] = manager.PresenceStatus(status)
+	}
+	s.presence.SetRemote(event.Instance, statuses, event.Full)
+
+	// Снимок в ответ на запрос получает и запросивший экземпляр, и все остальные
+	if event.SyncRequest {
+		s.publishPresenceSnapshot(false)
+	}
+}
@@ -0,0 +1,146 @@
+package service
+
+import (
+	"context"
+	"gRPCWebServer/backend/broker"
+	"gRPCWebServer/backend/entities"
+	pb "gRPCWebServer/backend/generated"
+	"log"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+const maxAttachments = 10
+
+// messageEnvelope проверяет содержимое нового сообщения и заполняет вложения описаниями файлов чата.
+// Клиенты, которые еще не передают конверт, присылают текст сообщения в content.
+func (cs *chatService) messageEnvelope(ctx context.Context, chatId uint64, envelope *pb.MessageEnvelope, content string) (entities.Envelope, error) {
+	if envelope == nil {
+		if content == "" {
+			return entities.Envelope{}, status.Errorf(codes.InvalidArgument, "message content cannot be empty")
+		}
+		return entities.Envelope{Kind: entities.KindText, Payload: []byte(content)}, nil
+	}
+
+	switch envelope.Kind {
+	case pb.MessageKind_KIND_TEXT:
+		if len(envelope.Attachments) > 0 {
+			return entities.Envelope{}, status.Errorf(codes.InvalidArgument, "text message cannot have attachments")
+		}
+		if len(envelope.Payload) == 0 {
+			return entities.Envelope{}, status.Errorf(codes.InvalidArgument, "message content cannot be empty")
+		}
+	case pb.MessageKind_KIND_FILE:
+		if len(envelope.Attachments) == 0 {
+			return entities.Envelope{}, status.Errorf(codes.InvalidArgument, "file message must have attachments")
+		}
+		if len(envelope.Attachments) > maxAttachments {
+			return entities.Envelope{}, status.Errorf(codes.InvalidArgument, "message cannot have more than %d attachments", maxAttachments)
+		}
+	default:
+		return entities.Envelope{}, status.Errorf(codes.InvalidArgument, "unknown message kind %d", envelope.Kind)
+	}
+
+	result := envelopeBody(envelope)
+	result.Kind = int(envelope.Kind)
+	if len(envelope.Attachments) == 0 {
+		return result, nil
+	}
+
+	// Описания файлов берутся с сервера, а не из запроса: приложить можно только файл, загруженный в этот чат
+	fileIds := make([]string, 0, len(envelope.Attachments))
+	for _, attachment := range envelope.Attachments {
+		fileIds = append(fileIds, attachment.FileId)
+	}
+
+	attachments, err := cs.messageRepo.FindAttachments(ctx, chatId, fileIds)
+	if err != nil {
+		return entities.Envelope{}, status.Errorf(codes.Internal, "%v", err)
+	}
+
+	if len(attachments) != len(fileIds) {
+		return entities.Envelope{}, status.Errorf(codes.NotFound, "attached file not found in this chat")
+	}
+
+	result.Attachments = attachments
+	return result, nil
+}
+
+// editedEnvelope возвращает новое тело сообщения из запроса правки
+func editedEnvelope(envelope *pb.MessageEnvelope, content string) (entities.Envelope, error) {
+	if envelope == nil {
+		envelope = &pb.MessageEnvelope{Payload: []byte(content)}
+	}
+
+	if len(envelope.Payload) == 0 {
+		return entities.Envelope{}, status.Errorf(codes.InvalidArgument, "message content cannot be empty")
+	}
+
+	return envelopeBody(envelope), nil
+}
+
+// envelopeBody возвращает тело конверта, его IV и параметры шифра без типа и вложений
+func envelopeBody(envelope *pb.MessageEnvelope) entities.Envelope {
+	return entities.Envelope{
+		Payload:         envelope.Payload,
+		IV:              envelope.Iv,
+		CipherAlgorithm: envelope.GetCipher().GetAlgorithm(),
+		CipherMode:      envelope.GetCipher().GetMode(),
+		CipherPadding:   envelope.GetCipher().GetPadding(),
+	}
+}
+
+func envelopeToProto(envelope *entities.Envelope) *pb.MessageEnvelope {
+	result := &pb.MessageEnvelope{
+		Kind:    pb.MessageKind(envelope.Kind),
+		Payload: envelope.Payload,
+		Iv:      envelope.IV,
+	}
+
+	if envelope.CipherAlgorithm != "" || envelope.CipherMode != "" || envelope.CipherPadding != "" {
+		result.Cipher = &pb.CipherParams{
+			Algorithm: envelope.CipherAlgorithm,
+			Mode:      envelope.CipherMode,
+			Padding:   envelope.CipherPadding,
+		}
+	}
+
+	for _, attachment := range envelope.Attachments {
+		result.Attachments = append(result.Attachments, &pb.Attachment{
+			FileId:   attachment.FileID,
+			FileName: attachment.FileName,
+			FileSize: attachment.FileSize,
+			MimeType: attachment.MimeType,
+		})
+	}
+
+	return result
+}
+
+// marshalEnvelope сериализует конверт для очереди получателя
+func marshalEnvelope(envelope *entities.Envelope) []byte {
+	data, err := proto.Marshal(envelopeToProto(envelope))
+	if err != nil {
+		log.Printf("Failed to marshal message envelope: %v", err)
+		return nil
+	}
+
+	return data
+}
+
+// queuedEnvelope восстанавливает конверт сообщения из очереди. Сообщения,
+// поставленные в очередь до появления конвертов, содержат только текст.
+func queuedEnvelope(message broker.QueuedMessage) (*pb.MessageEnvelope, error) {
+	if message.Envelope == nil {
+		return &pb.MessageEnvelope{Payload: []byte(message.Content)}, nil
+	}
+
+	envelope := &pb.MessageEnvelope{}
+	if err := proto.Unmarshal(message.Envelope, envelope); err != nil {
+		return nil, err
+	}
+
+	return envelope, nil
+}
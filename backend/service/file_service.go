@@ -34,6 +34,8 @@ type FileService struct {
 	fileRepo     repository.FileRepository
 	userRepo     repository.UserRepository
 	chatRepo     repository.ChatRepository
+	blockRepo    repository.BlockRepository
+	groupRepo    repository.GroupRepository
 	fileUploads  map[string]*ActiveUpload // uploadID -> активная загрузка
 	uploadsMutex sync.RWMutex
 	baseFilePath string // Базовый путь для хранения файлов
@@ -43,6 +45,8 @@ func NewFileService(
 	fileRepo repository.FileRepository,
 	userRepo repository.UserRepository,
 	chatRepo repository.ChatRepository,
+	blockRepo repository.BlockRepository,
+	groupRepo repository.GroupRepository,
 	baseFilePath string,
 ) *FileService {
 	// Создаем директории для хранения файлов, если они не существуют
@@ -53,6 +57,8 @@ func NewFileService(
 		fileRepo:     fileRepo,
 		userRepo:     userRepo,
 		chatRepo:     chatRepo,
+		blockRepo:    blockRepo,
+		groupRepo:    groupRepo,
 		fileUploads:  make(map[string]*ActiveUpload),
 		uploadsMutex: sync.RWMutex{},
 		baseFilePath: baseFilePath,
@@ -66,13 +72,24 @@ func (s *FileService) InitFileUpload(ctx context.Context, req *pb.InitFileUpload
 		return nil, status.Errorf(codes.Unauthenticated, "Требуется аутентификация")
 	}
 
-	// Получаем чат
-	chat, err := s.chatRepo.GetChatByUsername(ctx, req.ChatUsername)
-	if err != nil {
-		return nil, status.Errorf(codes.Internal, "Ошибка при получении информации о чате: %v", err)
-	}
-	if chat == nil {
-		return nil, status.Errorf(codes.NotFound, "Чат не найден")
+	// Без имени собеседника и группы загружается личный файл пользователя (например, аватар)
+	var chatID *uint64
+	if req.GroupId != 0 || req.ChatUsername != "" {
+		chat, err := s.requestChat(ctx, userID, req.ChatUsername, req.GroupId)
+		if err != nil {
+			return nil, err
+		}
+
+		if !chat.IsGroup {
+			peerID := chat.FirstUserID
+			if peerID == userID {
+				peerID = chat.SecondUserID
+			}
+			if err := checkNotBlocked(ctx, s.blockRepo, userID, peerID); err != nil {
+				return nil, err
+			}
+		}
+		chatID = &chat.ID
 	}
 
 	// Генерируем уникальный ID для загрузки
@@ -100,7 +117,7 @@ func (s *FileService) InitFileUpload(ctx context.Context, req *pb.InitFileUpload
 		ChunkSize:      chunkSize,
 		TempPath:       tempFilePath,
 		UserID:         userID,
-		ChatID:         chat.ID,
+		ChatID:         chatID,
 		Status:         "in_progress",
 		CreatedAt:      time.Now(),
 		UpdatedAt:      time.Now(),
@@ -320,7 +337,10 @@ func (s *FileService) FinalizeFileUpload(ctx context.Context, req *pb.FinalizeFi
 	}
 
 	// Создаем директорию для сохранения файла
-	finalDir := filepath.Join(s.baseFilePath, "files", strconv.FormatUint(currentUpload.uploadInfo.ChatID, 10))
+	finalDir := filepath.Join(s.baseFilePath, "files", "users", strconv.FormatUint(currentUpload.uploadInfo.UserID, 10))
+	if currentUpload.uploadInfo.ChatID != nil {
+		finalDir = filepath.Join(s.baseFilePath, "files", strconv.FormatUint(*currentUpload.uploadInfo.ChatID, 10))
+	}
 	err = os.MkdirAll(finalDir, 0755)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "Ошибка при создании директории: %v", err)
@@ -396,18 +416,9 @@ func (s *FileService) GetFileInfo(ctx context.Context, req *pb.GetFileInfoReques
 	}
 
 	// Получаем информацию о чате, чтобы проверить права доступа
-	chat, err := s.chatRepo.GetChatByID(ctx, file.ChatID)
+	chat, err := s.readableFileChat(ctx, file, userID)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "Ошибка при получении информации о чате: %v", err)
-	}
-
-	if chat == nil {
-		return nil, status.Errorf(codes.NotFound, "Чат не найден")
-	}
-
-	// Проверяем, есть ли у пользователя доступ к чату
-	if chat.FirstUserID != userID && chat.SecondUserID != userID {
-		return nil, status.Errorf(codes.PermissionDenied, "У вас нет доступа к этому файлу")
+		return nil, err
 	}
 
 	// Получаем имя пользователя, загрузившего файл
@@ -422,10 +433,15 @@ func (s *FileService) GetFileInfo(ctx context.Context, req *pb.GetFileInfoReques
 
 	// Получаем имя пользователя чата
 	var chatUsername string
-	if chat.FirstUserID == userID {
-		chatUsername = chat.SecondUsername
-	} else {
-		chatUsername = chat.FirstUsername
+	var groupID uint64
+	if chat != nil && chat.IsGroup {
+		groupID = chat.ID
+	} else if chat != nil {
+		if chat.FirstUserID == userID {
+			chatUsername = chat.SecondUsername
+		} else {
+			chatUsername = chat.FirstUsername
+		}
 	}
 
 	return &pb.GetFileInfoResponse{
@@ -436,6 +452,7 @@ func (s *FileService) GetFileInfo(ctx context.Context, req *pb.GetFileInfoReques
 		CreatedAt:    file.CreatedAt.Unix(),
 		UploadedBy:   uploader.Username,
 		ChatUsername: chatUsername,
+		GroupId:      groupID,
 	}, nil
 }
 
@@ -458,19 +475,9 @@ func (s *FileService) DownloadFile(req *pb.DownloadFileRequest, stream pb.FileSe
 		return status.Errorf(codes.NotFound, "Файл не найден")
 	}
 
-	// Получаем информацию о чате, чтобы проверить права доступа
-	chat, err := s.chatRepo.GetChatByID(ctx, file.ChatID)
-	if err != nil {
-		return status.Errorf(codes.Internal, "Ошибка при получении информации о чате: %v", err)
-	}
-
-	if chat == nil {
-		return status.Errorf(codes.NotFound, "Чат не найден")
-	}
-
-	// Проверяем, есть ли у пользователя доступ к чату
-	if chat.FirstUserID != userID && chat.SecondUserID != userID {
-		return status.Errorf(codes.PermissionDenied, "У вас нет доступа к этому файлу")
+	// Проверяем права доступа
+	if _, err := s.readableFileChat(ctx, file, userID); err != nil {
+		return err
 	}
 
 	// Проверяем существование файла по сохраненному пути
@@ -550,19 +557,10 @@ func (s *FileService) GetChatFiles(ctx context.Context, req *pb.GetChatFilesRequ
 		return nil, status.Errorf(codes.Unauthenticated, "Требуется аутентификация")
 	}
 
-	// Получаем информацию о чате
-	chat, err := s.chatRepo.GetChatByUsername(ctx, req.ChatUsername)
+	// Получаем информацию о чате и проверяем, есть ли у пользователя доступ к нему
+	chat, err := s.requestChat(ctx, userID, req.ChatUsername, req.GroupId)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "Ошибка при получении информации о чате: %v", err)
-	}
-
-	if chat == nil {
-		return nil, status.Errorf(codes.NotFound, "Чат не найден")
-	}
-
-	// Проверяем, есть ли у пользователя доступ к чату
-	if chat.FirstUserID != userID && chat.SecondUserID != userID {
-		return nil, status.Errorf(codes.PermissionDenied, "У вас нет доступа к этому чату")
+		return nil, err
 	}
 
 	// Определяем параметры пагинации
@@ -636,17 +634,24 @@ func (s *FileService) DeleteFile(ctx context.Context, req *pb.DeleteFileRequest)
 	// Проверяем, является ли пользователь владельцем файла
 	if file.UploadedBy != userID {
 		// Получаем информацию о чате, чтобы проверить права доступа
-		chat, err := s.chatRepo.GetChatByID(ctx, file.ChatID)
+		chat, err := s.fileChat(ctx, file)
 		if err != nil {
-			return nil, status.Errorf(codes.Internal, "Ошибка при получении информации о чате: %v", err)
+			return nil, err
 		}
 
+		// Личный файл может удалить только владелец, файл группы — ее владелец или администратор
 		if chat == nil {
-			return nil, status.Errorf(codes.NotFound, "Чат не найден")
+			return nil, status.Errorf(codes.PermissionDenied, "У вас нет доступа к этому файлу")
 		}
-
-		// Проверяем, есть ли у пользователя доступ к чату
-		if chat.FirstUserID != userID && chat.SecondUserID != userID {
+		if chat.IsGroup {
+			member, err := s.groupRepo.GetMember(ctx, chat.ID, userID)
+			if err != nil {
+				return nil, status.Errorf(codes.Internal, "Ошибка при проверке доступа к файлу: %v", err)
+			}
+			if member == nil || member.Role == entities.RoleMember {
+				return nil, status.Errorf(codes.PermissionDenied, "У вас нет доступа к этому файлу")
+			}
+		} else if chat.FirstUserID != userID && chat.SecondUserID != userID {
 			return nil, status.Errorf(codes.PermissionDenied, "У вас нет доступа к этому файлу")
 		}
 	}
@@ -663,6 +668,103 @@ func (s *FileService) DeleteFile(ctx context.Context, req *pb.DeleteFileRequest)
 	}, nil
 }
 
+// fileChat возвращает чат, к которому относится файл; nil для личных файлов
+func (s *FileService) fileChat(ctx context.Context, file *entities.File) (*entities.Chat, error) {
+	if file.ChatID == nil {
+		return nil, nil
+	}
+
+	chat, err := s.chatRepo.GetChatByID(ctx, *file.ChatID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Ошибка при получении информации о чате: %v", err)
+	}
+
+	if chat == nil {
+		return nil, status.Errorf(codes.NotFound, "Чат не найден")
+	}
+
+	return chat, nil
+}
+
+// requestChat возвращает чат из запроса: группу groupID, если она задана, иначе личный чат
+// с пользователем chatUsername. Пользователь должен быть участником чата.
+func (s *FileService) requestChat(ctx context.Context, userID uint64, chatUsername string, groupID uint64) (*entities.Chat, error) {
+	var chat *entities.Chat
+	var err error
+	if groupID != 0 {
+		chat, err = s.chatRepo.GetChatByID(ctx, groupID)
+	} else {
+		chat, err = s.chatRepo.GetChatByUsername(ctx, userID, chatUsername)
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Ошибка при получении информации о чате: %v", err)
+	}
+
+	if chat == nil || (groupID != 0 && !chat.IsGroup) {
+		return nil, status.Errorf(codes.NotFound, "Чат не найден")
+	}
+
+	isParticipant, err := s.isChatParticipant(ctx, chat, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !isParticipant {
+		return nil, status.Errorf(codes.PermissionDenied, "У вас нет доступа к этому чату")
+	}
+
+	return chat, nil
+}
+
+// isChatParticipant сообщает, участвует ли пользователь в личном чате или состоит в группе
+func (s *FileService) isChatParticipant(ctx context.Context, chat *entities.Chat, userID uint64) (bool, error) {
+	if !chat.IsGroup {
+		return chat.FirstUserID == userID || chat.SecondUserID == userID, nil
+	}
+
+	member, err := s.groupRepo.GetMember(ctx, chat.ID, userID)
+	if err != nil {
+		return false, status.Errorf(codes.Internal, "Ошибка при проверке участия в группе: %v", err)
+	}
+
+	return member != nil, nil
+}
+
+// readableFileChat проверяет, может ли пользователь читать файл, и возвращает его чат.
+// Файл чата доступен участникам чата. Личный файл доступен владельцу, а остальным
+// пользователям — только если он стоит аватаром в профиле.
+func (s *FileService) readableFileChat(ctx context.Context, file *entities.File, userID uint64) (*entities.Chat, error) {
+	chat, err := s.fileChat(ctx, file)
+	if err != nil {
+		return nil, err
+	}
+
+	if chat != nil {
+		isParticipant, err := s.isChatParticipant(ctx, chat, userID)
+		if err != nil {
+			return nil, err
+		}
+		if !isParticipant {
+			return nil, status.Errorf(codes.PermissionDenied, "У вас нет доступа к этому файлу")
+		}
+		return chat, nil
+	}
+
+	if file.UploadedBy == userID {
+		return nil, nil
+	}
+
+	isAvatar, err := s.fileRepo.IsProfileAvatar(ctx, file.FileID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Ошибка при проверке доступа к файлу: %v", err)
+	}
+
+	if !isAvatar {
+		return nil, status.Errorf(codes.PermissionDenied, "У вас нет доступа к этому файлу")
+	}
+
+	return nil, nil
+}
+
 // calculateMD5 вычисляет MD5-хеш файла
 func calculateMD5(filePath string) (string, error) {
 	file, err := os.Open(filePath)
@@ -0,0 +1,514 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"gRPCWebServer/backend/broker"
+	"gRPCWebServer/backend/entities"
+	pb "gRPCWebServer/backend/generated"
+	"gRPCWebServer/backend/manager"
+	"gRPCWebServer/backend/middleware"
+	"log"
+	"os"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	maxGroupTitleLength = 128
+	maxGroupMembers     = 200
+)
+
+// CreateGroup создает групповой чат; создатель становится его владельцем
+func (cs *chatService) CreateGroup(ctx context.Context, req *pb.CreateGroupRequest) (*pb.CreateGroupResponse, error) {
+	userId, ok := ctx.Value(middleware.TokenKey("user_id")).(uint64)
+	if !ok {
+		return nil, status.Errorf(codes.Unauthenticated, "User ID is missing in context")
+	}
+
+	title := strings.TrimSpace(req.Title)
+	if title == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "group title is required")
+	}
+	if utf8.RuneCountInString(title) > maxGroupTitleLength {
+		return nil, status.Errorf(codes.InvalidArgument, "group title must be at most %d characters long", maxGroupTitleLength)
+	}
+
+	if len(req.Usernames)+1 > maxGroupMembers {
+		return nil, status.Errorf(codes.InvalidArgument, "group cannot have more than %d members", maxGroupMembers)
+	}
+
+	memberIDs := make([]uint64, 0, len(req.Usernames))
+	for _, username := range req.Usernames {
+		member, err := cs.userRepo.GetByUsername(ctx, username)
+		if err != nil {
+			return nil, status.Errorf(codes.NotFound, "user '%s' not found", username)
+		}
+
+		if member.ID == userId {
+			continue
+		}
+
+		if err := checkNotBlocked(ctx, cs.blockRepo, userId, member.ID); err != nil {
+			return nil, err
+		}
+
+		memberIDs = append(memberIDs, member.ID)
+	}
+
+	groupId, err := cs.groupRepo.CreateGroup(ctx, userId, title, req.EncryptionAlgorithm, req.EncryptionMode, req.EncryptionPadding, memberIDs)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "%v", err)
+	}
+
+	log.Printf("User %d created group %d with %d members", userId, groupId, len(memberIDs)+1)
+
+	return &pb.CreateGroupResponse{
+		GroupId: groupId,
+	}, nil
+}
+
+// AddMember добавляет пользователя в группу; доступно владельцу и администраторам
+func (cs *chatService) AddMember(ctx context.Context, req *pb.AddMemberRequest) (*pb.AddMemberResponse, error) {
+	userId, ok := ctx.Value(middleware.TokenKey("user_id")).(uint64)
+	if !ok {
+		return nil, status.Errorf(codes.Unauthenticated, "User ID is missing in context")
+	}
+
+	caller, err := cs.groupMember(ctx, req.GroupId, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	if caller.Role == entities.RoleMember {
+		return nil, status.Errorf(codes.PermissionDenied, "only group owner and admins can add members")
+	}
+
+	target, err := cs.userRepo.GetByUsername(ctx, req.Username)
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "user '%s' not found", req.Username)
+	}
+
+	if err := checkNotBlocked(ctx, cs.blockRepo, userId, target.ID); err != nil {
+		return nil, err
+	}
+
+	count, err := cs.groupRepo.CountMembers(ctx, req.GroupId)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "%v", err)
+	}
+
+	if count >= maxGroupMembers {
+		return nil, status.Errorf(codes.FailedPrecondition, "group cannot have more than %d members", maxGroupMembers)
+	}
+
+	if err := cs.groupRepo.AddMember(ctx, req.GroupId, target.ID, entities.RoleMember); err != nil {
+		return nil, status.Errorf(codes.Internal, "%v", err)
+	}
+
+	return &pb.AddMemberResponse{
+		Success: true,
+	}, nil
+}
+
+// RemoveMember удаляет участника из группы. Владелец может удалить любого,
+// администратор — только обычных участников.
+func (cs *chatService) RemoveMember(ctx context.Context, req *pb.RemoveMemberRequest) (*pb.RemoveMemberResponse, error) {
+	userId, ok := ctx.Value(middleware.TokenKey("user_id")).(uint64)
+	if !ok {
+		return nil, status.Errorf(codes.Unauthenticated, "User ID is missing in context")
+	}
+
+	caller, err := cs.groupMember(ctx, req.GroupId, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	target, err := cs.targetMember(ctx, req.GroupId, req.Username)
+	if err != nil {
+		return nil, err
+	}
+
+	if target.UserID == userId {
+		return nil, status.Errorf(codes.InvalidArgument, "use LeaveGroup to leave the group")
+	}
+
+	if !canManage(caller.Role, target.Role) {
+		return nil, status.Errorf(codes.PermissionDenied, "not enough rights to remove '%s'", req.Username)
+	}
+
+	if _, _, err := cs.groupRepo.RemoveMember(ctx, req.GroupId, target.UserID); err != nil {
+		return nil, status.Errorf(codes.Internal, "%v", err)
+	}
+
+	return &pb.RemoveMemberResponse{
+		Success: true,
+	}, nil
+}
+
+// LeaveGroup выводит текущего пользователя из группы.
+// Если уходит владелец, владение переходит к администратору или самому давнему участнику.
+func (cs *chatService) LeaveGroup(ctx context.Context, req *pb.LeaveGroupRequest) (*pb.LeaveGroupResponse, error) {
+	userId, ok := ctx.Value(middleware.TokenKey("user_id")).(uint64)
+	if !ok {
+		return nil, status.Errorf(codes.Unauthenticated, "User ID is missing in context")
+	}
+
+	removed, filePaths, err := cs.groupRepo.RemoveMember(ctx, req.GroupId, userId)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "%v", err)
+	}
+
+	if !removed {
+		return nil, status.Errorf(codes.NotFound, "group not found")
+	}
+
+	// Последний участник ушел, группа удалена вместе с файлами
+	for _, path := range filePaths {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			log.Printf("Failed to remove file %s of deleted group %d: %v", path, req.GroupId, err)
+		}
+	}
+
+	return &pb.LeaveGroupResponse{
+		Success: true,
+	}, nil
+}
+
+// SetMemberRole меняет роль участника; доступно только владельцу.
+// Назначение OWNER передает владение, прежний владелец становится администратором.
+func (cs *chatService) SetMemberRole(ctx context.Context, req *pb.SetMemberRoleRequest) (*pb.SetMemberRoleResponse, error) {
+	userId, ok := ctx.Value(middleware.TokenKey("user_id")).(uint64)
+	if !ok {
+		return nil, status.Errorf(codes.Unauthenticated, "User ID is missing in context")
+	}
+
+	caller, err := cs.groupMember(ctx, req.GroupId, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	if caller.Role != entities.RoleOwner {
+		return nil, status.Errorf(codes.PermissionDenied, "only group owner can change roles")
+	}
+
+	target, err := cs.targetMember(ctx, req.GroupId, req.Username)
+	if err != nil {
+		return nil, err
+	}
+
+	if target.UserID == userId {
+		return nil, status.Errorf(codes.InvalidArgument, "cannot change your own role")
+	}
+
+	role := roleFromProto(req.Role)
+	if role == entities.RoleOwner {
+		err = cs.groupRepo.TransferOwnership(ctx, req.GroupId, userId, target.UserID)
+	} else {
+		err = cs.groupRepo.SetRole(ctx, req.GroupId, target.UserID, role)
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "%v", err)
+	}
+
+	return &pb.SetMemberRoleResponse{
+		Success: true,
+	}, nil
+}
+
+// GetGroupMembers возвращает участников группы
+func (cs *chatService) GetGroupMembers(ctx context.Context, req *pb.GetGroupMembersRequest) (*pb.GetGroupMembersResponse, error) {
+	userId, ok := ctx.Value(middleware.TokenKey("user_id")).(uint64)
+	if !ok {
+		return nil, status.Errorf(codes.Unauthenticated, "User ID is missing in context")
+	}
+
+	if _, err := cs.groupMember(ctx, req.GroupId, userId); err != nil {
+		return nil, err
+	}
+
+	members, err := cs.groupRepo.ListMembers(ctx, req.GroupId)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "%v", err)
+	}
+
+	response := &pb.GetGroupMembersResponse{
+		Members: make([]*pb.GroupMember, 0, len(members)),
+	}
+	for _, member := range members {
+		response.Members = append(response.Members, &pb.GroupMember{
+			Username: member.Username,
+			Role:     roleToProto(member.Role),
+			JoinedAt: member.JoinedAt.Unix(),
+		})
+	}
+
+	return response, nil
+}
+
+// connectToGroup подключает пользователя к групповому чату для последующего вызова Chat
+func (s *chatService) connectToGroup(ctx context.Context, senderId, groupId uint64) (*pb.ConnectResponse, error) {
+	if _, err := s.groupMember(ctx, groupId, senderId); err != nil {
+		return nil, err
+	}
+
+	deviceId := middleware.DeviceID(ctx)
+	s.streamManager.Connect(senderId, deviceId, manager.Subscription{GroupID: groupId})
+
+	log.Printf("User %d connected to group %d on device %q", senderId, groupId, deviceId)
+
+	return &pb.ConnectResponse{
+		Success: true,
+	}, nil
+}
+
+// groupChat обслуживает поток Chat для группы: отдает историю и накопленные в очереди сообщения,
+// а каждое новое сообщение рассылает всем участникам. Участникам, у которых сейчас
+// не открыт ни один поток, сообщение кладется в их очередь.
+func (s *chatService) groupChat(stream pb.ChatService_ChatServer, senderId uint64, deviceId string, groupId uint64) error {
+	ctx := stream.Context()
+
+	if _, err := s.groupMember(ctx, groupId, senderId); err != nil {
+		return err
+	}
+
+	senderUsername, err := s.userRepo.GetUserNameById(ctx, senderId)
+	if err != nil {
+		return status.Errorf(codes.NotFound, "failed to get sender username: %v", err)
+	}
+
+	session := s.streamManager.Open(senderId, deviceId, manager.Subscription{GroupID: groupId}, stream)
+	defer s.streamManager.Close(session)
+
+	log.Printf("User %d started chatting in group %d on device %q", senderId, groupId, deviceId)
+
+	if err := s.sendStreamHistory(ctx, session, senderId, groupId, groupId); err != nil {
+		return err
+	}
+
+	s.deliverQueuedMessages(ctx, session, senderId, senderUsername)
+
+	messageWG := &sync.WaitGroup{}
+	defer messageWG.Wait()
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Printf("Group chat session for userID=%d ended", senderId)
+			return nil
+
+		default:
+			req, err := stream.Recv()
+			if err != nil {
+				if errors.Is(ctx.Err(), context.Canceled) {
+					log.Printf("Stream closed by user %d", senderId)
+					return nil
+				}
+				return status.Errorf(codes.Internal, "failed to receive message: %v", err)
+			}
+
+			if req.GetSignal() != pb.ChatSignal_SIGNAL_NONE {
+				if err := s.handleSignal(ctx, senderId, senderUsername, 0, groupId, req.GetSignal()); err != nil {
+					return err
+				}
+				continue
+			}
+
+			confirmation, err := s.sendGroupMessage(ctx, groupId, senderId, senderUsername, req, messageWG)
+			if err != nil {
+				log.Printf("Rejected message from user %d: %v", senderId, err)
+				confirmation = rejectedResponse(req.GetClientId(), err)
+			}
+
+			if err := session.Send(confirmation); err != nil {
+				log.Printf("Failed to confirm message %d to sender %d: %v", confirmation.MessageId, senderId, err)
+			}
+		}
+	}
+}
+
+// sendGroupMessage сохраняет сообщение группы из потока Chat или SendMessage и рассылает его в фоне,
+// учитывая рассылку в wg. Возвращает подтверждение для отправителя: MESSAGE_SAVED или MESSAGE_SCHEDULED.
+func (s *chatService) sendGroupMessage(ctx context.Context, groupId, senderId uint64, senderUsername string, req *pb.ChatMessage, wg *sync.WaitGroup) (*pb.ChatResponse, error) {
+	envelope, err := s.messageEnvelope(ctx, groupId, req.GetEnvelope(), req.GetContent())
+	if err != nil {
+		return nil, err
+	}
+
+	// Участника могли удалить из группы, пока поток был открыт
+	members, err := s.groupRepo.ListMembers(ctx, groupId)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "%v", err)
+	}
+	if !hasMember(members, senderId) {
+		return nil, status.Errorf(codes.PermissionDenied, "you are not a member of this group")
+	}
+
+	message := &entities.Message{
+		ChatID:    groupId,
+		SenderId:  senderId,
+		Timestamp: time.Now(),
+		Envelope:  envelope,
+	}
+
+	if replyToId := req.GetReplyToMessageId(); replyToId != 0 {
+		threadRootId, err := s.replyThread(ctx, groupId, replyToId)
+		if err != nil {
+			return nil, err
+		}
+		message.ReplyToID = replyToId
+		message.ThreadRootID = threadRootId
+	}
+
+	deliverAt, err := prepareMessage(message, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if deliverAt != nil {
+		return s.scheduleMessage(ctx, message, *deliverAt, req.GetClientId())
+	}
+
+	if err := s.messageRepo.SaveMessage(message); err != nil {
+		log.Printf("Failed to save message: %v", err)
+		return nil, status.Errorf(codes.Internal, "failed to save message")
+	}
+	s.indexSentMessage(ctx, message, req.GetSearchTokens())
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		s.deliverGroupMessage(ctx, message, senderUsername, members)
+	}()
+
+	saved := savedResponse(message, req.GetClientId())
+	saved.GroupId = groupId
+	return saved, nil
+}
+
+// deliverGroupMessage рассылает сохраненное сообщение группы ее участникам
+func (s *chatService) deliverGroupMessage(ctx context.Context, message *entities.Message, senderUsername string, members []entities.GroupMember) {
+	resp := messageToResponse(message, senderUsername)
+	resp.GroupId = message.ChatID
+
+	queued := queuedMessage(message, senderUsername)
+	queued.GroupID = message.ChatID
+
+	s.fanOutGroupEvent(ctx, message.SenderId, members, resp, queued)
+}
+
+// fanOutGroupEvent отправляет сообщение или событие во все потоки участников
+// и кладет его в очереди тех, у кого не открыт ни один поток
+func (s *chatService) fanOutGroupEvent(ctx context.Context, senderId uint64, members []entities.GroupMember, resp *pb.ChatResponse, event broker.QueuedMessage) {
+	for _, member := range members {
+		if member.UserID == senderId {
+			continue
+		}
+
+		s.routeEvent(member.UserID, member.Username, senderId, 0, resp, &event)
+	}
+}
+
+// groupMember возвращает участника группы или ошибку, если группы нет или пользователь в ней не состоит
+func (s *chatService) groupMember(ctx context.Context, groupId, userId uint64) (*entities.GroupMember, error) {
+	member, err := s.groupRepo.GetMember(ctx, groupId, userId)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "%v", err)
+	}
+
+	if member == nil {
+		return nil, status.Errorf(codes.NotFound, "group not found")
+	}
+
+	return member, nil
+}
+
+func (s *chatService) targetMember(ctx context.Context, groupId uint64, username string) (*entities.GroupMember, error) {
+	target, err := s.userRepo.GetByUsername(ctx, username)
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "user '%s' not found", username)
+	}
+
+	member, err := s.groupRepo.GetMember(ctx, groupId, target.ID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "%v", err)
+	}
+
+	if member == nil {
+		return nil, status.Errorf(codes.NotFound, "user '%s' is not a member of this group", username)
+	}
+
+	return member, nil
+}
+
+func hasMember(members []entities.GroupMember, userId uint64) bool {
+	for _, member := range members {
+		if member.UserID == userId {
+			return true
+		}
+	}
+	return false
+}
+
+// canManage сообщает, может ли участник с ролью actor удалить участника с ролью target
+func canManage(actor, target string) bool {
+	switch actor {
+	case entities.RoleOwner:
+		return true
+	case entities.RoleAdmin:
+		return target == entities.RoleMember
+	default:
+		return false
+	}
+}
+
+func roleToProto(role string) pb.MemberRole {
+	switch role {
+	case entities.RoleOwner:
+		return pb.MemberRole_OWNER
+	case entities.RoleAdmin:
+		return pb.MemberRole_ADMIN
+	default:
+		return pb.MemberRole_MEMBER
+	}
+}
+
+func roleFromProto(role pb.MemberRole) string {
+	switch role {
+	case pb.MemberRole_OWNER:
+		return entities.RoleOwner
+	case pb.MemberRole_ADMIN:
+		return entities.RoleAdmin
+	default:
+		return entities.RoleMember
+	}
+}
+
+func groupToChatInfo(group *entities.Group) *pb.ChatInfo {
+	chatInfo := &pb.ChatInfo{
+		GroupId:     group.ID,
+		Title:       group.Title,
+		Role:        roleToProto(group.Role),
+		UnreadCount: uint32(group.UnreadCount),
+	}
+
+	if group.EncryptionAlgorithm != nil {
+		chatInfo.EncryptionAlgorithm = *group.EncryptionAlgorithm
+	}
+	if group.EncryptionMode != nil {
+		chatInfo.EncryptionMode = *group.EncryptionMode
+	}
+	if group.EncryptionPadding != nil {
+		chatInfo.EncryptionPadding = *group.EncryptionPadding
+	}
+	if group.MessageTTLSeconds != nil {
+		chatInfo.MessageTtlSeconds = uint32(*group.MessageTTLSeconds)
+	}
+
+	return chatInfo
+}
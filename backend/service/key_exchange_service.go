@@ -17,6 +17,9 @@ type KeyExchangeService struct {
 	keyExchangeRepo repository.KeyExchangeRepository
 	chatRepo        repository.ChatRepository
 	userRepo        repository.UserRepository
+	blockRepo       repository.BlockRepository
+	groupRepo       repository.GroupRepository
+	senderKeyRepo   repository.SenderKeyRepository
 }
 
 // NewKeyExchangeService создает новый экземпляр сервиса обмена ключами
@@ -24,11 +27,17 @@ func NewKeyExchangeService(
 	keyExchangeRepo repository.KeyExchangeRepository,
 	chatRepo repository.ChatRepository,
 	userRepo repository.UserRepository,
+	blockRepo repository.BlockRepository,
+	groupRepo repository.GroupRepository,
+	senderKeyRepo repository.SenderKeyRepository,
 ) *KeyExchangeService {
 	return &KeyExchangeService{
 		keyExchangeRepo: keyExchangeRepo,
 		chatRepo:        chatRepo,
 		userRepo:        userRepo,
+		blockRepo:       blockRepo,
+		groupRepo:       groupRepo,
+		senderKeyRepo:   senderKeyRepo,
 	}
 }
 
@@ -51,6 +60,10 @@ func (s *KeyExchangeService) InitKeyExchange(ctx context.Context, req *pb.InitKe
 		return nil, status.Errorf(codes.NotFound, "User '%s' not found", receiverUsername)
 	}
 
+	if err := checkNotBlocked(ctx, s.blockRepo, initiatorID, receiver.ID); err != nil {
+		return nil, err
+	}
+
 	// Проверяем, существует ли чат между пользователями
 	chatID, err := s.chatRepo.GetChatByUserIds(ctx, initiatorID, receiver.ID)
 	if err != nil {
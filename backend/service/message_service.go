@@ -0,0 +1,502 @@
+package service
+
+import (
+	"context"
+	"gRPCWebServer/backend/broker"
+	"gRPCWebServer/backend/entities"
+	pb "gRPCWebServer/backend/generated"
+	"gRPCWebServer/backend/manager"
+	"gRPCWebServer/backend/middleware"
+	"log"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// EditMessage заменяет тело сообщения; предыдущая версия сохраняется в истории правок.
+// Тип сообщения и вложения не меняются. Редактировать можно только свои сообщения.
+func (cs *chatService) EditMessage(ctx context.Context, req *pb.EditMessageRequest) (*pb.EditMessageResponse, error) {
+	userId, ok := ctx.Value(middleware.TokenKey("user_id")).(uint64)
+	if !ok {
+		return nil, status.Errorf(codes.Unauthenticated, "User ID is missing in context")
+	}
+
+	envelope, err := editedEnvelope(req.Envelope, req.Content)
+	if err != nil {
+		return nil, err
+	}
+
+	message, err := cs.ownMessage(ctx, userId, req.MessageId)
+	if err != nil {
+		return nil, err
+	}
+
+	if message.DeletedAt != nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "message is deleted")
+	}
+
+	// Писать в чат, а значит и править сообщения в нем, можно только пока пользователь в нем состоит
+	if message.ReceiverId != 0 {
+		if err := checkNotBlocked(ctx, cs.blockRepo, userId, message.ReceiverId); err != nil {
+			return nil, err
+		}
+	} else if _, err := cs.groupMember(ctx, message.ChatID, userId); err != nil {
+		return nil, err
+	}
+
+	editedAt, err := cs.messageRepo.Edit(ctx, message.ID, envelope)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "%v", err)
+	}
+
+	envelope.Kind = message.Kind
+	envelope.Attachments = message.Attachments
+	message.Envelope = envelope
+	message.EditedAt = &editedAt
+	cs.broadcastMessageEvent(ctx, message, broker.EventEdited, editedAt)
+
+	return &pb.EditMessageResponse{
+		Success:  true,
+		EditedAt: editedAt.Unix(),
+	}, nil
+}
+
+// DeleteMessage удаляет сообщение у всех участников чата, оставляя надгробие без текста.
+// Удалить можно только свое сообщение.
+func (cs *chatService) DeleteMessage(ctx context.Context, req *pb.DeleteMessageRequest) (*pb.DeleteMessageResponse, error) {
+	userId, ok := ctx.Value(middleware.TokenKey("user_id")).(uint64)
+	if !ok {
+		return nil, status.Errorf(codes.Unauthenticated, "User ID is missing in context")
+	}
+
+	message, err := cs.ownMessage(ctx, userId, req.MessageId)
+	if err != nil {
+		return nil, err
+	}
+
+	if message.DeletedAt != nil {
+		return &pb.DeleteMessageResponse{
+			Success: true,
+		}, nil
+	}
+
+	deletedAt, err := cs.messageRepo.Delete(ctx, message.ID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "%v", err)
+	}
+
+	message.Envelope = entities.Envelope{}
+	message.DeletedAt = &deletedAt
+	cs.broadcastMessageEvent(ctx, message, broker.EventDeleted, deletedAt)
+
+	return &pb.DeleteMessageResponse{
+		Success: true,
+	}, nil
+}
+
+// GetMessageEdits возвращает предыдущие версии сообщения
+func (cs *chatService) GetMessageEdits(ctx context.Context, req *pb.GetMessageEditsRequest) (*pb.GetMessageEditsResponse, error) {
+	userId, ok := ctx.Value(middleware.TokenKey("user_id")).(uint64)
+	if !ok {
+		return nil, status.Errorf(codes.Unauthenticated, "User ID is missing in context")
+	}
+
+	message, err := cs.messageRepo.GetByID(ctx, req.MessageId)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "%v", err)
+	}
+
+	if message == nil || !cs.canReadMessage(ctx, userId, message) {
+		return nil, status.Errorf(codes.NotFound, "message not found")
+	}
+
+	edits, err := cs.messageRepo.ListEdits(ctx, message.ID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "%v", err)
+	}
+
+	response := &pb.GetMessageEditsResponse{
+		Edits: make([]*pb.MessageEdit, 0, len(edits)),
+	}
+	for i := range edits {
+		response.Edits = append(response.Edits, &pb.MessageEdit{
+			EditedAt: edits[i].EditedAt.Unix(),
+			Envelope: envelopeToProto(&edits[i].Envelope),
+		})
+	}
+
+	return response, nil
+}
+
+const (
+	defaultHistoryPageSize = 50
+	maxHistoryPageSize     = 200
+)
+
+// GetHistory возвращает страницу истории личного чата или группы
+func (cs *chatService) GetHistory(ctx context.Context, req *pb.GetHistoryRequest) (*pb.GetHistoryResponse, error) {
+	userId, ok := ctx.Value(middleware.TokenKey("user_id")).(uint64)
+	if !ok {
+		return nil, status.Errorf(codes.Unauthenticated, "User ID is missing in context")
+	}
+
+	query := entities.HistoryQuery{
+		BeforeID: req.BeforeId,
+		AfterID:  req.AfterId,
+		Limit:    historyPageSize(req.PageSize),
+	}
+
+	if req.Since != 0 {
+		since := time.Unix(req.Since, 0)
+		query.Since = &since
+	}
+	if req.Until != 0 {
+		until := time.Unix(req.Until, 0)
+		query.Until = &until
+	}
+
+	chatId, err := cs.resolveChat(ctx, userId, req.Username, req.GroupId)
+	if err != nil {
+		return nil, err
+	}
+	query.ChatID = chatId
+
+	messages, hasMore, err := cs.historyPage(ctx, userId, req.GroupId, query)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.GetHistoryResponse{
+		Messages: messages,
+		HasMore:  hasMore,
+	}, nil
+}
+
+// historyPage загружает страницу истории чата в том виде, в котором ее видит пользователь userId
+func (cs *chatService) historyPage(ctx context.Context, userId, groupId uint64, query entities.HistoryQuery) ([]*pb.ChatResponse, bool, error) {
+	messages, hasMore, err := cs.messageRepo.GetHistory(ctx, query)
+	if err != nil {
+		return nil, false, status.Errorf(codes.Internal, "%v", err)
+	}
+
+	responses := make([]*pb.ChatResponse, 0, len(messages))
+	for i := range messages {
+		resp := messageToResponse(&messages[i], messages[i].SenderUsername)
+		resp.GroupId = groupId
+		if messages[i].SenderId == userId {
+			resp.Status = pb.MessageStatus(messages[i].Status)
+		}
+		responses = append(responses, resp)
+	}
+
+	if err := cs.attachReactions(ctx, userId, responses); err != nil {
+		return nil, false, err
+	}
+
+	return responses, hasMore, nil
+}
+
+// sendStreamHistory отправляет в открытый поток Chat последние сообщения чата,
+// если это включено через SetStreamHistorySize для клиентов без GetHistory.
+func (cs *chatService) sendStreamHistory(ctx context.Context, session *manager.Session, userId, chatId, groupId uint64) error {
+	if cs.streamHistorySize <= 0 {
+		return nil
+	}
+
+	messages, _, err := cs.historyPage(ctx, userId, groupId, entities.HistoryQuery{
+		ChatID: chatId,
+		Limit:  cs.streamHistorySize,
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, resp := range messages {
+		if err := session.Send(resp); err != nil {
+			log.Printf("Failed to send message from history to user %d: %v", userId, err)
+			return status.Error(codes.Internal, "failed to send history message")
+		}
+	}
+
+	return nil
+}
+
+// GetThread возвращает первое сообщение ветки и страницу ответов в ней.
+// Если указан ответ, а не первое сообщение, возвращается ветка, к которой он относится.
+func (cs *chatService) GetThread(ctx context.Context, req *pb.GetThreadRequest) (*pb.GetThreadResponse, error) {
+	userId, ok := ctx.Value(middleware.TokenKey("user_id")).(uint64)
+	if !ok {
+		return nil, status.Errorf(codes.Unauthenticated, "User ID is missing in context")
+	}
+
+	root, err := cs.messageRepo.GetByID(ctx, req.RootMessageId)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "%v", err)
+	}
+
+	if root != nil && root.ThreadRootID != 0 {
+		root, err = cs.messageRepo.GetByID(ctx, root.ThreadRootID)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "%v", err)
+		}
+	}
+
+	if root == nil || !cs.canReadMessage(ctx, userId, root) {
+		return nil, status.Errorf(codes.NotFound, "message not found")
+	}
+
+	var groupId uint64
+	if root.ReceiverId == 0 {
+		groupId = root.ChatID
+	}
+
+	rootSender, err := cs.userRepo.GetUserNameById(ctx, root.SenderId)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get sender username: %v", err)
+	}
+
+	replies, hasMore, err := cs.messageRepo.GetHistory(ctx, entities.HistoryQuery{
+		ChatID:       root.ChatID,
+		ThreadRootID: root.ID,
+		BeforeID:     req.BeforeId,
+		AfterID:      req.AfterId,
+		Limit:        historyPageSize(req.PageSize),
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "%v", err)
+	}
+
+	response := &pb.GetThreadResponse{
+		Root:     messageToResponse(root, rootSender),
+		Messages: make([]*pb.ChatResponse, 0, len(replies)),
+		HasMore:  hasMore,
+	}
+	response.Root.GroupId = groupId
+	for i := range replies {
+		resp := messageToResponse(&replies[i], replies[i].SenderUsername)
+		resp.GroupId = groupId
+		if replies[i].SenderId == userId {
+			resp.Status = pb.MessageStatus(replies[i].Status)
+		}
+		response.Messages = append(response.Messages, resp)
+	}
+
+	if err := cs.attachReactions(ctx, userId, append([]*pb.ChatResponse{response.Root}, response.Messages...)); err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// historyPageSize возвращает размер страницы истории с учетом значения по умолчанию и ограничения
+func historyPageSize(pageSize uint32) int {
+	if pageSize == 0 {
+		return defaultHistoryPageSize
+	}
+	if pageSize > maxHistoryPageSize {
+		return maxHistoryPageSize
+	}
+	return int(pageSize)
+}
+
+// replyThread проверяет, что сообщение, на которое отвечают, есть в этом чате, и возвращает первое сообщение его ветки
+func (cs *chatService) replyThread(ctx context.Context, chatId, replyToId uint64) (uint64, error) {
+	parent, err := cs.messageRepo.GetByID(ctx, replyToId)
+	if err != nil {
+		return 0, status.Errorf(codes.Internal, "%v", err)
+	}
+
+	if parent == nil || parent.ChatID != chatId {
+		return 0, status.Errorf(codes.InvalidArgument, "message %d not found in this chat", replyToId)
+	}
+
+	if parent.ThreadRootID != 0 {
+		return parent.ThreadRootID, nil
+	}
+	return parent.ID, nil
+}
+
+// ownMessage возвращает сообщение, отправленное пользователем
+func (cs *chatService) ownMessage(ctx context.Context, userId, messageId uint64) (*entities.Message, error) {
+	message, err := cs.messageRepo.GetByID(ctx, messageId)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "%v", err)
+	}
+
+	if message == nil || !cs.canReadMessage(ctx, userId, message) {
+		return nil, status.Errorf(codes.NotFound, "message not found")
+	}
+
+	if message.SenderId != userId {
+		return nil, status.Errorf(codes.PermissionDenied, "only the sender can change a message")
+	}
+
+	return message, nil
+}
+
+// canReadMessage сообщает, видит ли пользователь сообщение: в личном чате — отправитель и получатель,
+// в группе — отправитель и текущие участники
+func (cs *chatService) canReadMessage(ctx context.Context, userId uint64, message *entities.Message) bool {
+	if message.SenderId == userId || message.ReceiverId == userId {
+		return true
+	}
+
+	if message.ReceiverId != 0 {
+		return false
+	}
+
+	member, err := cs.groupRepo.GetMember(ctx, message.ChatID, userId)
+	if err != nil {
+		log.Printf("Failed to check group membership of user %d: %v", userId, err)
+		return false
+	}
+
+	return member != nil
+}
+
+// broadcastMessageEvent рассылает правку или удаление сообщения собеседнику или участникам группы:
+// тем, кто сейчас в сети, — в поток, остальным — в очередь
+func (cs *chatService) broadcastMessageEvent(ctx context.Context, message *entities.Message, event string, at time.Time) {
+	senderUsername, err := cs.userRepo.GetUserNameById(ctx, message.SenderId)
+	if err != nil {
+		log.Printf("Failed to get sender username for message %d: %v", message.ID, err)
+		return
+	}
+
+	resp := messageToResponse(message, senderUsername)
+	resp.Event = pb.ChatEventType_MESSAGE_EDITED
+	if event == broker.EventDeleted {
+		resp.Event = pb.ChatEventType_MESSAGE_DELETED
+	}
+
+	queued := broker.QueuedMessage{
+		SenderUsername: senderUsername,
+		MessageID:      message.ID,
+		Event:          event,
+		Timestamp:      at,
+	}
+	if event == broker.EventEdited {
+		queued.Envelope = marshalEnvelope(&message.Envelope)
+	}
+
+	cs.broadcastToChat(ctx, message, message.SenderId, resp, queued)
+}
+
+// broadcastToChat рассылает событие о сообщении всем участникам его чата, кроме инициатора события:
+// тем, кто сейчас в сети, — в поток, остальным — в очередь
+func (cs *chatService) broadcastToChat(ctx context.Context, message *entities.Message, actorId uint64, resp *pb.ChatResponse, queued broker.QueuedMessage) {
+	if message.ReceiverId == 0 {
+		members, err := cs.groupRepo.ListMembers(ctx, message.ChatID)
+		if err != nil {
+			log.Printf("Failed to get members of group %d: %v", message.ChatID, err)
+			return
+		}
+
+		resp.GroupId = message.ChatID
+		queued.GroupID = message.ChatID
+		cs.fanOutGroupEvent(ctx, actorId, members, resp, queued)
+		return
+	}
+
+	peerId := message.ReceiverId
+	if actorId == message.ReceiverId {
+		peerId = message.SenderId
+	}
+	cs.sendEvent(ctx, peerId, actorId, resp, queued)
+}
+
+// writableMessage возвращает неудаленное сообщение чата, в который пользователь сейчас может писать
+func (cs *chatService) writableMessage(ctx context.Context, userId, messageId uint64) (*entities.Message, error) {
+	message, err := cs.messageRepo.GetByID(ctx, messageId)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "%v", err)
+	}
+
+	if message == nil || !cs.canReadMessage(ctx, userId, message) {
+		return nil, status.Errorf(codes.NotFound, "message not found")
+	}
+
+	if message.DeletedAt != nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "message is deleted")
+	}
+
+	if message.ReceiverId == 0 {
+		if _, err := cs.groupMember(ctx, message.ChatID, userId); err != nil {
+			return nil, err
+		}
+	} else if err := checkNotBlocked(ctx, cs.blockRepo, message.SenderId, message.ReceiverId); err != nil {
+		return nil, err
+	}
+
+	return message, nil
+}
+
+// messageToResponse преобразует сохраненное сообщение в событие MESSAGE с его текущим состоянием
+func messageToResponse(message *entities.Message, senderUsername string) *pb.ChatResponse {
+	resp := &pb.ChatResponse{
+		Senderusername:   senderUsername,
+		Timestamp:        message.Timestamp.Unix(),
+		MessageId:        message.ID,
+		ReplyToMessageId: message.ReplyToID,
+		ThreadRootId:     message.ThreadRootID,
+		ReplyCount:       uint32(message.ReplyCount),
+	}
+
+	if message.ExpiresAt != nil {
+		resp.ExpiresAt = message.ExpiresAt.Unix()
+	}
+
+	if message.EditedAt != nil {
+		resp.EditedAt = message.EditedAt.Unix()
+	}
+
+	if message.DeletedAt != nil {
+		resp.Deleted = true
+	} else {
+		resp.Envelope = envelopeToProto(&message.Envelope)
+	}
+
+	return resp
+}
+
+// queuedMessage преобразует сохраненное сообщение в событие MESSAGE для очереди получателя
+func queuedMessage(message *entities.Message, senderUsername string) broker.QueuedMessage {
+	return broker.QueuedMessage{
+		SenderUsername: senderUsername,
+		MessageID:      message.ID,
+		Event:          broker.EventMessage,
+		Envelope:       marshalEnvelope(&message.Envelope),
+		Timestamp:      message.Timestamp,
+		ReplyToID:      message.ReplyToID,
+		ThreadRootID:   message.ThreadRootID,
+		ExpiresAt:      message.ExpiresAt,
+	}
+}
+
+// savedResponse подтверждает отправителю сохранение сообщения
+func savedResponse(message *entities.Message, clientId string) *pb.ChatResponse {
+	resp := &pb.ChatResponse{
+		Timestamp: message.Timestamp.Unix(),
+		MessageId: message.ID,
+		Event:     pb.ChatEventType_MESSAGE_SAVED,
+		ClientId:  clientId,
+		Envelope:  envelopeToProto(&message.Envelope),
+	}
+	if message.ExpiresAt != nil {
+		resp.ExpiresAt = message.ExpiresAt.Unix()
+	}
+
+	return resp
+}
+
+// rejectedResponse сообщает отправителю в потоке Chat, что его сообщение не принято.
+// Ошибка одного сообщения не закрывает поток.
+func rejectedResponse(clientId string, err error) *pb.ChatResponse {
+	return &pb.ChatResponse{
+		Timestamp: time.Now().Unix(),
+		Event:     pb.ChatEventType_MESSAGE_REJECTED,
+		ClientId:  clientId,
+		Error:     status.Convert(err).Message(),
+	}
+}
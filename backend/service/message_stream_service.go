@@ -0,0 +1,145 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	pb "gRPCWebServer/backend/generated"
+	"gRPCWebServer/backend/manager"
+	"gRPCWebServer/backend/middleware"
+	"log"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// SendMessage отправляет сообщение без двунаправленного потока, который недоступен в gRPC-Web.
+// Сообщение сохраняется и рассылается так же, как в потоке Chat: тем, кто не в сети, оно ставится в очередь.
+func (s *chatService) SendMessage(ctx context.Context, req *pb.SendMessageRequest) (*pb.SendMessageResponse, error) {
+	senderId, ok := ctx.Value(middleware.TokenKey("user_id")).(uint64)
+	if !ok {
+		return nil, status.Errorf(codes.Unauthenticated, "user ID is missing in context")
+	}
+
+	senderUsername, err := s.userRepo.GetUserNameById(ctx, senderId)
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "failed to get sender username: %v", err)
+	}
+
+	message := &pb.ChatMessage{
+		Content:          req.Content,
+		ReplyToMessageId: req.ReplyToMessageId,
+		TtlSeconds:       req.TtlSeconds,
+		DeliverAt:        req.DeliverAt,
+		SearchTokens:     req.SearchTokens,
+		Envelope:         req.Envelope,
+	}
+
+	// Контекст запроса отменяется после ответа, поэтому рассылка завершается до него
+	messageWG := &sync.WaitGroup{}
+	defer messageWG.Wait()
+
+	var confirmation *pb.ChatResponse
+	if req.GroupId != 0 {
+		if req.Signal != pb.ChatSignal_SIGNAL_NONE {
+			if err := s.handleSignal(ctx, senderId, senderUsername, 0, req.GroupId, req.Signal); err != nil {
+				return nil, err
+			}
+			return &pb.SendMessageResponse{SenderUsername: senderUsername, Timestamp: time.Now().Unix()}, nil
+		}
+
+		confirmation, err = s.sendGroupMessage(ctx, req.GroupId, senderId, senderUsername, message, messageWG)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		if req.Username == "" {
+			return nil, status.Errorf(codes.InvalidArgument, "username or group ID is required")
+		}
+
+		receiver, err := s.userRepo.GetByUsername(ctx, req.Username)
+		if err != nil {
+			return nil, status.Errorf(codes.NotFound, "user '%s' not found", req.Username)
+		}
+
+		chatID, err := s.chatRepo.GetChatByUserIds(ctx, senderId, receiver.ID)
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Errorf(codes.NotFound, "chat with user '%s' not found", req.Username)
+		}
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "error checking chat existence: %v", err)
+		}
+
+		if req.Signal != pb.ChatSignal_SIGNAL_NONE {
+			if err := s.handleSignal(ctx, senderId, senderUsername, receiver.ID, 0, req.Signal); err != nil {
+				return nil, err
+			}
+			return &pb.SendMessageResponse{SenderUsername: senderUsername, Timestamp: time.Now().Unix()}, nil
+		}
+
+		confirmation, err = s.sendDirectMessage(ctx, chatID, senderId, senderUsername, receiver.ID, receiver.Username, message, messageWG)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &pb.SendMessageResponse{
+		SenderUsername: senderUsername,
+		Timestamp:      confirmation.Timestamp,
+		Confirmation:   confirmation,
+	}, nil
+}
+
+// ReceiveMessages открывает поток событий всех чатов пользователя. Вместе с SendMessage он заменяет
+// поток Chat для клиентов gRPC-Web; при подключении отправляются сообщения из очереди пользователя.
+func (s *chatService) ReceiveMessages(req *pb.ReceiveMessagesRequest, stream pb.ChatService_ReceiveMessagesServer) error {
+	ctx := stream.Context()
+	userId, ok := ctx.Value(middleware.TokenKey("user_id")).(uint64)
+	if !ok {
+		return status.Errorf(codes.Unauthenticated, "user ID is missing in context")
+	}
+
+	username, err := s.userRepo.GetUserNameById(ctx, userId)
+	if err != nil {
+		return status.Errorf(codes.NotFound, "failed to get username: %v", err)
+	}
+
+	deviceId := middleware.DeviceID(ctx)
+	session := s.streamManager.Open(userId, deviceId, manager.Subscription{AllChats: true}, &receiveStream{stream: stream})
+	defer s.streamManager.Close(session)
+
+	log.Printf("User %d started receiving messages on device %q", userId, deviceId)
+
+	s.deliverQueuedMessages(ctx, session, userId, username)
+
+	select {
+	case <-ctx.Done():
+	case <-session.Done():
+		// Поток этого устройства открыт заново или клиент перестал принимать события
+	}
+	log.Printf("Receive stream for userID=%d ended", userId)
+	return nil
+}
+
+// receiveStream передает события, которые рассылаются в потоки Chat, в поток ReceiveMessages
+type receiveStream struct {
+	stream pb.ChatService_ReceiveMessagesServer
+}
+
+func (r *receiveStream) Send(resp *pb.ChatResponse) error {
+	return r.stream.Send(&pb.ReceiveMessagesResponse{
+		SenderUsername:   resp.Senderusername,
+		Content:          resp.Content,
+		Timestamp:        resp.Timestamp,
+		ReplyToMessageId: resp.ReplyToMessageId,
+		ThreadRootId:     resp.ThreadRootId,
+		Envelope:         resp.Envelope,
+		Update:           resp,
+	})
+}
+
+func (r *receiveStream) Context() context.Context {
+	return r.stream.Context()
+}
@@ -0,0 +1,224 @@
+package service
+
+import (
+	"context"
+	"gRPCWebServer/backend/broker"
+	"gRPCWebServer/backend/entities"
+	pb "gRPCWebServer/backend/generated"
+	"gRPCWebServer/backend/middleware"
+	"log"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	maxPinnedMessages  = 20
+	maxStarredMessages = 500
+)
+
+// PinMessage закрепляет сообщение в чате; закрепленные сообщения видят все участники
+func (cs *chatService) PinMessage(ctx context.Context, req *pb.PinMessageRequest) (*pb.PinMessageResponse, error) {
+	userId, ok := ctx.Value(middleware.TokenKey("user_id")).(uint64)
+	if !ok {
+		return nil, status.Errorf(codes.Unauthenticated, "User ID is missing in context")
+	}
+
+	message, err := cs.writableMessage(ctx, userId, req.MessageId)
+	if err != nil {
+		return nil, err
+	}
+
+	count, err := cs.pinRepo.CountPinned(ctx, message.ChatID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "%v", err)
+	}
+
+	if count >= maxPinnedMessages {
+		return nil, status.Errorf(codes.FailedPrecondition, "chat cannot have more than %d pinned messages", maxPinnedMessages)
+	}
+
+	pinned, err := cs.pinRepo.Pin(ctx, message.ChatID, message.ID, userId)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "%v", err)
+	}
+
+	if pinned {
+		cs.broadcastPin(ctx, message, userId, broker.EventPinned)
+	}
+
+	return &pb.PinMessageResponse{
+		Success: true,
+	}, nil
+}
+
+// UnpinMessage открепляет сообщение
+func (cs *chatService) UnpinMessage(ctx context.Context, req *pb.UnpinMessageRequest) (*pb.UnpinMessageResponse, error) {
+	userId, ok := ctx.Value(middleware.TokenKey("user_id")).(uint64)
+	if !ok {
+		return nil, status.Errorf(codes.Unauthenticated, "User ID is missing in context")
+	}
+
+	message, err := cs.writableMessage(ctx, userId, req.MessageId)
+	if err != nil {
+		return nil, err
+	}
+
+	unpinned, err := cs.pinRepo.Unpin(ctx, message.ChatID, message.ID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "%v", err)
+	}
+
+	if unpinned {
+		cs.broadcastPin(ctx, message, userId, broker.EventUnpinned)
+	}
+
+	return &pb.UnpinMessageResponse{
+		Success: true,
+	}, nil
+}
+
+// ListPinnedMessages возвращает закрепленные сообщения личного чата или группы
+func (cs *chatService) ListPinnedMessages(ctx context.Context, req *pb.ListPinnedMessagesRequest) (*pb.ListPinnedMessagesResponse, error) {
+	userId, ok := ctx.Value(middleware.TokenKey("user_id")).(uint64)
+	if !ok {
+		return nil, status.Errorf(codes.Unauthenticated, "User ID is missing in context")
+	}
+
+	chatId, err := cs.resolveChat(ctx, userId, req.Username, req.GroupId)
+	if err != nil {
+		return nil, err
+	}
+
+	pinned, err := cs.pinRepo.ListPinned(ctx, chatId)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "%v", err)
+	}
+
+	response := &pb.ListPinnedMessagesResponse{
+		Messages: make([]*pb.PinnedMessage, 0, len(pinned)),
+	}
+	for i := range pinned {
+		resp := messageToResponse(&pinned[i].Message, pinned[i].SenderUsername)
+		resp.GroupId = req.GroupId
+		response.Messages = append(response.Messages, &pb.PinnedMessage{
+			Message:  resp,
+			PinnedBy: pinned[i].PinnedBy,
+			PinnedAt: pinned[i].PinnedAt.Unix(),
+		})
+	}
+
+	return response, nil
+}
+
+// StarMessage добавляет сообщение в избранное пользователя; остальные участники чата об этом не узнают
+func (cs *chatService) StarMessage(ctx context.Context, req *pb.StarMessageRequest) (*pb.StarMessageResponse, error) {
+	userId, ok := ctx.Value(middleware.TokenKey("user_id")).(uint64)
+	if !ok {
+		return nil, status.Errorf(codes.Unauthenticated, "User ID is missing in context")
+	}
+
+	message, err := cs.messageRepo.GetByID(ctx, req.MessageId)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "%v", err)
+	}
+
+	if message == nil || !cs.canReadMessage(ctx, userId, message) {
+		return nil, status.Errorf(codes.NotFound, "message not found")
+	}
+
+	if message.DeletedAt != nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "message is deleted")
+	}
+
+	count, err := cs.pinRepo.CountStarred(ctx, userId)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "%v", err)
+	}
+
+	if count >= maxStarredMessages {
+		return nil, status.Errorf(codes.FailedPrecondition, "cannot star more than %d messages", maxStarredMessages)
+	}
+
+	if err := cs.pinRepo.Star(ctx, userId, message.ID); err != nil {
+		return nil, status.Errorf(codes.Internal, "%v", err)
+	}
+
+	return &pb.StarMessageResponse{
+		Success: true,
+	}, nil
+}
+
+// UnstarMessage убирает сообщение из избранного
+func (cs *chatService) UnstarMessage(ctx context.Context, req *pb.UnstarMessageRequest) (*pb.UnstarMessageResponse, error) {
+	userId, ok := ctx.Value(middleware.TokenKey("user_id")).(uint64)
+	if !ok {
+		return nil, status.Errorf(codes.Unauthenticated, "User ID is missing in context")
+	}
+
+	if err := cs.pinRepo.Unstar(ctx, userId, req.MessageId); err != nil {
+		return nil, status.Errorf(codes.Internal, "%v", err)
+	}
+
+	return &pb.UnstarMessageResponse{
+		Success: true,
+	}, nil
+}
+
+// ListStarredMessages возвращает избранные сообщения пользователя из всех его чатов
+func (cs *chatService) ListStarredMessages(ctx context.Context, req *pb.ListStarredMessagesRequest) (*pb.ListStarredMessagesResponse, error) {
+	userId, ok := ctx.Value(middleware.TokenKey("user_id")).(uint64)
+	if !ok {
+		return nil, status.Errorf(codes.Unauthenticated, "User ID is missing in context")
+	}
+
+	starred, err := cs.pinRepo.ListStarred(ctx, userId)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "%v", err)
+	}
+
+	response := &pb.ListStarredMessagesResponse{
+		Messages: make([]*pb.StarredMessage, 0, len(starred)),
+	}
+	for i := range starred {
+		resp := messageToResponse(&starred[i].Message, starred[i].SenderUsername)
+		if starred[i].ReceiverId == 0 {
+			resp.GroupId = starred[i].ChatID
+		}
+		response.Messages = append(response.Messages, &pb.StarredMessage{
+			Message:      resp,
+			ChatUsername: starred[i].PeerUsername,
+			StarredAt:    starred[i].StarredAt.Unix(),
+		})
+	}
+
+	return response, nil
+}
+
+// broadcastPin рассылает событие закрепления или открепления всем участникам чата, кроме инициатора
+func (cs *chatService) broadcastPin(ctx context.Context, message *entities.Message, userId uint64, event string) {
+	username, err := cs.userRepo.GetUserNameById(ctx, userId)
+	if err != nil {
+		log.Printf("Failed to get username of user %d: %v", userId, err)
+		return
+	}
+
+	now := time.Now()
+	resp := &pb.ChatResponse{
+		Senderusername: username,
+		Timestamp:      now.Unix(),
+		MessageId:      message.ID,
+		Event:          pb.ChatEventType_MESSAGE_PINNED,
+	}
+	if event == broker.EventUnpinned {
+		resp.Event = pb.ChatEventType_MESSAGE_UNPINNED
+	}
+
+	cs.broadcastToChat(ctx, message, userId, resp, broker.QueuedMessage{
+		SenderUsername: username,
+		MessageID:      message.ID,
+		Event:          event,
+		Timestamp:      now,
+	})
+}
@@ -0,0 +1,144 @@
+package service
+
+import (
+	"context"
+	pb "gRPCWebServer/backend/generated"
+	"gRPCWebServer/backend/manager"
+	"gRPCWebServer/backend/middleware"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const maxPresenceSubscriptions = 200
+
+// SubscribePresence отправляет текущее состояние указанных пользователей, а затем его изменения,
+// пока клиент не закроет поток. Заблокированные в любую сторону пользователи всегда не в сети.
+func (cs *chatService) SubscribePresence(req *pb.SubscribePresenceRequest, stream pb.ChatService_SubscribePresenceServer) error {
+	ctx := stream.Context()
+	userId, ok := ctx.Value(middleware.TokenKey("user_id")).(uint64)
+	if !ok {
+		return status.Errorf(codes.Unauthenticated, "User ID is missing in context")
+	}
+
+	if len(req.Usernames) > maxPresenceSubscriptions {
+		return status.Errorf(codes.InvalidArgument, "cannot subscribe to more than %d users", maxPresenceSubscriptions)
+	}
+
+	usernames := make(map[uint64]string, len(req.Usernames))
+	var userIds []uint64
+	for _, username := range req.Usernames {
+		user, err := cs.userRepo.GetByUsername(ctx, username)
+		if err != nil {
+			return status.Errorf(codes.NotFound, "user '%s' not found", username)
+		}
+
+		if _, exists := usernames[user.ID]; exists {
+			continue
+		}
+
+		blocked, err := cs.blockRepo.IsBlocked(ctx, userId, user.ID)
+		if err != nil {
+			return status.Errorf(codes.Internal, "%v", err)
+		}
+
+		usernames[user.ID] = user.Username
+		if !blocked {
+			userIds = append(userIds, user.ID)
+		}
+	}
+
+	// Подписка оформляется до снимка, чтобы не пропустить изменения между ними
+	updates, unsubscribe := cs.presence.Subscribe(userIds)
+	defer unsubscribe()
+
+	now := time.Now().Unix()
+	for id, username := range usernames {
+		if err := stream.Send(&pb.PresenceUpdate{
+			Username:  username,
+			Status:    presenceToProto(cs.presence.GetStatus(id)),
+			ChangedAt: now,
+		}); err != nil {
+			return err
+		}
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+
+		case update := <-updates:
+			if err := stream.Send(&pb.PresenceUpdate{
+				Username:  usernames[update.UserID],
+				Status:    presenceToProto(update.Status),
+				ChangedAt: update.At.Unix(),
+			}); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// handleSignal обрабатывает служебный сигнал из потока Chat. Сигналы получают только те,
+// кто сейчас открыл этот чат: в базу и в очередь они не попадают.
+func (s *chatService) handleSignal(ctx context.Context, senderId uint64, senderUsername string, receiverId, groupId uint64, signal pb.ChatSignal) error {
+	resp := &pb.ChatResponse{
+		Senderusername: senderUsername,
+		Timestamp:      time.Now().Unix(),
+		GroupId:        groupId,
+	}
+
+	switch signal {
+	case pb.ChatSignal_SIGNAL_AWAY:
+		s.presence.SetAway(senderId)
+		return nil
+	case pb.ChatSignal_SIGNAL_ACTIVE:
+		s.presence.SetOnline(senderId)
+		return nil
+	case pb.ChatSignal_SIGNAL_TYPING_START:
+		resp.Event = pb.ChatEventType_TYPING_STARTED
+	case pb.ChatSignal_SIGNAL_TYPING_STOP:
+		resp.Event = pb.ChatEventType_TYPING_STOPPED
+	default:
+		return status.Errorf(codes.InvalidArgument, "unknown signal %d", signal)
+	}
+
+	if groupId != 0 {
+		members, err := s.groupRepo.ListMembers(ctx, groupId)
+		if err != nil {
+			return status.Errorf(codes.Internal, "%v", err)
+		}
+		if !hasMember(members, senderId) {
+			return status.Errorf(codes.PermissionDenied, "you are not a member of this group")
+		}
+
+		for _, member := range members {
+			if member.UserID == senderId {
+				continue
+			}
+			s.routeEvent(member.UserID, member.Username, senderId, 0, resp, nil)
+		}
+		return nil
+	}
+
+	if err := checkNotBlocked(ctx, s.blockRepo, senderId, receiverId); err != nil {
+		return err
+	}
+
+	// Собеседник увидит сигнал только на устройствах, где сейчас открыт именно этот чат или поток ReceiveMessages
+	s.routeEvent(receiverId, "", senderId, senderId, resp, nil)
+	return nil
+}
+
+func presenceToProto(presence manager.PresenceStatus) pb.PresenceStatus {
+	switch presence {
+	case manager.PresenceOnline:
+		return pb.PresenceStatus_PRESENCE_ONLINE
+	case manager.PresenceAway:
+		return pb.PresenceStatus_PRESENCE_AWAY
+	default:
+		return pb.PresenceStatus_PRESENCE_OFFLINE
+	}
+}
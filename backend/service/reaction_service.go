@@ -0,0 +1,132 @@
+package service
+
+import (
+	"context"
+	"gRPCWebServer/backend/broker"
+	"gRPCWebServer/backend/entities"
+	pb "gRPCWebServer/backend/generated"
+	"gRPCWebServer/backend/middleware"
+	"log"
+	"time"
+	"unicode/utf8"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const maxReactionLength = 32
+
+// AddReaction ставит реакцию на сообщение и рассылает событие остальным участникам чата
+func (cs *chatService) AddReaction(ctx context.Context, req *pb.AddReactionRequest) (*pb.AddReactionResponse, error) {
+	userId, ok := ctx.Value(middleware.TokenKey("user_id")).(uint64)
+	if !ok {
+		return nil, status.Errorf(codes.Unauthenticated, "User ID is missing in context")
+	}
+
+	message, err := cs.reactionTarget(ctx, userId, req.MessageId, req.Emoji)
+	if err != nil {
+		return nil, err
+	}
+
+	added, err := cs.reactionRepo.Add(ctx, message.ID, userId, req.Emoji)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "%v", err)
+	}
+
+	if added {
+		cs.broadcastReaction(ctx, message, userId, req.Emoji, broker.EventReactionAdded)
+	}
+
+	return &pb.AddReactionResponse{
+		Success: true,
+	}, nil
+}
+
+// RemoveReaction снимает реакцию пользователя с сообщения
+func (cs *chatService) RemoveReaction(ctx context.Context, req *pb.RemoveReactionRequest) (*pb.RemoveReactionResponse, error) {
+	userId, ok := ctx.Value(middleware.TokenKey("user_id")).(uint64)
+	if !ok {
+		return nil, status.Errorf(codes.Unauthenticated, "User ID is missing in context")
+	}
+
+	message, err := cs.reactionTarget(ctx, userId, req.MessageId, req.Emoji)
+	if err != nil {
+		return nil, err
+	}
+
+	removed, err := cs.reactionRepo.Remove(ctx, message.ID, userId, req.Emoji)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "%v", err)
+	}
+
+	if removed {
+		cs.broadcastReaction(ctx, message, userId, req.Emoji, broker.EventReactionRemoved)
+	}
+
+	return &pb.RemoveReactionResponse{
+		Success: true,
+	}, nil
+}
+
+// reactionTarget проверяет эмодзи и возвращает сообщение, на которое пользователь может реагировать
+func (cs *chatService) reactionTarget(ctx context.Context, userId, messageId uint64, emoji string) (*entities.Message, error) {
+	if emoji == "" || len(emoji) > maxReactionLength || !utf8.ValidString(emoji) {
+		return nil, status.Errorf(codes.InvalidArgument, "emoji must be 1 to %d bytes of valid UTF-8", maxReactionLength)
+	}
+
+	return cs.writableMessage(ctx, userId, messageId)
+}
+
+// broadcastReaction рассылает событие реакции всем участникам чата, кроме поставившего ее
+func (cs *chatService) broadcastReaction(ctx context.Context, message *entities.Message, userId uint64, emoji, event string) {
+	username, err := cs.userRepo.GetUserNameById(ctx, userId)
+	if err != nil {
+		log.Printf("Failed to get username of user %d: %v", userId, err)
+		return
+	}
+
+	now := time.Now()
+	resp := &pb.ChatResponse{
+		Senderusername: username,
+		Timestamp:      now.Unix(),
+		MessageId:      message.ID,
+		Event:          pb.ChatEventType_REACTION_ADDED,
+		Reaction:       emoji,
+	}
+	if event == broker.EventReactionRemoved {
+		resp.Event = pb.ChatEventType_REACTION_REMOVED
+	}
+
+	cs.broadcastToChat(ctx, message, userId, resp, broker.QueuedMessage{
+		SenderUsername: username,
+		MessageID:      message.ID,
+		Event:          event,
+		Content:        emoji,
+		Timestamp:      now,
+	})
+}
+
+// attachReactions добавляет к сообщениям страницы истории сводку реакций на них
+func (cs *chatService) attachReactions(ctx context.Context, userId uint64, responses []*pb.ChatResponse) error {
+	messageIds := make([]uint64, 0, len(responses))
+	for _, resp := range responses {
+		messageIds = append(messageIds, resp.MessageId)
+	}
+
+	summary, err := cs.reactionRepo.Summarize(ctx, messageIds, userId)
+	if err != nil {
+		return status.Errorf(codes.Internal, "%v", err)
+	}
+
+	for _, resp := range responses {
+		for _, reaction := range summary[resp.MessageId] {
+			resp.Reactions = append(resp.Reactions, &pb.Reaction{
+				Emoji:   reaction.Emoji,
+				Count:   uint32(reaction.Count),
+				Reacted: reaction.Reacted,
+			})
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,135 @@
+package service
+
+import (
+	"context"
+	"gRPCWebServer/backend/broker"
+	pb "gRPCWebServer/backend/generated"
+	"gRPCWebServer/backend/middleware"
+	"log"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// MarkRead отмечает прочитанными сообщения чата до указанного ID и уведомляет их авторов
+func (cs *chatService) MarkRead(ctx context.Context, req *pb.MarkReadRequest) (*pb.MarkReadResponse, error) {
+	userId, ok := ctx.Value(middleware.TokenKey("user_id")).(uint64)
+	if !ok {
+		return nil, status.Errorf(codes.Unauthenticated, "User ID is missing in context")
+	}
+
+	if req.UpToMessageId == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "up_to_message_id is required")
+	}
+
+	chatId, err := cs.resolveChat(ctx, userId, req.Username, req.GroupId)
+	if err != nil {
+		return nil, err
+	}
+
+	username, err := cs.userRepo.GetUserNameById(ctx, userId)
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "user not found")
+	}
+
+	receipts, err := cs.receiptRepo.MarkRead(ctx, chatId, userId, req.UpToMessageId)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "%v", err)
+	}
+
+	now := time.Now()
+	for _, receipt := range receipts {
+		cs.sendEvent(ctx, receipt.SenderID, userId, &pb.ChatResponse{
+			Timestamp:   now.Unix(),
+			GroupId:     req.GroupId,
+			MessageId:   receipt.MessageID,
+			Event:       pb.ChatEventType_MESSAGE_READ,
+			ReceiptFrom: username,
+		}, broker.QueuedMessage{
+			SenderUsername: username,
+			GroupID:        req.GroupId,
+			MessageID:      receipt.MessageID,
+			Event:          broker.EventRead,
+			Timestamp:      now,
+		})
+	}
+
+	unread, err := cs.receiptRepo.CountUnread(ctx, chatId, userId)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "%v", err)
+	}
+
+	return &pb.MarkReadResponse{
+		Success:     true,
+		UnreadCount: uint32(unread),
+	}, nil
+}
+
+// markDelivered отмечает сообщение доставленным получателю и уведомляет автора.
+// Повторная доставка того же сообщения уведомления не создает.
+func (s *chatService) markDelivered(ctx context.Context, messageId, groupId, recipientId uint64, recipientUsername string, senderId uint64) {
+	marked, err := s.receiptRepo.MarkDelivered(ctx, messageId, recipientId)
+	if err != nil {
+		log.Printf("Failed to mark message %d delivered to user %d: %v", messageId, recipientId, err)
+		return
+	}
+
+	if !marked {
+		return
+	}
+
+	now := time.Now()
+	s.sendEvent(ctx, senderId, recipientId, &pb.ChatResponse{
+		Timestamp:   now.Unix(),
+		GroupId:     groupId,
+		MessageId:   messageId,
+		Event:       pb.ChatEventType_MESSAGE_DELIVERED,
+		ReceiptFrom: recipientUsername,
+	}, broker.QueuedMessage{
+		SenderUsername: recipientUsername,
+		GroupID:        groupId,
+		MessageID:      messageId,
+		Event:          broker.EventDelivered,
+		Timestamp:      now,
+	})
+}
+
+// sendEvent отправляет событие пользователю в поток, а если он не в сети — в его очередь.
+// peerId — собеседник пользователя в личном чате события; для событий группы не используется.
+func (s *chatService) sendEvent(ctx context.Context, userId, peerId uint64, resp *pb.ChatResponse, queued broker.QueuedMessage) {
+	// Имя нужно заранее: очередь выбирает экземпляр, узнавший, что потоков нет
+	username, err := s.userRepo.GetUserNameById(ctx, userId)
+	if err != nil {
+		log.Printf("Failed to get username of user %d: %v", userId, err)
+		return
+	}
+
+	s.routeEvent(userId, username, 0, peerId, resp, &queued)
+}
+
+// resolveChat возвращает ID личного чата с собеседником или группы, в которой состоит пользователь
+func (s *chatService) resolveChat(ctx context.Context, userId uint64, username string, groupId uint64) (uint64, error) {
+	switch {
+	case groupId != 0:
+		if _, err := s.groupMember(ctx, groupId, userId); err != nil {
+			return 0, err
+		}
+		return groupId, nil
+
+	case username != "":
+		peer, err := s.userRepo.GetByUsername(ctx, username)
+		if err != nil {
+			return 0, status.Errorf(codes.NotFound, "user '%s' not found", username)
+		}
+
+		chatId, err := s.chatRepo.GetChatByUserIds(ctx, userId, peer.ID)
+		if err != nil {
+			return 0, status.Errorf(codes.NotFound, "chat with '%s' not found", username)
+		}
+		return chatId, nil
+
+	default:
+		return 0, status.Errorf(codes.InvalidArgument, "username or group_id is required")
+	}
+}
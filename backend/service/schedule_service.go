@@ -0,0 +1,235 @@
+package service
+
+import (
+	"context"
+	"gRPCWebServer/backend/broker"
+	"gRPCWebServer/backend/entities"
+	pb "gRPCWebServer/backend/generated"
+	"gRPCWebServer/backend/middleware"
+	"log"
+	"os"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	minMessageTTL    = 5 * time.Second
+	maxMessageTTL    = 7 * 24 * time.Hour
+	maxScheduleDelay = 365 * 24 * time.Hour
+
+	// Сколько сообщений планировщик обрабатывает за один запрос к базе
+	schedulerBatchSize = 100
+)
+
+// SetMessageTTL задает срок жизни сообщений личного чата или группы.
+// В группе его могут менять только администраторы и владелец.
+func (cs *chatService) SetMessageTTL(ctx context.Context, req *pb.SetMessageTTLRequest) (*pb.SetMessageTTLResponse, error) {
+	userId, ok := ctx.Value(middleware.TokenKey("user_id")).(uint64)
+	if !ok {
+		return nil, status.Errorf(codes.Unauthenticated, "User ID is missing in context")
+	}
+
+	if err := validateMessageTTL(req.TtlSeconds); err != nil {
+		return nil, err
+	}
+
+	chatId, err := cs.resolveChat(ctx, userId, req.Username, req.GroupId)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.GroupId != 0 {
+		member, err := cs.groupMember(ctx, req.GroupId, userId)
+		if err != nil {
+			return nil, err
+		}
+		if member.Role == entities.RoleMember {
+			return nil, status.Errorf(codes.PermissionDenied, "only admins can change message ttl")
+		}
+	}
+
+	if err := cs.chatRepo.SetMessageTTL(ctx, chatId, int(req.TtlSeconds)); err != nil {
+		return nil, status.Errorf(codes.Internal, "%v", err)
+	}
+
+	return &pb.SetMessageTTLResponse{
+		Success: true,
+	}, nil
+}
+
+// RunMessageScheduler раз в interval отправляет отложенные сообщения, время которых наступило,
+// и удаляет истекшие исчезающие сообщения. Задания хранятся в базе, поэтому переживают перезапуск,
+// а при нескольких экземплярах сервера каждое задание выполняет только один из них.
+func (cs *chatService) RunMessageScheduler(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		cs.releaseScheduledMessages(ctx)
+		cs.deleteExpiredMessages(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// releaseScheduledMessages отправляет отложенные сообщения так же, как если бы они пришли из потока Chat,
+// и сообщает отправителю ID отправленного сообщения
+func (cs *chatService) releaseScheduledMessages(ctx context.Context) {
+	for {
+		messages, err := cs.messageRepo.ReleaseDue(ctx, schedulerBatchSize)
+		if err != nil {
+			log.Printf("Failed to release scheduled messages: %v", err)
+			return
+		}
+
+		for i := range messages {
+			cs.deliverReleasedMessage(ctx, &messages[i])
+		}
+
+		if len(messages) < schedulerBatchSize {
+			return
+		}
+	}
+}
+
+func (cs *chatService) deliverReleasedMessage(ctx context.Context, message *entities.Message) {
+	senderUsername, err := cs.userRepo.GetUserNameById(ctx, message.SenderId)
+	if err != nil {
+		log.Printf("Failed to get sender username for scheduled message %d: %v", message.ScheduledID, err)
+		return
+	}
+
+	var groupId uint64
+	if message.ReceiverId == 0 {
+		groupId = message.ChatID
+	}
+
+	saved := savedResponse(message, "")
+	saved.GroupId = groupId
+	saved.ScheduledId = message.ScheduledID
+	cs.sendEvent(ctx, message.SenderId, message.ReceiverId, saved, broker.QueuedMessage{
+		SenderUsername: senderUsername,
+		GroupID:        groupId,
+		MessageID:      message.ID,
+		Event:          broker.EventReleased,
+		Envelope:       marshalEnvelope(&message.Envelope),
+		Timestamp:      message.Timestamp,
+		ScheduledID:    message.ScheduledID,
+		ExpiresAt:      message.ExpiresAt,
+	})
+
+	if groupId != 0 {
+		members, err := cs.groupRepo.ListMembers(ctx, groupId)
+		if err != nil {
+			log.Printf("Failed to get members of group %d: %v", groupId, err)
+			return
+		}
+
+		cs.deliverGroupMessage(ctx, message, senderUsername, members)
+		return
+	}
+
+	receiverUsername, err := cs.userRepo.GetUserNameById(ctx, message.ReceiverId)
+	if err != nil {
+		log.Printf("Failed to get receiver username for message %d: %v", message.ID, err)
+		return
+	}
+
+	cs.deliverDirectMessage(ctx, message, senderUsername, receiverUsername)
+}
+
+// deleteExpiredMessages удаляет истекшие исчезающие сообщения и их файлы на диске
+func (cs *chatService) deleteExpiredMessages(ctx context.Context) {
+	for {
+		count, filePaths, err := cs.messageRepo.DeleteExpired(ctx, schedulerBatchSize)
+		if err != nil {
+			log.Printf("Failed to delete expired messages: %v", err)
+			return
+		}
+
+		for _, path := range filePaths {
+			if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+				log.Printf("Failed to remove file %s of expired message: %v", path, err)
+			}
+		}
+
+		if count > 0 {
+			log.Printf("Deleted %d expired messages", count)
+		}
+
+		if count < schedulerBatchSize {
+			return
+		}
+	}
+}
+
+// scheduleMessage сохраняет сообщение для отправки в deliverAt и возвращает подтверждение для отправителя.
+// Токены поиска к отложенному сообщению не сохраняются: клиент индексирует его через IndexMessages после отправки.
+func (s *chatService) scheduleMessage(ctx context.Context, message *entities.Message, deliverAt time.Time, clientId string) (*pb.ChatResponse, error) {
+	if err := s.messageRepo.Schedule(ctx, message, deliverAt); err != nil {
+		log.Printf("Failed to schedule message: %v", err)
+		return nil, status.Errorf(codes.Internal, "failed to schedule message")
+	}
+
+	resp := &pb.ChatResponse{
+		Timestamp:   message.Timestamp.Unix(),
+		Event:       pb.ChatEventType_MESSAGE_SCHEDULED,
+		ClientId:    clientId,
+		DeliverAt:   deliverAt.Unix(),
+		ScheduledId: message.ScheduledID,
+		Envelope:    envelopeToProto(&message.Envelope),
+	}
+	if message.ReceiverId == 0 {
+		resp.GroupId = message.ChatID
+	}
+
+	return resp, nil
+}
+
+// prepareMessage заполняет срок жизни сообщения из запроса и проверяет токены поиска.
+// Возвращает время отложенной отправки или nil, если сообщение нужно отправить сразу.
+func prepareMessage(message *entities.Message, req *pb.ChatMessage) (*time.Time, error) {
+	if err := validateMessageTTL(req.GetTtlSeconds()); err != nil {
+		return nil, err
+	}
+	message.TTLSeconds = int(req.GetTtlSeconds())
+
+	if err := validateMessageTokens(req.GetSearchTokens()); err != nil {
+		return nil, err
+	}
+
+	if req.GetDeliverAt() == 0 {
+		return nil, nil
+	}
+
+	deliverAt := time.Unix(req.GetDeliverAt(), 0)
+	if !deliverAt.After(message.Timestamp) {
+		return nil, nil
+	}
+
+	if deliverAt.Sub(message.Timestamp) > maxScheduleDelay {
+		return nil, status.Errorf(codes.InvalidArgument, "message cannot be scheduled more than %d days ahead", int(maxScheduleDelay.Hours()/24))
+	}
+
+	return &deliverAt, nil
+}
+
+func validateMessageTTL(ttlSeconds uint32) error {
+	if ttlSeconds == 0 {
+		return nil
+	}
+
+	ttl := time.Duration(ttlSeconds) * time.Second
+	if ttl < minMessageTTL || ttl > maxMessageTTL {
+		return status.Errorf(codes.InvalidArgument, "message ttl must be between %d and %d seconds",
+			int(minMessageTTL.Seconds()), int(maxMessageTTL.Seconds()))
+	}
+
+	return nil
+}
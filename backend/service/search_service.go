@@ -0,0 +1,183 @@
+package service
+
+import (
+	"context"
+	"gRPCWebServer/backend/entities"
+	pb "gRPCWebServer/backend/generated"
+	"gRPCWebServer/backend/middleware"
+	"log"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	minSearchTokenSize        = 8
+	maxSearchTokenSize        = 64
+	maxSearchTokensPerQuery   = 16
+	maxSearchTokensPerMessage = 256
+	maxIndexedMessages        = 200
+)
+
+// SearchMessages ищет сообщения в чатах пользователя по метаданным и его слепому индексу
+func (cs *chatService) SearchMessages(ctx context.Context, req *pb.SearchMessagesRequest) (*pb.SearchMessagesResponse, error) {
+	userId, ok := ctx.Value(middleware.TokenKey("user_id")).(uint64)
+	if !ok {
+		return nil, status.Errorf(codes.Unauthenticated, "User ID is missing in context")
+	}
+
+	if len(req.Tokens) > maxSearchTokensPerQuery {
+		return nil, status.Errorf(codes.InvalidArgument, "cannot search for more than %d tokens", maxSearchTokensPerQuery)
+	}
+	if err := validateSearchTokens(req.Tokens); err != nil {
+		return nil, err
+	}
+
+	query := entities.SearchQuery{
+		UserID:         userId,
+		HasAttachment:  req.HasAttachment,
+		FileName:       req.FileName,
+		MimeTypePrefix: req.MimeType,
+		Tokens:         req.Tokens,
+		BeforeID:       req.BeforeId,
+		Limit:          historyPageSize(req.PageSize),
+	}
+
+	if req.Username != "" || req.GroupId != 0 {
+		chatId, err := cs.resolveChat(ctx, userId, req.Username, req.GroupId)
+		if err != nil {
+			return nil, err
+		}
+		query.ChatID = chatId
+	}
+
+	if req.FromUsername != "" {
+		sender, err := cs.userRepo.GetByUsername(ctx, req.FromUsername)
+		if err != nil {
+			return nil, status.Errorf(codes.NotFound, "user '%s' not found", req.FromUsername)
+		}
+		query.SenderID = sender.ID
+	}
+
+	if req.Since != 0 {
+		since := time.Unix(req.Since, 0)
+		query.Since = &since
+	}
+	if req.Until != 0 {
+		until := time.Unix(req.Until, 0)
+		query.Until = &until
+	}
+
+	results, hasMore, err := cs.searchRepo.Search(ctx, query)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "%v", err)
+	}
+
+	response := &pb.SearchMessagesResponse{
+		Results: make([]*pb.SearchResult, 0, len(results)),
+		HasMore: hasMore,
+	}
+	for i := range results {
+		resp := messageToResponse(&results[i].Message, results[i].SenderUsername)
+		if results[i].ReceiverId == 0 {
+			resp.GroupId = results[i].ChatID
+		}
+		result := &pb.SearchResult{
+			Message:      resp,
+			ChatUsername: results[i].PeerUsername,
+		}
+		if attachments := results[i].Attachments; len(attachments) > 0 {
+			result.FileName = attachments[0].FileName
+			result.MimeType = attachments[0].MimeType
+		}
+		response.Results = append(response.Results, result)
+	}
+
+	return response, nil
+}
+
+// IndexMessages добавляет в слепой индекс пользователя токены сообщений, которые он может читать.
+// Так клиент индексирует полученные сообщения и старую историю.
+func (cs *chatService) IndexMessages(ctx context.Context, req *pb.IndexMessagesRequest) (*pb.IndexMessagesResponse, error) {
+	userId, ok := ctx.Value(middleware.TokenKey("user_id")).(uint64)
+	if !ok {
+		return nil, status.Errorf(codes.Unauthenticated, "User ID is missing in context")
+	}
+
+	if len(req.Messages) > maxIndexedMessages {
+		return nil, status.Errorf(codes.InvalidArgument, "cannot index more than %d messages at once", maxIndexedMessages)
+	}
+
+	for _, entry := range req.Messages {
+		if err := validateMessageTokens(entry.Tokens); err != nil {
+			return nil, err
+		}
+
+		message, err := cs.messageRepo.GetByID(ctx, entry.MessageId)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "%v", err)
+		}
+
+		if message == nil || !cs.canReadMessage(ctx, userId, message) {
+			return nil, status.Errorf(codes.NotFound, "message %d not found", entry.MessageId)
+		}
+	}
+
+	for _, entry := range req.Messages {
+		if err := cs.searchRepo.AddTokens(ctx, userId, entry.MessageId, entry.Tokens); err != nil {
+			return nil, status.Errorf(codes.Internal, "%v", err)
+		}
+	}
+
+	return &pb.IndexMessagesResponse{
+		Success: true,
+	}, nil
+}
+
+// ClearSearchIndex удаляет слепой индекс пользователя
+func (cs *chatService) ClearSearchIndex(ctx context.Context, req *pb.ClearSearchIndexRequest) (*pb.ClearSearchIndexResponse, error) {
+	userId, ok := ctx.Value(middleware.TokenKey("user_id")).(uint64)
+	if !ok {
+		return nil, status.Errorf(codes.Unauthenticated, "User ID is missing in context")
+	}
+
+	if err := cs.searchRepo.ClearTokens(ctx, userId); err != nil {
+		return nil, status.Errorf(codes.Internal, "%v", err)
+	}
+
+	return &pb.ClearSearchIndexResponse{
+		Success: true,
+	}, nil
+}
+
+// indexSentMessage сохраняет токены, которые отправитель приложил к сообщению.
+// Ошибка индексации не мешает отправке: сообщение уже сохранено.
+func (s *chatService) indexSentMessage(ctx context.Context, message *entities.Message, tokens [][]byte) {
+	if len(tokens) == 0 {
+		return
+	}
+
+	if err := s.searchRepo.AddTokens(ctx, message.SenderId, message.ID, tokens); err != nil {
+		log.Printf("Failed to index message %d: %v", message.ID, err)
+	}
+}
+
+// validateMessageTokens проверяет токены слепого индекса одного сообщения
+func validateMessageTokens(tokens [][]byte) error {
+	if len(tokens) > maxSearchTokensPerMessage {
+		return status.Errorf(codes.InvalidArgument, "message cannot have more than %d search tokens", maxSearchTokensPerMessage)
+	}
+
+	return validateSearchTokens(tokens)
+}
+
+func validateSearchTokens(tokens [][]byte) error {
+	for _, token := range tokens {
+		if len(token) < minSearchTokenSize || len(token) > maxSearchTokenSize {
+			return status.Errorf(codes.InvalidArgument, "search token must be %d to %d bytes long", minSearchTokenSize, maxSearchTokenSize)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,184 @@
+package service
+
+import (
+	"context"
+	"gRPCWebServer/backend/entities"
+	pb "gRPCWebServer/backend/generated"
+	"gRPCWebServer/backend/middleware"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Максимальный размер обертки ключа отправителя; сам ключ и служебные данные шифра занимают меньше
+const maxWrappedSenderKeySize = 1024
+
+// DistributeSenderKey сохраняет ключ отправителя, зашифрованный для участников группы
+func (s *KeyExchangeService) DistributeSenderKey(ctx context.Context, req *pb.DistributeSenderKeyRequest) (*pb.DistributeSenderKeyResponse, error) {
+	senderID, ok := ctx.Value(middleware.TokenKey("user_id")).(uint64)
+	if !ok {
+		return nil, status.Errorf(codes.Unauthenticated, "User ID is missing in context")
+	}
+
+	members, err := s.groupMembers(ctx, req.GetGroupId(), senderID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(req.GetKeys()) == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "At least one wrapped key is required")
+	}
+
+	current, err := s.senderKeyRepo.GetCurrentEpoch(ctx, req.GetGroupId(), senderID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "%v", err)
+	}
+
+	// Дослать ключ можно только в актуальную эпоху; после изменения состава группы нужна новая
+	var currentEpoch uint32
+	if current != nil {
+		currentEpoch = current.Epoch
+	}
+	switch {
+	case req.GetEpoch() == currentEpoch+1:
+	case current != nil && req.GetEpoch() == currentEpoch && !current.Stale:
+	case current != nil && req.GetEpoch() == currentEpoch:
+		return nil, status.Errorf(codes.FailedPrecondition, "Group membership changed, rotate the key to epoch %d", currentEpoch+1)
+	default:
+		return nil, status.Errorf(codes.FailedPrecondition, "Unexpected epoch %d, current epoch is %d", req.GetEpoch(), currentEpoch)
+	}
+
+	memberIDs := make(map[string]uint64, len(members))
+	for _, member := range members {
+		memberIDs[member.Username] = member.UserID
+	}
+
+	bundles := make([]entities.SenderKeyBundle, 0, len(req.GetKeys()))
+	for _, key := range req.GetKeys() {
+		recipientID, ok := memberIDs[key.GetUsername()]
+		if !ok {
+			return nil, status.Errorf(codes.InvalidArgument, "User '%s' is not a member of this group", key.GetUsername())
+		}
+
+		if recipientID == senderID {
+			return nil, status.Errorf(codes.InvalidArgument, "Cannot wrap a sender key for yourself")
+		}
+
+		if len(key.GetWrappedKey()) == 0 || len(key.GetWrappedKey()) > maxWrappedSenderKeySize {
+			return nil, status.Errorf(codes.InvalidArgument, "Wrapped key for '%s' must be 1 to %d bytes long", key.GetUsername(), maxWrappedSenderKeySize)
+		}
+
+		bundles = append(bundles, entities.SenderKeyBundle{
+			RecipientID: recipientID,
+			WrappedKey:  key.GetWrappedKey(),
+		})
+	}
+
+	if err := s.senderKeyRepo.SaveBundles(ctx, req.GetGroupId(), senderID, req.GetEpoch(), bundles); err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to save sender keys: %v", err)
+	}
+
+	recipients, err := s.senderKeyRepo.ListRecipients(ctx, req.GetGroupId(), senderID, req.GetEpoch())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "%v", err)
+	}
+
+	response := &pb.DistributeSenderKeyResponse{
+		Success: true,
+	}
+	for _, recipient := range recipients {
+		if !recipient.HasKey {
+			response.PendingUsernames = append(response.PendingUsernames, recipient.Username)
+		}
+	}
+
+	return response, nil
+}
+
+// GetSenderKeyStatus возвращает состояние ключа отправителя текущего пользователя в группе
+func (s *KeyExchangeService) GetSenderKeyStatus(ctx context.Context, req *pb.GetSenderKeyStatusRequest) (*pb.GetSenderKeyStatusResponse, error) {
+	senderID, ok := ctx.Value(middleware.TokenKey("user_id")).(uint64)
+	if !ok {
+		return nil, status.Errorf(codes.Unauthenticated, "User ID is missing in context")
+	}
+
+	if _, err := s.groupMembers(ctx, req.GetGroupId(), senderID); err != nil {
+		return nil, err
+	}
+
+	current, err := s.senderKeyRepo.GetCurrentEpoch(ctx, req.GetGroupId(), senderID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "%v", err)
+	}
+
+	if current == nil {
+		return &pb.GetSenderKeyStatusResponse{
+			RotationRequired: true,
+		}, nil
+	}
+
+	recipients, err := s.senderKeyRepo.ListRecipients(ctx, req.GetGroupId(), senderID, current.Epoch)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "%v", err)
+	}
+
+	response := &pb.GetSenderKeyStatusResponse{
+		Epoch:            current.Epoch,
+		RotationRequired: current.Stale,
+	}
+	for _, recipient := range recipients {
+		switch {
+		case !recipient.HasKey:
+			response.PendingUsernames = append(response.PendingUsernames, recipient.Username)
+		case !recipient.Delivered:
+			response.UndeliveredUsernames = append(response.UndeliveredUsernames, recipient.Username)
+		}
+	}
+
+	return response, nil
+}
+
+// GetSenderKeys возвращает ключи других участников группы, зашифрованные для текущего пользователя
+func (s *KeyExchangeService) GetSenderKeys(ctx context.Context, req *pb.GetSenderKeysRequest) (*pb.GetSenderKeysResponse, error) {
+	userID, ok := ctx.Value(middleware.TokenKey("user_id")).(uint64)
+	if !ok {
+		return nil, status.Errorf(codes.Unauthenticated, "User ID is missing in context")
+	}
+
+	if _, err := s.groupMembers(ctx, req.GetGroupId(), userID); err != nil {
+		return nil, err
+	}
+
+	bundles, err := s.senderKeyRepo.FetchForRecipient(ctx, req.GetGroupId(), userID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "%v", err)
+	}
+
+	response := &pb.GetSenderKeysResponse{
+		Keys: make([]*pb.SenderKeyBundle, 0, len(bundles)),
+	}
+	for _, bundle := range bundles {
+		response.Keys = append(response.Keys, &pb.SenderKeyBundle{
+			SenderUsername: bundle.SenderUsername,
+			Epoch:          bundle.Epoch,
+			WrappedKey:     bundle.WrappedKey,
+			CreatedAt:      bundle.CreatedAt.Unix(),
+		})
+	}
+
+	return response, nil
+}
+
+// groupMembers возвращает участников группы, если пользователь в ней состоит
+func (s *KeyExchangeService) groupMembers(ctx context.Context, groupID, userID uint64) ([]entities.GroupMember, error) {
+	members, err := s.groupRepo.ListMembers(ctx, groupID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "%v", err)
+	}
+
+	if !hasMember(members, userID) {
+		return nil, status.Errorf(codes.NotFound, "Group not found")
+	}
+
+	return members, nil
+}
@@ -0,0 +1,390 @@
+package service
+
+import (
+	"archive/zip"
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"gRPCWebServer/backend/entities"
+	pb "gRPCWebServer/backend/generated"
+	"gRPCWebServer/backend/middleware"
+	"gRPCWebServer/backend/utils"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Размер части архива, отправляемой клиенту за один раз
+const exportChunkSize = 64 * 1024
+
+// DeleteAccount удаляет аккаунт после повторной проверки пароля: записи в базе,
+// файлы на диске и очередь недоставленных сообщений в RabbitMQ
+func (us *UserService) DeleteAccount(ctx context.Context, req *pb.DeleteAccountRequest) (*pb.DeleteAccountResponse, error) {
+	userId, ok := ctx.Value(middleware.TokenKey("user_id")).(uint64)
+	if !ok {
+		return nil, status.Errorf(codes.Unauthenticated, "User ID is missing in context")
+	}
+
+	user, err := us.repo.GetByID(ctx, userId)
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "user not found")
+	}
+
+	match, _, err := utils.VerifyPassword(req.Password, user.PasswordHash)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to verify password: %v", err)
+	}
+
+	if !match {
+		return nil, status.Errorf(codes.Unauthenticated, "incorrect password")
+	}
+
+	filePaths, chatIDs, err := us.accountRepo.Delete(ctx, userId)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "%v", err)
+	}
+
+	// Аккаунт уже удален из базы, поэтому ошибки очистки только логируются
+	legacyDir := filepath.Join(us.baseFilePath, "files")
+	for _, path := range filePaths {
+		paths := []string{path}
+		// Рядом с файлами, загруженными через WebSocket до FileService, лежат их метаданные
+		if filepath.Dir(filepath.Clean(path)) == legacyDir {
+			paths = append(paths, path+".meta")
+		}
+
+		for _, path := range paths {
+			if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+				log.Printf("Failed to remove file %s of deleted user %d: %v", path, userId, err)
+			}
+		}
+	}
+
+	dirs := []string{filepath.Join(us.baseFilePath, "files", "users", strconv.FormatUint(userId, 10))}
+	for _, chatID := range chatIDs {
+		dirs = append(dirs, filepath.Join(us.baseFilePath, "files", strconv.FormatUint(chatID, 10)))
+	}
+	for _, dir := range dirs {
+		if err := os.RemoveAll(dir); err != nil {
+			log.Printf("Failed to remove directory %s of deleted user %d: %v", dir, userId, err)
+		}
+	}
+
+	if err := us.broker.DeleteQueue(fmt.Sprintf("chat_queue_%s", user.Username)); err != nil {
+		log.Printf("Failed to purge message queue of deleted user %s: %v", user.Username, err)
+	}
+
+	log.Printf("User %d deleted their account", userId)
+
+	return &pb.DeleteAccountResponse{
+		Success: true,
+	}, nil
+}
+
+type exportedProfile struct {
+	Username         string   `json:"username"`
+	DisplayName      string   `json:"display_name"`
+	Bio              string   `json:"bio"`
+	AvatarFileID     string   `json:"avatar_file_id,omitempty"`
+	HiddenFromSearch bool     `json:"hidden_from_search"`
+	HideLastSeen     bool     `json:"hide_last_seen"`
+	Contacts         []string `json:"contacts"`
+	Blocked          []string `json:"blocked"`
+}
+
+type exportedChat struct {
+	ID                  uint64 `json:"id"`
+	Username            string `json:"username"`
+	EncryptionAlgorithm string `json:"encryption_algorithm"`
+	EncryptionMode      string `json:"encryption_mode"`
+	EncryptionPadding   string `json:"encryption_padding"`
+	MessagesFile        string `json:"messages_file"`
+}
+
+type exportedGroup struct {
+	ID                  uint64 `json:"id"`
+	Title               string `json:"title"`
+	Role                string `json:"role"`
+	EncryptionAlgorithm string `json:"encryption_algorithm"`
+	EncryptionMode      string `json:"encryption_mode"`
+	EncryptionPadding   string `json:"encryption_padding"`
+	MessagesFile        string `json:"messages_file"`
+}
+
+type exportedMessage struct {
+	ID        uint64            `json:"id"`
+	Sender    string            `json:"sender"`
+	Timestamp time.Time         `json:"timestamp"`
+	Envelope  entities.Envelope `json:"envelope"` // тело — шифротекст в том виде, в каком он хранится на сервере
+}
+
+type exportedFile struct {
+	FileID     string    `json:"file_id"`
+	FileName   string    `json:"file_name"`
+	MimeType   string    `json:"mime_type"`
+	Size       int64     `json:"size"`
+	UploadedBy string    `json:"uploaded_by"`
+	ChatID     *uint64   `json:"chat_id,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+	Path       string    `json:"path,omitempty"` // путь внутри архива; пусто, если файла нет на диске
+}
+
+// ExportMyData отправляет zip-архив с профилем, метаданными чатов и групп, шифротекстами сообщений и файлами
+func (us *UserService) ExportMyData(req *pb.ExportMyDataRequest, stream pb.UserService_ExportMyDataServer) error {
+	ctx := stream.Context()
+
+	userId, ok := ctx.Value(middleware.TokenKey("user_id")).(uint64)
+	if !ok {
+		return status.Errorf(codes.Unauthenticated, "User ID is missing in context")
+	}
+
+	buffered := bufio.NewWriterSize(&exportStreamWriter{stream: stream}, exportChunkSize)
+	archive := zip.NewWriter(buffered)
+
+	if err := us.exportArchive(ctx, userId, archive); err != nil {
+		return err
+	}
+
+	if err := archive.Close(); err != nil {
+		return status.Errorf(codes.Internal, "failed to finish archive: %v", err)
+	}
+
+	if err := buffered.Flush(); err != nil {
+		return status.Errorf(codes.Internal, "failed to send archive: %v", err)
+	}
+
+	return nil
+}
+
+func (us *UserService) exportArchive(ctx context.Context, userID uint64, archive *zip.Writer) error {
+	profile, err := us.profileRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to get profile: %v", err)
+	}
+
+	if profile == nil {
+		return status.Errorf(codes.NotFound, "user not found")
+	}
+
+	contacts, err := us.contactRepo.List(ctx, userID)
+	if err != nil {
+		return status.Errorf(codes.Internal, "%v", err)
+	}
+
+	blocked, err := us.blockRepo.ListBlocked(ctx, userID)
+	if err != nil {
+		return status.Errorf(codes.Internal, "%v", err)
+	}
+
+	exportProfile := exportedProfile{
+		Username:         profile.Username,
+		DisplayName:      profile.DisplayName,
+		Bio:              profile.Bio,
+		HiddenFromSearch: profile.HiddenFromSearch,
+		HideLastSeen:     profile.HideLastSeen,
+		Contacts:         make([]string, 0, len(contacts)),
+		Blocked:          make([]string, 0, len(blocked)),
+	}
+	if profile.AvatarFileID != nil {
+		exportProfile.AvatarFileID = *profile.AvatarFileID
+	}
+	for _, contact := range contacts {
+		exportProfile.Contacts = append(exportProfile.Contacts, contact.Username)
+	}
+	for _, user := range blocked {
+		exportProfile.Blocked = append(exportProfile.Blocked, user.Username)
+	}
+
+	if err := writeArchiveJSON(archive, "profile.json", exportProfile); err != nil {
+		return err
+	}
+
+	chats, err := us.accountRepo.ListChats(ctx, userID)
+	if err != nil {
+		return status.Errorf(codes.Internal, "%v", err)
+	}
+
+	usernames := map[uint64]string{userID: profile.Username}
+	exportChats := make([]exportedChat, 0, len(chats))
+	for _, chat := range chats {
+		usernames[chat.FirstUserID] = chat.FirstUsername
+		usernames[chat.SecondUserID] = chat.SecondUsername
+
+		peer := chat.FirstUsername
+		if chat.FirstUserID == userID {
+			peer = chat.SecondUsername
+		}
+
+		messagesFile := fmt.Sprintf("messages/%d.json", chat.ID)
+		exportChats = append(exportChats, exportedChat{
+			ID:                  chat.ID,
+			Username:            peer,
+			EncryptionAlgorithm: chat.EncryptionAlgorithm,
+			EncryptionMode:      chat.EncryptionMode,
+			EncryptionPadding:   chat.EncryptionPadding,
+			MessagesFile:        messagesFile,
+		})
+
+		if err := us.exportMessages(ctx, archive, messagesFile, chat.ID, usernames); err != nil {
+			return err
+		}
+	}
+
+	if err := writeArchiveJSON(archive, "chats.json", exportChats); err != nil {
+		return err
+	}
+
+	groups, err := us.accountRepo.ListGroups(ctx, userID)
+	if err != nil {
+		return status.Errorf(codes.Internal, "%v", err)
+	}
+
+	exportGroups := make([]exportedGroup, 0, len(groups))
+	for _, group := range groups {
+		messagesFile := fmt.Sprintf("messages/%d.json", group.ID)
+		exportGroup := exportedGroup{
+			ID:           group.ID,
+			Title:        group.Title,
+			Role:         group.Role,
+			MessagesFile: messagesFile,
+		}
+		if group.EncryptionAlgorithm != nil {
+			exportGroup.EncryptionAlgorithm = *group.EncryptionAlgorithm
+		}
+		if group.EncryptionMode != nil {
+			exportGroup.EncryptionMode = *group.EncryptionMode
+		}
+		if group.EncryptionPadding != nil {
+			exportGroup.EncryptionPadding = *group.EncryptionPadding
+		}
+		exportGroups = append(exportGroups, exportGroup)
+
+		if err := us.exportMessages(ctx, archive, messagesFile, group.ID, usernames); err != nil {
+			return err
+		}
+	}
+
+	if err := writeArchiveJSON(archive, "groups.json", exportGroups); err != nil {
+		return err
+	}
+
+	files, err := us.accountRepo.ListFiles(ctx, userID)
+	if err != nil {
+		return status.Errorf(codes.Internal, "%v", err)
+	}
+
+	exportFiles := make([]exportedFile, 0, len(files))
+	for _, file := range files {
+		exportFile := exportedFile{
+			FileID:     file.FileID,
+			FileName:   file.FileName,
+			MimeType:   file.MimeType,
+			Size:       file.Size,
+			UploadedBy: usernames[file.UploadedBy],
+			ChatID:     file.ChatID,
+			CreatedAt:  file.CreatedAt,
+		}
+
+		archivePath := "files/" + file.FileID + "/" + filepath.Base(file.FileName)
+		copied, err := copyFileToArchive(archive, archivePath, file.Path)
+		if err != nil {
+			return err
+		}
+		if copied {
+			exportFile.Path = archivePath
+		}
+
+		exportFiles = append(exportFiles, exportFile)
+	}
+
+	return writeArchiveJSON(archive, "files.json", exportFiles)
+}
+
+// exportMessages записывает сообщения чата в архив. usernames кэширует имена отправителей;
+// имена участников групп, не встречавшихся в личных чатах, запрашиваются из базы.
+func (us *UserService) exportMessages(ctx context.Context, archive *zip.Writer, name string, chatID uint64, usernames map[uint64]string) error {
+	messages, err := us.accountRepo.ListMessages(ctx, chatID)
+	if err != nil {
+		return status.Errorf(codes.Internal, "%v", err)
+	}
+
+	exportMessages := make([]exportedMessage, 0, len(messages))
+	for _, message := range messages {
+		sender, ok := usernames[message.SenderId]
+		if !ok {
+			sender, err = us.repo.GetUserNameById(ctx, message.SenderId)
+			if err != nil {
+				return status.Errorf(codes.Internal, "failed to get sender username: %v", err)
+			}
+			usernames[message.SenderId] = sender
+		}
+
+		exportMessages = append(exportMessages, exportedMessage{
+			ID:        message.ID,
+			Sender:    sender,
+			Timestamp: message.Timestamp,
+			Envelope:  message.Envelope,
+		})
+	}
+
+	return writeArchiveJSON(archive, name, exportMessages)
+}
+
+func writeArchiveJSON(archive *zip.Writer, name string, value interface{}) error {
+	w, err := archive.Create(name)
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to add %s to archive: %v", name, err)
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(value); err != nil {
+		return status.Errorf(codes.Internal, "failed to write %s: %v", name, err)
+	}
+
+	return nil
+}
+
+// copyFileToArchive добавляет файл с диска в архив; возвращает false, если файла на диске нет
+func copyFileToArchive(archive *zip.Writer, name, path string) (bool, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			log.Printf("File %s is missing on disk, skipping it in export", path)
+			return false, nil
+		}
+		return false, status.Errorf(codes.Internal, "failed to open file: %v", err)
+	}
+	defer f.Close()
+
+	w, err := archive.Create(name)
+	if err != nil {
+		return false, status.Errorf(codes.Internal, "failed to add %s to archive: %v", name, err)
+	}
+
+	if _, err := io.Copy(w, f); err != nil {
+		return false, status.Errorf(codes.Internal, "failed to write %s: %v", name, err)
+	}
+
+	return true, nil
+}
+
+// exportStreamWriter отправляет записанные данные клиенту частями архива
+type exportStreamWriter struct {
+	stream pb.UserService_ExportMyDataServer
+}
+
+func (w *exportStreamWriter) Write(p []byte) (int, error) {
+	// bufio.Writer переиспользует буфер после Write, а Send может держать сообщение дольше
+	if err := w.stream.Send(&pb.ExportMyDataChunk{Data: append([]byte(nil), p...)}); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
@@ -0,0 +1,102 @@
+package service
+
+import (
+	"context"
+	pb "gRPCWebServer/backend/generated"
+	"gRPCWebServer/backend/middleware"
+	"gRPCWebServer/backend/repository"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// BlockUser блокирует пользователя и убирает его из контактов
+func (us *UserService) BlockUser(ctx context.Context, req *pb.BlockUserRequest) (*pb.BlockUserResponse, error) {
+	userId, ok := ctx.Value(middleware.TokenKey("user_id")).(uint64)
+	if !ok {
+		return nil, status.Errorf(codes.Unauthenticated, "User ID is missing in context")
+	}
+
+	blockedID, err := us.userIDByUsername(ctx, req.Username)
+	if err != nil {
+		return nil, err
+	}
+
+	if blockedID == userId {
+		return nil, status.Errorf(codes.InvalidArgument, "cannot block yourself")
+	}
+
+	if err := us.blockRepo.Block(ctx, userId, blockedID); err != nil {
+		return nil, status.Errorf(codes.Internal, "%v", err)
+	}
+
+	if _, err := us.contactRepo.Remove(ctx, userId, blockedID); err != nil {
+		return nil, status.Errorf(codes.Internal, "%v", err)
+	}
+
+	return &pb.BlockUserResponse{
+		Success: true,
+	}, nil
+}
+
+// UnblockUser снимает блокировку с пользователя
+func (us *UserService) UnblockUser(ctx context.Context, req *pb.UnblockUserRequest) (*pb.UnblockUserResponse, error) {
+	userId, ok := ctx.Value(middleware.TokenKey("user_id")).(uint64)
+	if !ok {
+		return nil, status.Errorf(codes.Unauthenticated, "User ID is missing in context")
+	}
+
+	blockedID, err := us.userIDByUsername(ctx, req.Username)
+	if err != nil {
+		return nil, err
+	}
+
+	unblocked, err := us.blockRepo.Unblock(ctx, userId, blockedID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "%v", err)
+	}
+
+	if !unblocked {
+		return nil, status.Errorf(codes.NotFound, "user '%s' is not blocked", req.Username)
+	}
+
+	return &pb.UnblockUserResponse{
+		Success: true,
+	}, nil
+}
+
+// ListBlocked возвращает пользователей, заблокированных текущим пользователем
+func (us *UserService) ListBlocked(ctx context.Context, req *pb.ListBlockedRequest) (*pb.ListBlockedResponse, error) {
+	userId, ok := ctx.Value(middleware.TokenKey("user_id")).(uint64)
+	if !ok {
+		return nil, status.Errorf(codes.Unauthenticated, "User ID is missing in context")
+	}
+
+	profiles, err := us.blockRepo.ListBlocked(ctx, userId)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "%v", err)
+	}
+
+	response := &pb.ListBlockedResponse{
+		Users: make([]*pb.UserProfile, 0, len(profiles)),
+	}
+	for i := range profiles {
+		response.Users = append(response.Users, profileToProto(&profiles[i], userId))
+	}
+
+	return response, nil
+}
+
+// checkNotBlocked возвращает PermissionDenied, если один из пользователей заблокировал другого
+func checkNotBlocked(ctx context.Context, blockRepo repository.BlockRepository, userID, peerID uint64) error {
+	blocked, err := blockRepo.IsBlocked(ctx, userID, peerID)
+	if err != nil {
+		return status.Errorf(codes.Internal, "%v", err)
+	}
+
+	if blocked {
+		return status.Errorf(codes.PermissionDenied, "user is blocked")
+	}
+
+	return nil
+}
@@ -0,0 +1,137 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	pb "gRPCWebServer/backend/generated"
+	"gRPCWebServer/backend/middleware"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// SearchUsers ищет пользователей по префиксу и похожести имени.
+// Пользователи, скрывшие себя из поиска, находятся только по точному имени.
+func (us *UserService) SearchUsers(ctx context.Context, req *pb.SearchUsersRequest) (*pb.SearchUsersResponse, error) {
+	userId, ok := ctx.Value(middleware.TokenKey("user_id")).(uint64)
+	if !ok {
+		return nil, status.Errorf(codes.Unauthenticated, "User ID is missing in context")
+	}
+
+	query := strings.TrimSpace(req.Query)
+	if query == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "search query is empty")
+	}
+
+	page := int(req.Page)
+	if page <= 0 {
+		page = 1
+	}
+
+	pageSize := int(req.PageSize)
+	if pageSize <= 0 || pageSize > 50 {
+		pageSize = 20
+	}
+
+	profiles, totalCount, err := us.profileRepo.Search(ctx, userId, query, page, pageSize)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "%v", err)
+	}
+
+	response := &pb.SearchUsersResponse{
+		Users:      make([]*pb.UserProfile, 0, len(profiles)),
+		TotalCount: int32(totalCount),
+	}
+	for i := range profiles {
+		response.Users = append(response.Users, profileToProto(&profiles[i], userId))
+	}
+
+	return response, nil
+}
+
+// AddContact добавляет пользователя в контакты по точному имени
+func (us *UserService) AddContact(ctx context.Context, req *pb.AddContactRequest) (*pb.AddContactResponse, error) {
+	userId, ok := ctx.Value(middleware.TokenKey("user_id")).(uint64)
+	if !ok {
+		return nil, status.Errorf(codes.Unauthenticated, "User ID is missing in context")
+	}
+
+	contactID, err := us.userIDByUsername(ctx, req.Username)
+	if err != nil {
+		return nil, err
+	}
+
+	if contactID == userId {
+		return nil, status.Errorf(codes.InvalidArgument, "cannot add yourself to contacts")
+	}
+
+	if err := us.contactRepo.Add(ctx, userId, contactID); err != nil {
+		return nil, status.Errorf(codes.Internal, "%v", err)
+	}
+
+	return &pb.AddContactResponse{
+		Success: true,
+	}, nil
+}
+
+// RemoveContact удаляет пользователя из контактов
+func (us *UserService) RemoveContact(ctx context.Context, req *pb.RemoveContactRequest) (*pb.RemoveContactResponse, error) {
+	userId, ok := ctx.Value(middleware.TokenKey("user_id")).(uint64)
+	if !ok {
+		return nil, status.Errorf(codes.Unauthenticated, "User ID is missing in context")
+	}
+
+	contactID, err := us.userIDByUsername(ctx, req.Username)
+	if err != nil {
+		return nil, err
+	}
+
+	removed, err := us.contactRepo.Remove(ctx, userId, contactID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "%v", err)
+	}
+
+	if !removed {
+		return nil, status.Errorf(codes.NotFound, "user '%s' is not in contacts", req.Username)
+	}
+
+	return &pb.RemoveContactResponse{
+		Success: true,
+	}, nil
+}
+
+// ListContacts возвращает контакты текущего пользователя
+func (us *UserService) ListContacts(ctx context.Context, req *pb.ListContactsRequest) (*pb.ListContactsResponse, error) {
+	userId, ok := ctx.Value(middleware.TokenKey("user_id")).(uint64)
+	if !ok {
+		return nil, status.Errorf(codes.Unauthenticated, "User ID is missing in context")
+	}
+
+	profiles, err := us.contactRepo.List(ctx, userId)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "%v", err)
+	}
+
+	response := &pb.ListContactsResponse{
+		Contacts: make([]*pb.UserProfile, 0, len(profiles)),
+	}
+	for i := range profiles {
+		response.Contacts = append(response.Contacts, profileToProto(&profiles[i], userId))
+	}
+
+	return response, nil
+}
+
+func (us *UserService) userIDByUsername(ctx context.Context, username string) (uint64, error) {
+	user, err := us.repo.GetByUsername(ctx, username)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, status.Errorf(codes.NotFound, "user '%s' not found", username)
+		}
+		return 0, status.Errorf(codes.Internal, "failed to get user: %v", err)
+	}
+
+	return user.ID, nil
+}
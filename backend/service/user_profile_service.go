@@ -0,0 +1,143 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"gRPCWebServer/backend/entities"
+	pb "gRPCWebServer/backend/generated"
+	"gRPCWebServer/backend/middleware"
+	"strings"
+	"unicode/utf8"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	maxDisplayNameLength = 64
+	maxBioLength         = 500
+)
+
+// GetProfile возвращает профиль пользователя по имени или профиль текущего пользователя
+func (us *UserService) GetProfile(ctx context.Context, req *pb.GetProfileRequest) (*pb.UserProfile, error) {
+	userId, ok := ctx.Value(middleware.TokenKey("user_id")).(uint64)
+	if !ok {
+		return nil, status.Errorf(codes.Unauthenticated, "User ID is missing in context")
+	}
+
+	var profile *entities.UserProfile
+	var err error
+	if req.Username == "" {
+		profile, err = us.profileRepo.GetByUserID(ctx, userId)
+	} else {
+		profile, err = us.profileRepo.GetByUsername(ctx, req.Username)
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get profile: %v", err)
+	}
+
+	if profile == nil {
+		return nil, status.Errorf(codes.NotFound, "user not found")
+	}
+
+	return profileToProto(profile, userId), nil
+}
+
+// UpdateProfile сохраняет отображаемое имя, описание и аватар текущего пользователя.
+// Аватар должен быть изображением, загруженным пользователем через FileService без чата.
+func (us *UserService) UpdateProfile(ctx context.Context, req *pb.UpdateProfileRequest) (*pb.UserProfile, error) {
+	userId, ok := ctx.Value(middleware.TokenKey("user_id")).(uint64)
+	if !ok {
+		return nil, status.Errorf(codes.Unauthenticated, "User ID is missing in context")
+	}
+
+	displayName := strings.TrimSpace(req.DisplayName)
+	if utf8.RuneCountInString(displayName) > maxDisplayNameLength {
+		return nil, status.Errorf(codes.InvalidArgument, "display name must be at most %d characters long", maxDisplayNameLength)
+	}
+
+	bio := strings.TrimSpace(req.Bio)
+	if utf8.RuneCountInString(bio) > maxBioLength {
+		return nil, status.Errorf(codes.InvalidArgument, "bio must be at most %d characters long", maxBioLength)
+	}
+
+	var avatarFileID *string
+	if req.AvatarFileId != "" {
+		if err := us.checkAvatar(ctx, userId, req.AvatarFileId); err != nil {
+			return nil, err
+		}
+		avatarFileID = &req.AvatarFileId
+	}
+
+	updated := &entities.UserProfile{
+		UserID:           userId,
+		DisplayName:      displayName,
+		Bio:              bio,
+		AvatarFileID:     avatarFileID,
+		HiddenFromSearch: req.HiddenFromSearch,
+		HideLastSeen:     req.HideLastSeen,
+	}
+
+	if err := us.profileRepo.Update(ctx, updated); err != nil {
+		return nil, status.Errorf(codes.Internal, "%v", err)
+	}
+
+	profile, err := us.profileRepo.GetByUserID(ctx, userId)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get profile: %v", err)
+	}
+
+	if profile == nil {
+		return nil, status.Errorf(codes.NotFound, "user not found")
+	}
+
+	return profileToProto(profile, userId), nil
+}
+
+func (us *UserService) checkAvatar(ctx context.Context, userID uint64, fileID string) error {
+	file, err := us.fileRepo.GetFileByID(ctx, fileID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return status.Errorf(codes.NotFound, "avatar file not found")
+		}
+		return status.Errorf(codes.Internal, "failed to get avatar file: %v", err)
+	}
+
+	if file.UploadedBy != userID || file.ChatID != nil {
+		return status.Errorf(codes.PermissionDenied, "avatar must be a personal file uploaded by the user")
+	}
+
+	if !strings.HasPrefix(file.MimeType, "image/") {
+		return status.Errorf(codes.InvalidArgument, "avatar must be an image")
+	}
+
+	return nil
+}
+
+// profileToProto преобразует профиль для пользователя viewerID. Настройки приватности видит
+// только владелец, а время посещения скрывается от других, если владелец его скрыл.
+func profileToProto(profile *entities.UserProfile, viewerID uint64) *pb.UserProfile {
+	own := profile.UserID == viewerID
+
+	response := &pb.UserProfile{
+		Username:    profile.Username,
+		DisplayName: profile.DisplayName,
+		Bio:         profile.Bio,
+	}
+
+	if own {
+		response.HiddenFromSearch = profile.HiddenFromSearch
+		response.HideLastSeen = profile.HideLastSeen
+	}
+
+	if profile.AvatarFileID != nil {
+		response.AvatarFileId = *profile.AvatarFileID
+	}
+
+	if profile.LastSeenAt != nil && (own || !profile.HideLastSeen) {
+		response.LastSeenAt = profile.LastSeenAt.Unix()
+	}
+
+	return response
+}
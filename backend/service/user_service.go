@@ -2,30 +2,64 @@ package service
 
 import (
 	"context"
-	"crypto/subtle"
 	"database/sql"
-	"encoding/base64"
 	"errors"
 	"fmt"
+	"gRPCWebServer/backend/broker"
 	"gRPCWebServer/backend/entities"
 	pb "gRPCWebServer/backend/generated"
 	"gRPCWebServer/backend/middleware"
 	"gRPCWebServer/backend/repository"
 	"gRPCWebServer/backend/utils"
-	"strings"
+	"log"
+	"time"
 
-	"golang.org/x/crypto/argon2"
+	"github.com/google/uuid"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
 
 type UserService struct {
 	pb.UnimplementedUserServiceServer
-	repo repository.UserRepository
+	repo           repository.UserRepository
+	sessionRepo    repository.SessionRepository
+	totpRepo       repository.TOTPRepository
+	profileRepo    repository.ProfileRepository
+	contactRepo    repository.ContactRepository
+	blockRepo      repository.BlockRepository
+	accountRepo    repository.AccountRepository
+	fileRepo       repository.FileRepository
+	passwordPolicy *utils.PasswordPolicy
+	broker         broker.MessageBroker
+	baseFilePath   string // Базовый путь хранилища файлов, чтобы удалять их вместе с аккаунтом
 }
 
-func NewUserService(repo repository.UserRepository) *UserService {
-	return &UserService{repo: repo}
+func NewUserService(
+	repo repository.UserRepository,
+	sessionRepo repository.SessionRepository,
+	totpRepo repository.TOTPRepository,
+	profileRepo repository.ProfileRepository,
+	contactRepo repository.ContactRepository,
+	blockRepo repository.BlockRepository,
+	accountRepo repository.AccountRepository,
+	fileRepo repository.FileRepository,
+	passwordPolicy *utils.PasswordPolicy,
+	broker broker.MessageBroker,
+	baseFilePath string,
+) *UserService {
+	return &UserService{
+		repo:           repo,
+		sessionRepo:    sessionRepo,
+		totpRepo:       totpRepo,
+		profileRepo:    profileRepo,
+		contactRepo:    contactRepo,
+		blockRepo:      blockRepo,
+		accountRepo:    accountRepo,
+		fileRepo:       fileRepo,
+		passwordPolicy: passwordPolicy,
+		broker:         broker,
+		baseFilePath:   baseFilePath,
+	}
 }
 
 func (us *UserService) Register(ctx context.Context, req *pb.RegisterRequest) (*pb.RegisterResponse, error) {
@@ -35,21 +69,21 @@ func (us *UserService) Register(ctx context.Context, req *pb.RegisterRequest) (*
 		if errors.Is(err, sql.ErrNoRows) {
 			existingUser = nil
 		} else {
-			return nil, fmt.Errorf("failed to check existing user: %v", err)
+			return nil, status.Errorf(codes.Internal, "failed to check existing user: %v", err)
 		}
 	}
 
 	if existingUser != nil {
-		return nil, errors.New("username already taken")
+		return nil, status.Errorf(codes.AlreadyExists, "username already taken")
 	}
 
-	// if req.Password != req.Confirmpassword {
-	// 	return nil, errors.New("password must match")
-	// }
+	if err := us.passwordPolicy.Validate(req.Username, req.Password, req.Confirmpassword); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
 
 	passwordHash, err := utils.HashPassword(req.Password)
 	if err != nil {
-		return nil, fmt.Errorf("failed to hash password: %v", err)
+		return nil, status.Errorf(codes.Internal, "failed to hash password: %v", err)
 	}
 
 	user := entities.User{
@@ -59,50 +93,65 @@ func (us *UserService) Register(ctx context.Context, req *pb.RegisterRequest) (*
 
 	userID, err := us.repo.Create(ctx, user)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create user: %v", err)
+		return nil, status.Errorf(codes.Internal, "failed to create user: %v", err)
 	}
 
-	token, err := utils.GenerateToken(userID)
+	token, refreshToken, err := us.startSession(ctx, userID, req.DeviceLabel)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to generate token: %v", err)
+		return nil, status.Errorf(codes.Internal, "failed to start session: %v", err)
 	}
 
 	return &pb.RegisterResponse{
-		Token: token,
+		Token:        token,
+		RefreshToken: refreshToken,
 	}, nil
 }
 
 func (us *UserService) Login(ctx context.Context, req *pb.LoginRequest) (*pb.LoginResponse, error) {
+	// Не различаем "нет пользователя" и "неверный пароль": код Unauthenticated
+	// учитывается ограничителем попыток входа
 	user, err := us.repo.GetByUsername(ctx, req.Username)
 	if err != nil {
-		return nil, err
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Errorf(codes.Unauthenticated, "invalid username or password")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get user: %v", err)
 	}
 
-	if user == nil {
-		return nil, errors.New("user not found")
+	match, needsRehash, err := utils.VerifyPassword(req.Password, user.PasswordHash)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to verify password: %v", err)
 	}
 
-	parts := strings.Split(user.PasswordHash, ":")
-	if len(parts) != 2 {
-		return nil, errors.New("invalid hash format")
+	if !match {
+		return nil, status.Errorf(codes.Unauthenticated, "invalid username or password")
 	}
 
-	salt := parts[0]
-	storedHash := parts[1]
+	// Хеш создан с устаревшими параметрами — пересчитываем его, пока знаем пароль
+	if needsRehash {
+		if passwordHash, err := utils.HashPassword(req.Password); err != nil {
+			log.Printf("Failed to rehash password for user %d: %v", user.ID, err)
+		} else if err := us.repo.UpdatePasswordHash(ctx, user.ID, passwordHash); err != nil {
+			log.Printf("Failed to store rehashed password for user %d: %v", user.ID, err)
+		}
+	}
 
-	hash := argon2.IDKey([]byte(req.Password), []byte(salt), 1, 64*1024, 1, 32) // вынести константы в .env
-	hashStr := base64.StdEncoding.EncodeToString(hash)
+	// При включенной 2FA сессия создается только после VerifySecondFactor
+	challenge, err := us.loginChallenge(ctx, user.ID, req.DeviceLabel)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "%v", err)
+	}
 
-	if subtle.ConstantTimeCompare([]byte(storedHash), []byte(hashStr)) != 1 {
-		return nil, errors.New("incorrect password") // wrong username or password
+	if challenge != nil {
+		return challenge, nil
 	}
 
-	token, err := utils.GenerateToken(user.ID)
+	token, refreshToken, err := us.startSession(ctx, user.ID, req.DeviceLabel)
 	if err != nil {
-		return nil, fmt.Errorf("failed to generate token: %v", err)
+		return nil, fmt.Errorf("failed to start session: %v", err)
 	}
 
-	return &pb.LoginResponse{Token: token}, nil
+	return &pb.LoginResponse{Token: token, RefreshToken: refreshToken}, nil
 }
 
 func (us *UserService) Logout(ctx context.Context, req *pb.LogoutRequest) (*pb.LogoutResponse, error) {
@@ -111,7 +160,159 @@ func (us *UserService) Logout(ctx context.Context, req *pb.LogoutRequest) (*pb.L
 		return nil, status.Errorf(codes.Unauthenticated, "User ID is missing in context")
 	}
 
+	sessionId, ok := ctx.Value(middleware.TokenKey("session_id")).(string)
+	if !ok {
+		return nil, status.Errorf(codes.Unauthenticated, "Session ID is missing in context")
+	}
+
+	if err := us.sessionRepo.Revoke(ctx, sessionId); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to revoke session: %v", err)
+	}
+
 	return &pb.LogoutResponse{
 		Success: true,
 	}, nil
 }
+
+func (us *UserService) RefreshToken(ctx context.Context, req *pb.RefreshTokenRequest) (*pb.RefreshTokenResponse, error) {
+	if req.RefreshToken == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "refresh token is required")
+	}
+
+	tokenHash := utils.HashToken(req.RefreshToken)
+	session, err := us.sessionRepo.GetByRefreshTokenHash(ctx, tokenHash)
+	if err != nil {
+		if !errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Errorf(codes.Internal, "failed to get session: %v", err)
+		}
+
+		// Уже замененный токен предъявляет тот, кто его украл, или сам владелец после кражи;
+		// какой из них законный, неизвестно, поэтому сессия отзывается
+		revoked, err := us.sessionRepo.RevokeByPreviousRefreshTokenHash(ctx, tokenHash)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "%v", err)
+		}
+		if revoked {
+			log.Printf("Replaced refresh token reused, session revoked")
+			return nil, status.Errorf(codes.Unauthenticated, "refresh token has already been used, session revoked")
+		}
+		return nil, status.Errorf(codes.Unauthenticated, "invalid refresh token")
+	}
+
+	if time.Now().After(session.ExpiresAt) {
+		return nil, status.Errorf(codes.Unauthenticated, "refresh token expired")
+	}
+
+	// Каждый refresh-токен одноразовый: выдаем новый и заменяем хеш в сессии
+	refreshToken, err := utils.GenerateRefreshToken()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "%v", err)
+	}
+
+	err = us.sessionRepo.RotateRefreshToken(ctx, session.SessionID, tokenHash, utils.HashToken(refreshToken), time.Now().Add(utils.RefreshTokenTTL))
+	if err != nil {
+		if !errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Errorf(codes.Internal, "failed to rotate refresh token: %v", err)
+		}
+
+		// Токен одновременно предъявлен дважды, и другой запрос уже заменил его
+		if _, err := us.sessionRepo.RevokeByPreviousRefreshTokenHash(ctx, tokenHash); err != nil {
+			return nil, status.Errorf(codes.Internal, "%v", err)
+		}
+		return nil, status.Errorf(codes.Unauthenticated, "session has been revoked")
+	}
+
+	token, err := utils.GenerateToken(session.UserID, session.SessionID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to generate token: %v", err)
+	}
+
+	return &pb.RefreshTokenResponse{
+		Token:        token,
+		RefreshToken: refreshToken,
+	}, nil
+}
+
+func (us *UserService) ListSessions(ctx context.Context, req *pb.ListSessionsRequest) (*pb.ListSessionsResponse, error) {
+	userId, ok := ctx.Value(middleware.TokenKey("user_id")).(uint64)
+	if !ok {
+		return nil, status.Errorf(codes.Unauthenticated, "User ID is missing in context")
+	}
+
+	currentSessionId, _ := ctx.Value(middleware.TokenKey("session_id")).(string)
+
+	sessions, err := us.sessionRepo.ListActiveByUser(ctx, userId)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list sessions: %v", err)
+	}
+
+	response := &pb.ListSessionsResponse{}
+	for _, session := range sessions {
+		response.Sessions = append(response.Sessions, &pb.SessionInfo{
+			SessionId:   session.SessionID,
+			DeviceLabel: session.DeviceLabel,
+			IpAddress:   session.IPAddress,
+			CreatedAt:   session.CreatedAt.Unix(),
+			LastSeenAt:  session.LastSeenAt.Unix(),
+			Current:     session.SessionID == currentSessionId,
+		})
+	}
+
+	return response, nil
+}
+
+func (us *UserService) RevokeSession(ctx context.Context, req *pb.RevokeSessionRequest) (*pb.RevokeSessionResponse, error) {
+	userId, ok := ctx.Value(middleware.TokenKey("user_id")).(uint64)
+	if !ok {
+		return nil, status.Errorf(codes.Unauthenticated, "User ID is missing in context")
+	}
+
+	if req.SessionId == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "session ID is required")
+	}
+
+	err := us.sessionRepo.RevokeForUser(ctx, userId, req.SessionId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Errorf(codes.NotFound, "session '%s' not found", req.SessionId)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to revoke session: %v", err)
+	}
+
+	return &pb.RevokeSessionResponse{
+		Success: true,
+	}, nil
+}
+
+// startSession создает новую сессию и возвращает access- и refresh-токены для нее
+func (us *UserService) startSession(ctx context.Context, userID uint64, deviceLabel string) (string, string, error) {
+	refreshToken, err := utils.GenerateRefreshToken()
+	if err != nil {
+		return "", "", err
+	}
+
+	// Если клиент не подписал устройство, используем его User-Agent
+	if deviceLabel == "" {
+		deviceLabel = middleware.UserAgent(ctx)
+	}
+
+	session := &entities.Session{
+		SessionID:        uuid.New().String(),
+		UserID:           userID,
+		RefreshTokenHash: utils.HashToken(refreshToken),
+		DeviceLabel:      deviceLabel,
+		IPAddress:        middleware.ClientIP(ctx),
+		ExpiresAt:        time.Now().Add(utils.RefreshTokenTTL),
+	}
+
+	if err := us.sessionRepo.Create(ctx, session); err != nil {
+		return "", "", err
+	}
+
+	token, err := utils.GenerateToken(userID, session.SessionID)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to generate token: %v", err)
+	}
+
+	return token, refreshToken, nil
+}
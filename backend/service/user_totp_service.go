@@ -0,0 +1,288 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	pb "gRPCWebServer/backend/generated"
+	"gRPCWebServer/backend/middleware"
+	"gRPCWebServer/backend/utils"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const recoveryCodesCount = 10
+
+// Сколько раз можно ввести код по одному challenge-токену; после этого нужно снова войти по паролю
+const maxChallengeAttempts = 5
+
+// EnrollTOTP создает новый секрет TOTP и коды восстановления.
+// 2FA включается только после ConfirmTOTP с верным кодом.
+func (us *UserService) EnrollTOTP(ctx context.Context, req *pb.EnrollTOTPRequest) (*pb.EnrollTOTPResponse, error) {
+	userId, ok := ctx.Value(middleware.TokenKey("user_id")).(uint64)
+	if !ok {
+		return nil, status.Errorf(codes.Unauthenticated, "User ID is missing in context")
+	}
+
+	settings, err := us.totpRepo.GetSettings(ctx, userId)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get 2FA settings: %v", err)
+	}
+
+	if settings != nil && settings.Enabled {
+		return nil, status.Errorf(codes.FailedPrecondition, "two-factor authentication is already enabled")
+	}
+
+	user, err := us.repo.GetByID(ctx, userId)
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "user not found")
+	}
+
+	secret, err := utils.GenerateTOTPSecret()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "%v", err)
+	}
+
+	recoveryCodes, err := utils.GenerateRecoveryCodes(recoveryCodesCount)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "%v", err)
+	}
+
+	recoveryCodeHashes := make([]string, 0, len(recoveryCodes))
+	for _, code := range recoveryCodes {
+		hash, err := utils.HashPassword(utils.NormalizeRecoveryCode(code))
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to hash recovery code: %v", err)
+		}
+		recoveryCodeHashes = append(recoveryCodeHashes, hash)
+	}
+
+	if err := us.totpRepo.SavePending(ctx, userId, secret, recoveryCodeHashes); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to save 2FA settings: %v", err)
+	}
+
+	return &pb.EnrollTOTPResponse{
+		OtpauthUri:    utils.TOTPProvisioningURI(user.Username, secret),
+		Secret:        secret,
+		RecoveryCodes: recoveryCodes,
+	}, nil
+}
+
+// ConfirmTOTP включает 2FA, если код из приложения совпадает с сохраненным секретом
+func (us *UserService) ConfirmTOTP(ctx context.Context, req *pb.ConfirmTOTPRequest) (*pb.ConfirmTOTPResponse, error) {
+	userId, ok := ctx.Value(middleware.TokenKey("user_id")).(uint64)
+	if !ok {
+		return nil, status.Errorf(codes.Unauthenticated, "User ID is missing in context")
+	}
+
+	settings, err := us.totpRepo.GetSettings(ctx, userId)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get 2FA settings: %v", err)
+	}
+
+	if settings == nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "two-factor authentication is not enrolled")
+	}
+
+	if settings.Enabled {
+		return nil, status.Errorf(codes.FailedPrecondition, "two-factor authentication is already enabled")
+	}
+
+	step, valid := utils.ValidateTOTP(settings.Secret, req.Code, time.Now())
+	if !valid {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid code")
+	}
+
+	fresh, err := us.totpRepo.UseStep(ctx, userId, step)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "%v", err)
+	}
+
+	if !fresh {
+		return nil, status.Errorf(codes.InvalidArgument, "code already used")
+	}
+
+	if err := us.totpRepo.Enable(ctx, userId); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to enable 2FA: %v", err)
+	}
+
+	return &pb.ConfirmTOTPResponse{
+		Success: true,
+	}, nil
+}
+
+// DisableTOTP отключает 2FA; требует пароль и код (из приложения или восстановления)
+func (us *UserService) DisableTOTP(ctx context.Context, req *pb.DisableTOTPRequest) (*pb.DisableTOTPResponse, error) {
+	userId, ok := ctx.Value(middleware.TokenKey("user_id")).(uint64)
+	if !ok {
+		return nil, status.Errorf(codes.Unauthenticated, "User ID is missing in context")
+	}
+
+	user, err := us.repo.GetByID(ctx, userId)
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "user not found")
+	}
+
+	match, _, err := utils.VerifyPassword(req.Password, user.PasswordHash)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to verify password: %v", err)
+	}
+
+	if !match {
+		return nil, status.Errorf(codes.Unauthenticated, "incorrect password")
+	}
+
+	valid, err := us.verifySecondFactor(ctx, userId, req.Code)
+	if err != nil {
+		return nil, err
+	}
+
+	if !valid {
+		return nil, status.Errorf(codes.Unauthenticated, "invalid code")
+	}
+
+	if err := us.totpRepo.Disable(ctx, userId); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to disable 2FA: %v", err)
+	}
+
+	return &pb.DisableTOTPResponse{
+		Success: true,
+	}, nil
+}
+
+// VerifySecondFactor обменивает challenge-токен из Login и код второго фактора на сессию
+func (us *UserService) VerifySecondFactor(ctx context.Context, req *pb.VerifySecondFactorRequest) (*pb.VerifySecondFactorResponse, error) {
+	claims, err := utils.ValidateChallengeToken(req.ChallengeToken)
+	if err != nil {
+		return nil, status.Errorf(codes.Unauthenticated, "invalid challenge token: %v", err)
+	}
+
+	allowed, err := us.totpRepo.TakeChallengeAttempt(ctx, claims.Id, claims.UserId, maxChallengeAttempts)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "%v", err)
+	}
+
+	if !allowed {
+		return nil, status.Errorf(codes.Unauthenticated, "challenge token is used, expired or out of attempts")
+	}
+
+	valid, err := us.verifySecondFactor(ctx, claims.UserId, req.Code)
+	if err != nil {
+		return nil, err
+	}
+
+	if !valid {
+		return nil, status.Errorf(codes.Unauthenticated, "invalid code")
+	}
+
+	// Токен одноразовый: параллельный запрос с тем же токеном сессию не получит
+	consumed, err := us.totpRepo.ConsumeChallenge(ctx, claims.Id)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "%v", err)
+	}
+
+	if !consumed {
+		return nil, status.Errorf(codes.Unauthenticated, "challenge token is already used")
+	}
+
+	token, refreshToken, err := us.startSession(ctx, claims.UserId, claims.DeviceLabel)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to start session: %v", err)
+	}
+
+	return &pb.VerifySecondFactorResponse{
+		Token:        token,
+		RefreshToken: refreshToken,
+	}, nil
+}
+
+// verifySecondFactor проверяет код TOTP или одноразовый код восстановления.
+// Принятый код нельзя использовать повторно.
+func (us *UserService) verifySecondFactor(ctx context.Context, userID uint64, code string) (bool, error) {
+	settings, err := us.totpRepo.GetSettings(ctx, userID)
+	if err != nil {
+		return false, status.Errorf(codes.Internal, "failed to get 2FA settings: %v", err)
+	}
+
+	if settings == nil || !settings.Enabled {
+		return false, status.Errorf(codes.FailedPrecondition, "two-factor authentication is not enabled")
+	}
+
+	if step, valid := utils.ValidateTOTP(settings.Secret, code, time.Now()); valid {
+		fresh, err := us.totpRepo.UseStep(ctx, userID, step)
+		if err != nil {
+			return false, status.Errorf(codes.Internal, "%v", err)
+		}
+		return fresh, nil
+	}
+
+	// Неверный шестизначный код не может быть кодом восстановления, и argon2 для него не запускается
+	if utils.IsTOTPCode(code) {
+		return false, nil
+	}
+
+	return us.useRecoveryCode(ctx, userID, code)
+}
+
+func (us *UserService) useRecoveryCode(ctx context.Context, userID uint64, code string) (bool, error) {
+	normalized := utils.NormalizeRecoveryCode(code)
+	if !utils.IsRecoveryCode(normalized) {
+		return false, nil
+	}
+
+	recoveryCodes, err := us.totpRepo.GetUnusedRecoveryCodes(ctx, userID)
+	if err != nil {
+		return false, status.Errorf(codes.Internal, "%v", err)
+	}
+
+	for _, recoveryCode := range recoveryCodes {
+		match, _, err := utils.VerifyPassword(normalized, recoveryCode.CodeHash)
+		if err != nil {
+			return false, status.Errorf(codes.Internal, "failed to verify recovery code: %v", err)
+		}
+		if !match {
+			continue
+		}
+
+		used, err := us.totpRepo.UseRecoveryCode(ctx, recoveryCode.ID)
+		if err != nil {
+			return false, status.Errorf(codes.Internal, "%v", err)
+		}
+		return used, nil
+	}
+
+	return false, nil
+}
+
+// loginChallenge возвращает ответ Login с challenge-токеном, если у пользователя включена 2FA
+func (us *UserService) loginChallenge(ctx context.Context, userID uint64, deviceLabel string) (*pb.LoginResponse, error) {
+	settings, err := us.totpRepo.GetSettings(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get 2FA settings: %v", err)
+	}
+
+	if settings == nil || !settings.Enabled {
+		return nil, nil
+	}
+
+	challengeId, err := utils.GenerateChallengeID()
+	if err != nil {
+		return nil, err
+	}
+
+	expiresAt := time.Now().Add(utils.ChallengeTokenTTL)
+	if err := us.totpRepo.CreateChallenge(ctx, challengeId, userID, expiresAt); err != nil {
+		return nil, err
+	}
+
+	challengeToken, err := utils.GenerateChallengeToken(challengeId, userID, deviceLabel, expiresAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate challenge token: %v", err)
+	}
+
+	return &pb.LoginResponse{
+		SecondFactorRequired: true,
+		ChallengeToken:       challengeToken,
+	}, nil
+}
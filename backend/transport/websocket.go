@@ -2,15 +2,15 @@ package transport
 
 import (
 	"context"
+	"crypto/md5"
 	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
-	"fmt"
 	pb "gRPCWebServer/backend/generated"
+	"hash"
 	"io"
 	"log"
 	"net/http"
-	"os"
-	"path/filepath"
 	"sync"
 	"time"
 
@@ -19,6 +19,7 @@ import (
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 )
 
 // Message представляет общую структуру сообщения
@@ -28,6 +29,19 @@ type Message struct {
 	SenderUsername string `json:"senderUsername,omitempty"`
 	Timestamp      int64  `json:"timestamp,omitempty"`
 
+	// Поля для сообщений чата и отметок о прочтении
+	MessageId   uint64 `json:"messageId,omitempty"`
+	GroupId     uint64 `json:"groupId,omitempty"`
+	ClientId    string `json:"clientId,omitempty"`
+	UnreadCount uint32 `json:"unreadCount,omitempty"`
+	ReplyToId   uint64 `json:"replyToMessageId,omitempty"`
+	TTLSeconds  uint32 `json:"ttlSeconds,omitempty"`
+	DeliverAt   int64  `json:"deliverAt,omitempty"`
+	BeforeId    uint64 `json:"beforeId,omitempty"` // Запрос истории: сообщения с ID меньше указанного
+	// Токены слепого индекса; в JSON передаются строками base64
+	SearchTokens [][]byte `json:"searchTokens,omitempty"`
+	IV           []byte   `json:"iv,omitempty"` // base64
+
 	// Поля для файлов
 	FileId      string `json:"fileId,omitempty"`
 	FileName    string `json:"fileName,omitempty"`
@@ -52,16 +66,18 @@ type Message struct {
 	Error string `json:"error,omitempty"`
 }
 
-// FileUpload представляет информацию о загружаемом файле
+// FileUpload представляет информацию о файле, загружаемом через FileService
 type FileUpload struct {
-	UploadId       string
+	UploadId       string // ID загрузки в FileService
 	FileName       string
 	MimeType       string
 	TotalSize      int64
 	ChatUsername   string
-	FilePath       string
 	ReceivedChunks int32
-	UserId         string
+
+	stream   pb.FileService_UploadFileChunkClient
+	checksum hash.Hash // MD5 переданных данных для FinalizeFileUpload
+	cancel   context.CancelFunc
 }
 
 type WebSocketHandler struct {
@@ -70,20 +86,15 @@ type WebSocketHandler struct {
 	client      pb.ChatServiceClient
 	fileClient  pb.FileServiceClient
 
-	// Для работы с файлами
+	// Для работы с файлами: ID загрузки клиента -> загрузка
 	fileUploads  map[string]*FileUpload
 	uploadsMutex sync.RWMutex
-	baseFilePath string
 
 	// Мьютекс для защиты записи в WebSocket
 	writeMutex sync.Mutex
 }
 
 func NewWebSocketHandler() *WebSocketHandler {
-	// Создаем директорию для временных файлов
-	tempDir := "./storage/temp"
-	os.MkdirAll(tempDir, 0755)
-
 	return &WebSocketHandler{
 		upgrader: websocket.Upgrader{
 			CheckOrigin: func(r *http.Request) bool {
@@ -92,7 +103,6 @@ func NewWebSocketHandler() *WebSocketHandler {
 		},
 		fileUploads:  make(map[string]*FileUpload),
 		uploadsMutex: sync.RWMutex{},
-		baseFilePath: "./storage",
 	}
 }
 
@@ -125,6 +135,10 @@ func (h *WebSocketHandler) Handle(w http.ResponseWriter, r *http.Request) {
 
 	ctx := context.Background()
 	ctx = metadata.AppendToOutgoingContext(ctx, "Authorization", "Bearer "+token)
+	// Браузер не может задать заголовки WebSocket, поэтому устройство передается в параметре запроса
+	if deviceId := r.URL.Query().Get("deviceId"); deviceId != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, "x-device-id", deviceId)
+	}
 
 	stream, err := h.client.Chat(ctx)
 	if err != nil {
@@ -182,33 +196,52 @@ func (h *WebSocketHandler) Handle(w http.ResponseWriter, r *http.Request) {
 			switch message.Type {
 			case "file_upload_init":
 				// Инициализация загрузки файла
-				h.handleFileUploadInit(conn, message, token)
+				h.handleFileUploadInit(ctx, conn, message)
 
 			case "file_chunk":
 				// Обработка чанка файла
-				h.handleFileChunk(conn, message)
+				h.handleFileChunk(ctx, conn, message)
 
 			case "file_download_request":
 				// Запрос на скачивание файла
-				h.handleFileDownload(conn, message)
+				h.handleFileDownload(ctx, conn, message)
+
+			case "history_request":
+				// Страница истории открытого чата
+				h.handleHistoryRequest(ctx, conn, message)
+
+			case "mark_read":
+				// Отметка о прочтении сообщений чата до messageId включительно
+				h.handleMarkRead(ctx, conn, message)
+
+			case "typing_start", "typing_stop", "away", "active":
+				// Служебные сигналы: передаются только тем, кто сейчас в сети, и нигде не сохраняются
+				if err := stream.Send(&pb.ChatMessage{Signal: chatSignals[message.Type]}); err != nil {
+					log.Println("Error sending signal to gRPC stream:", err)
+					return
+				}
 
 			default:
 				// Стандартное текстовое сообщение для чата
-				chatMessage := pb.ChatMessage{
-					Content: message.Content,
+				envelope := &pb.MessageEnvelope{
+					Kind:    pb.MessageKind_KIND_TEXT,
+					Payload: []byte(message.Content),
+					Iv:      message.IV,
 				}
 
-				// Если это файловое сообщение, добавляем метаинформацию о файле в Content
+				// Файловое сообщение: имя, размер и тип файла сервер берет из описания загруженного файла
 				if message.MessageType == "file" && message.FileId != "" {
-					// Создаем специальный префикс для идентификации файлового сообщения
-					fileInfo := fmt.Sprintf("[FILE:%s:%s:%d]%s",
-						message.FileId,
-						message.FileName,
-						message.FileSize,
-						message.Content)
-
-					// Заменяем контент сообщения структурированной информацией о файле
-					chatMessage.Content = fileInfo
+					envelope.Kind = pb.MessageKind_KIND_FILE
+					envelope.Attachments = []*pb.Attachment{{FileId: message.FileId}}
+				}
+
+				chatMessage := pb.ChatMessage{
+					ClientId:         message.ClientId,
+					ReplyToMessageId: message.ReplyToId,
+					TtlSeconds:       message.TTLSeconds,
+					DeliverAt:        message.DeliverAt,
+					SearchTokens:     message.SearchTokens,
+					Envelope:         envelope,
 				}
 
 				if err := stream.Send(&chatMessage); err != nil {
@@ -228,11 +261,9 @@ func (h *WebSocketHandler) Handle(w http.ResponseWriter, r *http.Request) {
 				return
 			}
 
-			messageJSON, err := json.Marshal(map[string]interface{}{
-				"senderUsername": resp.Senderusername,
-				"content":        resp.Content,
-				"timestamp":      resp.Timestamp,
-			})
+			fields := responseFields(resp)
+
+			messageJSON, err := json.Marshal(fields)
 			if err != nil {
 				log.Println("Error marshalling JSON:", err)
 				return
@@ -252,34 +283,177 @@ func (h *WebSocketHandler) Handle(w http.ResponseWriter, r *http.Request) {
 	select {}
 }
 
-// Обработка инициализации загрузки файла
-func (h *WebSocketHandler) handleFileUploadInit(conn *websocket.Conn, message Message, token string) {
-	// Создаем новую загрузку
+// responseFields передает ответ потока Chat клиенту полями JSON
+func responseFields(resp *pb.ChatResponse) map[string]interface{} {
+	fields := map[string]interface{}{
+		"senderUsername":   resp.Senderusername,
+		"timestamp":        resp.Timestamp,
+		"messageId":        resp.MessageId,
+		"groupId":          resp.GroupId,
+		"event":            resp.Event.String(),
+		"clientId":         resp.ClientId,
+		"editedAt":         resp.EditedAt,
+		"deleted":          resp.Deleted,
+		"receiptFrom":      resp.ReceiptFrom,
+		"status":           resp.Status.String(),
+		"replyToMessageId": resp.ReplyToMessageId,
+		"threadRootId":     resp.ThreadRootId,
+		"replyCount":       resp.ReplyCount,
+		"reaction":         resp.Reaction,
+		"expiresAt":        resp.ExpiresAt,
+		"deliverAt":        resp.DeliverAt,
+		"scheduledId":      resp.ScheduledId,
+		"error":            resp.Error,
+	}
+	envelopeFields(fields, resp.Envelope)
+
+	// Обработчики сообщений чата на клиенте рисуют все, что пришло без type,
+	// поэтому без type отправляются только новые сообщения
+	if resp.Event != pb.ChatEventType_MESSAGE {
+		fields["type"] = "chat_event"
+	}
+
+	return fields
+}
+
+// envelopeFields передает содержимое сообщения отдельными полями: тело в content,
+// тип в messageType и вложения в attachments, а первое вложение — еще и в fileId, fileName и fileSize
+func envelopeFields(fields map[string]interface{}, envelope *pb.MessageEnvelope) {
+	if envelope == nil {
+		return
+	}
+
+	fields["content"] = string(envelope.Payload)
+	fields["iv"] = envelope.Iv
+	fields["messageType"] = "text"
+	if envelope.Kind == pb.MessageKind_KIND_FILE {
+		fields["messageType"] = "file"
+	}
+
+	attachments := make([]map[string]interface{}, 0, len(envelope.Attachments))
+	for _, attachment := range envelope.Attachments {
+		attachments = append(attachments, map[string]interface{}{
+			"fileId":   attachment.FileId,
+			"fileName": attachment.FileName,
+			"fileSize": attachment.FileSize,
+			"mimeType": attachment.MimeType,
+		})
+	}
+	fields["attachments"] = attachments
+
+	if len(envelope.Attachments) > 0 {
+		fields["fileId"] = envelope.Attachments[0].FileId
+		fields["fileName"] = envelope.Attachments[0].FileName
+		fields["fileSize"] = envelope.Attachments[0].FileSize
+	}
+}
+
+// Служебные сигналы чата по типу сообщения WebSocket
+var chatSignals = map[string]pb.ChatSignal{
+	"typing_start": pb.ChatSignal_SIGNAL_TYPING_START,
+	"typing_stop":  pb.ChatSignal_SIGNAL_TYPING_STOP,
+	"away":         pb.ChatSignal_SIGNAL_AWAY,
+	"active":       pb.ChatSignal_SIGNAL_ACTIVE,
+}
+
+// Обработка отметки о прочтении
+func (h *WebSocketHandler) handleMarkRead(ctx context.Context, conn *websocket.Conn, message Message) {
+	resp, err := h.client.MarkRead(ctx, &pb.MarkReadRequest{
+		Username:      message.ChatUsername,
+		GroupId:       message.GroupId,
+		UpToMessageId: message.MessageId,
+	})
+	if err != nil {
+		log.Printf("Error marking messages read: %v", err)
+		h.sendError(conn, "mark_read_error", "", "Не удалось отметить сообщения прочитанными")
+		return
+	}
+
+	h.sendMessage(conn, Message{
+		Type:         "mark_read_result",
+		ChatUsername: message.ChatUsername,
+		GroupId:      message.GroupId,
+		MessageId:    message.MessageId,
+		UnreadCount:  resp.UnreadCount,
+	})
+}
+
+// Обработка запроса истории. Поток Chat не присылает старые сообщения при подключении,
+// поэтому клиент загружает их страницами через GetHistory.
+func (h *WebSocketHandler) handleHistoryRequest(ctx context.Context, conn *websocket.Conn, message Message) {
+	resp, err := h.client.GetHistory(ctx, &pb.GetHistoryRequest{
+		Username: message.ChatUsername,
+		GroupId:  message.GroupId,
+		BeforeId: message.BeforeId,
+	})
+	if err != nil {
+		log.Printf("Error getting chat history: %v", err)
+		h.sendMessage(conn, Message{
+			Type:         "history_error",
+			ChatUsername: message.ChatUsername,
+			GroupId:      message.GroupId,
+			BeforeId:     message.BeforeId,
+			Error:        status.Convert(err).Message(),
+		})
+		return
+	}
+
+	messages := make([]map[string]interface{}, 0, len(resp.Messages))
+	for _, historyMessage := range resp.Messages {
+		messages = append(messages, responseFields(historyMessage))
+	}
+
+	h.sendJSON(conn, map[string]interface{}{
+		"type":         "history",
+		"chatUsername": message.ChatUsername,
+		"groupId":      message.GroupId,
+		"beforeId":     message.BeforeId,
+		"hasMore":      resp.HasMore,
+		"messages":     messages,
+	})
+}
+
+// Обработка инициализации загрузки файла. Файл загружается через FileService, поэтому
+// он попадает в таблицу files и доступен участникам чата как вложение.
+func (h *WebSocketHandler) handleFileUploadInit(ctx context.Context, conn *websocket.Conn, message Message) {
+	// Ответы клиенту идут с его ID загрузки
 	uploadId := message.UploadId
 	if uploadId == "" {
 		uploadId = uuid.New().String()
 	}
 
-	// Создаем временный файл
-	tempFilePath := filepath.Join(h.baseFilePath, "temp", uploadId)
-	tempFile, err := os.Create(tempFilePath)
+	initResp, err := h.fileClient.InitFileUpload(ctx, &pb.InitFileUploadRequest{
+		Filename:     message.FileName,
+		MimeType:     message.MimeType,
+		TotalSize:    message.TotalSize,
+		ChatUsername: message.ChatUsername,
+		GroupId:      message.GroupId,
+	})
+	if err != nil {
+		log.Printf("Error initializing file upload: %v", err)
+		h.sendError(conn, "file_upload_error", uploadId, status.Convert(err).Message())
+		return
+	}
+
+	uploadCtx, cancel := context.WithCancel(ctx)
+	chunkStream, err := h.fileClient.UploadFileChunk(uploadCtx)
 	if err != nil {
-		log.Printf("Error creating temp file: %v", err)
-		h.sendError(conn, "file_upload_error", uploadId, "Ошибка создания временного файла")
+		cancel()
+		log.Printf("Error opening file upload stream: %v", err)
+		h.sendError(conn, "file_upload_error", uploadId, "Ошибка загрузки файла")
 		return
 	}
-	tempFile.Close()
 
 	h.uploadsMutex.Lock()
 	h.fileUploads[uploadId] = &FileUpload{
-		UploadId:       uploadId,
-		FileName:       message.FileName,
-		MimeType:       message.MimeType,
-		TotalSize:      message.TotalSize,
-		ChatUsername:   message.ChatUsername,
-		FilePath:       tempFilePath,
-		ReceivedChunks: 0,
-		UserId:         token, // используем токен для идентификации пользователя
+		UploadId:     initResp.UploadId,
+		FileName:     message.FileName,
+		MimeType:     message.MimeType,
+		TotalSize:    message.TotalSize,
+		ChatUsername: message.ChatUsername,
+		stream:       chunkStream,
+		checksum:     md5.New(),
+		cancel:       cancel,
 	}
 	h.uploadsMutex.Unlock()
 
@@ -292,7 +466,7 @@ func (h *WebSocketHandler) handleFileUploadInit(conn *websocket.Conn, message Me
 }
 
 // Обработка чанка файла
-func (h *WebSocketHandler) handleFileChunk(conn *websocket.Conn, message Message) {
+func (h *WebSocketHandler) handleFileChunk(ctx context.Context, conn *websocket.Conn, message Message) {
 	uploadId := message.UploadId
 
 	h.uploadsMutex.RLock()
@@ -304,15 +478,6 @@ func (h *WebSocketHandler) handleFileChunk(conn *websocket.Conn, message Message
 		return
 	}
 
-	// Открываем файл для добавления данных
-	file, err := os.OpenFile(upload.FilePath, os.O_WRONLY|os.O_APPEND, 0644)
-	if err != nil {
-		log.Printf("Error opening temp file: %v", err)
-		h.sendError(conn, "file_upload_error", uploadId, "Ошибка открытия временного файла")
-		return
-	}
-	defer file.Close()
-
 	// Данные для записи в файл
 	var dataToWrite []byte
 
@@ -322,15 +487,13 @@ func (h *WebSocketHandler) handleFileChunk(conn *websocket.Conn, message Message
 		decoded, err := base64.StdEncoding.DecodeString(message.DataString)
 		if err != nil {
 			log.Printf("Error decoding base64 data: %v", err)
-			h.sendError(conn, "file_upload_error", uploadId, "Ошибка декодирования данных")
+			h.abortFileUpload(conn, uploadId, "Ошибка декодирования данных")
 			return
 		}
 		dataToWrite = decoded
-		log.Printf("Received base64 chunk for upload %s, size after decode: %d bytes", uploadId, len(dataToWrite))
 	} else {
 		// Совместимость с предыдущей версией - используем бинарные данные
 		dataToWrite = message.Data
-		log.Printf("Received binary chunk for upload %s, size: %d bytes", uploadId, len(dataToWrite))
 	}
 
 	// Проверяем, есть ли данные для записи
@@ -338,17 +501,23 @@ func (h *WebSocketHandler) handleFileChunk(conn *websocket.Conn, message Message
 		log.Printf("Warning: empty chunk data received for upload %s", uploadId)
 	}
 
-	// Записываем данные
-	if _, err := file.Write(dataToWrite); err != nil {
-		log.Printf("Error writing to temp file: %v", err)
-		h.sendError(conn, "file_upload_error", uploadId, "Ошибка записи данных")
+	// Передаем данные в FileService
+	err := upload.stream.Send(&pb.FileChunk{
+		UploadId:   upload.UploadId,
+		ChunkIndex: message.ChunkIndex,
+		Data:       dataToWrite,
+	})
+	if err != nil {
+		log.Printf("Error sending file chunk: %v", err)
+		h.abortFileUpload(conn, uploadId, "Ошибка записи данных")
 		return
 	}
+	upload.checksum.Write(dataToWrite)
 
 	// Обновляем счетчик полученных чанков
 	h.uploadsMutex.Lock()
-	h.fileUploads[uploadId].ReceivedChunks++
-	receivedChunks := h.fileUploads[uploadId].ReceivedChunks
+	upload.ReceivedChunks++
+	receivedChunks := upload.ReceivedChunks
 	h.uploadsMutex.Unlock()
 
 	// Отправляем подтверждение получения чанка
@@ -362,209 +531,149 @@ func (h *WebSocketHandler) handleFileChunk(conn *websocket.Conn, message Message
 
 	// Если это последний чанк, финализируем загрузку
 	if message.IsLastChunk {
-		h.finalizeFileUpload(conn, uploadId)
+		h.finalizeFileUpload(ctx, conn, uploadId)
 	}
 }
 
-// Финализация загрузки файла
-func (h *WebSocketHandler) finalizeFileUpload(conn *websocket.Conn, uploadId string) {
-	h.uploadsMutex.RLock()
+// Финализация загрузки файла: FileService проверяет размер и контрольную сумму и создает запись о файле
+func (h *WebSocketHandler) finalizeFileUpload(ctx context.Context, conn *websocket.Conn, uploadId string) {
+	h.uploadsMutex.Lock()
 	upload, exists := h.fileUploads[uploadId]
-	h.uploadsMutex.RUnlock()
+	delete(h.fileUploads, uploadId)
+	h.uploadsMutex.Unlock()
 
 	if !exists {
 		h.sendError(conn, "file_upload_error", uploadId, "Загрузка не найдена")
 		return
 	}
+	defer upload.cancel()
 
-	// Проверяем размер файла
-	fileStats, err := os.Stat(upload.FilePath)
-	if err != nil {
-		log.Printf("Error checking file stats: %v", err)
-	} else {
-		log.Printf("Temp file size: %d bytes, expected size: %d bytes", fileStats.Size(), upload.TotalSize)
-		if fileStats.Size() == 0 {
-			log.Printf("WARNING: File is empty! This indicates a problem with upload.")
-		}
-	}
-
-	// Перемещаем файл из временной директории в постоянную
-	finalDir := filepath.Join(h.baseFilePath, "files")
-	os.MkdirAll(finalDir, 0755)
-
-	fileId := uuid.New().String()
-	finalPath := filepath.Join(finalDir, fileId)
-
-	if err := os.Rename(upload.FilePath, finalPath); err != nil {
-		log.Printf("Error moving file: %v", err)
-		h.sendError(conn, "file_upload_error", uploadId, "Ошибка перемещения файла")
+	if _, err := upload.stream.CloseAndRecv(); err != nil {
+		log.Printf("Error closing file upload stream: %v", err)
+		h.sendError(conn, "file_upload_error", uploadId, status.Convert(err).Message())
 		return
 	}
 
-	// Создаем и сохраняем метаданные файла
-	metaPath := filepath.Join(finalDir, fileId+".meta")
-	metaFile, err := os.Create(metaPath)
+	finalizeResp, err := h.fileClient.FinalizeFileUpload(ctx, &pb.FinalizeFileUploadRequest{
+		UploadId: upload.UploadId,
+		Checksum: hex.EncodeToString(upload.checksum.Sum(nil)),
+	})
 	if err != nil {
-		log.Printf("Error creating metadata file: %v", err)
-	} else {
-		defer metaFile.Close()
-		metaData := struct {
-			FileName string `json:"fileName"`
-			MimeType string `json:"mimeType"`
-			FileSize int64  `json:"fileSize"`
-		}{
-			FileName: upload.FileName,
-			MimeType: upload.MimeType,
-			FileSize: upload.TotalSize,
-		}
-
-		metaBytes, err := json.Marshal(metaData)
-		if err != nil {
-			log.Printf("Error marshaling metadata: %v", err)
-		} else {
-			if _, err := metaFile.Write(metaBytes); err != nil {
-				log.Printf("Error writing metadata: %v", err)
-			}
-		}
+		log.Printf("Error finalizing file upload: %v", err)
+		h.sendError(conn, "file_upload_error", uploadId, status.Convert(err).Message())
+		return
 	}
 
 	// Отправляем сообщение о завершении загрузки
 	response := Message{
 		Type:     "file_upload_complete",
 		UploadId: uploadId,
-		FileId:   fileId,
+		FileId:   finalizeResp.FileId,
 		FileName: upload.FileName, // Важно: включаем имя файла в ответ
 	}
 	h.sendMessage(conn, response)
 
-	// Удаляем информацию о загрузке
-	h.uploadsMutex.Lock()
-	delete(h.fileUploads, uploadId)
-	h.uploadsMutex.Unlock()
-
 	// Логируем успешную загрузку
 	log.Printf("File uploaded successfully: %s, size: %d, type: %s",
 		upload.FileName, upload.TotalSize, upload.MimeType)
 }
 
-// Обработка запроса на скачивание файла
-func (h *WebSocketHandler) handleFileDownload(conn *websocket.Conn, message Message) {
-	fileId := message.FileId
-
-	// Получаем метаданные файла, если они есть
-	filePath := filepath.Join(h.baseFilePath, "files", fileId)
-	metaPath := filepath.Join(h.baseFilePath, "files", fileId+".meta")
+// abortFileUpload прерывает загрузку и сообщает клиенту об ошибке
+func (h *WebSocketHandler) abortFileUpload(conn *websocket.Conn, uploadId string, errorMessage string) {
+	h.uploadsMutex.Lock()
+	upload, exists := h.fileUploads[uploadId]
+	delete(h.fileUploads, uploadId)
+	h.uploadsMutex.Unlock()
 
-	// Инициализируем информацию о файле со значениями по умолчанию
-	fileInfo := Message{
-		Type:     "file_info",
-		FileId:   fileId,
-		FileName: "downloaded_file", // Значение по умолчанию
-		MimeType: "application/octet-stream",
-		FileSize: 0,
+	if exists {
+		upload.cancel()
 	}
+	h.sendError(conn, "file_upload_error", uploadId, errorMessage)
+}
 
-	// Пробуем загрузить метаданные
-	if metaBytes, err := os.ReadFile(metaPath); err == nil {
-		var metaData struct {
-			FileName string `json:"fileName"`
-			MimeType string `json:"mimeType"`
-			FileSize int64  `json:"fileSize"`
-		}
-
-		if err := json.Unmarshal(metaBytes, &metaData); err == nil {
-			fileInfo.FileName = metaData.FileName
-			fileInfo.MimeType = metaData.MimeType
-			fileInfo.FileSize = metaData.FileSize
-		}
-	} else {
-		// Если метаданные не найдены, используем значение по умолчанию
-		fileInfo.FileName = filepath.Base(filePath)
-	}
+// Обработка запроса на скачивание файла. Права доступа к файлу проверяет FileService.
+func (h *WebSocketHandler) handleFileDownload(ctx context.Context, conn *websocket.Conn, message Message) {
+	fileId := message.FileId
 
-	// Проверяем существование файла
-	fileStats, err := os.Stat(filePath)
+	info, err := h.fileClient.GetFileInfo(ctx, &pb.GetFileInfoRequest{FileId: fileId})
 	if err != nil {
-		// Если файл не найден, проверяем другие возможные расширения
-		found := false
-		possibleExtensions := []string{".jpg", ".jpeg", ".png", ".gif", ".pdf", ".txt", ".doc", ".docx", ".xls", ".xlsx", ".zip"}
-
-		for _, ext := range possibleExtensions {
-			possiblePath := filepath.Join(h.baseFilePath, "files", fileId+ext)
-			if stats, err := os.Stat(possiblePath); err == nil {
-				filePath = possiblePath
-				fileStats = stats
-				found = true
-				break
-			}
-		}
-
-		if !found {
-			h.sendError(conn, "file_download_error", fileId, "Файл не найден")
-			return
-		}
+		log.Printf("Error getting file info: %v", err)
+		h.sendError(conn, "file_download_error", fileId, status.Convert(err).Message())
+		return
 	}
 
-	// Используем размер из статистики файла
-	fileInfo.FileSize = fileStats.Size()
-	h.sendMessage(conn, fileInfo)
-
-	// Отправляем файл по частям
-	file, err := os.Open(filePath)
+	h.sendMessage(conn, Message{
+		Type:     "file_info",
+		FileId:   fileId,
+		FileName: info.Filename,
+		MimeType: info.MimeType,
+		FileSize: info.Size,
+	})
+
+	downloadCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	chunkStream, err := h.fileClient.DownloadFile(downloadCtx, &pb.DownloadFileRequest{
+		FileId:    fileId,
+		ChunkSize: 64 * 1024, // 64 КБ чанки
+	})
 	if err != nil {
+		log.Printf("Error starting file download: %v", err)
 		h.sendError(conn, "file_download_error", fileId, "Ошибка открытия файла")
 		return
 	}
-	defer file.Close()
 
-	buffer := make([]byte, 64*1024) // 64 КБ чанки
+	// Чанк отправляется клиенту, когда получен следующий: так известно, какой из них последний
+	var pending []byte
 	chunkIndex := int32(0)
-
 	for {
-		bytesRead, err := file.Read(buffer)
+		chunk, err := chunkStream.Recv()
 		if err == io.EOF {
 			break
 		}
 		if err != nil {
-			h.sendError(conn, "file_download_error", fileId, "Ошибка чтения файла")
+			log.Printf("Error receiving file chunk: %v", err)
+			h.sendError(conn, "file_download_error", fileId, status.Convert(err).Message())
 			return
 		}
 
-		// Определяем, последний ли это чанк
-		isLastChunk := bytesRead < len(buffer)
-
-		// Логируем информацию о чанке для отладки
-		log.Printf("Sending chunk %d for file %s, size: %d bytes", chunkIndex, fileId, bytesRead)
+		if pending != nil {
+			h.sendFileChunk(conn, fileId, chunkIndex, pending, false)
+			chunkIndex++
 
-		// Кодируем данные в base64 для безопасной передачи через JSON
-		base64Data := base64.StdEncoding.EncodeToString(buffer[:bytesRead])
-
-		// Отправляем чанк
-		chunk := Message{
-			Type:        "file_chunk",
-			FileId:      fileId,
-			ChunkIndex:  chunkIndex,
-			DataString:  base64Data,
-			Encoding:    "base64",
-			IsLastChunk: isLastChunk,
+			// Небольшая задержка, чтобы не перегружать соединение
+			time.Sleep(20 * time.Millisecond)
 		}
-		h.sendMessage(conn, chunk)
-
-		chunkIndex++
-
-		// Небольшая задержка, чтобы не перегружать соединение
-		time.Sleep(20 * time.Millisecond)
+		pending = chunk.Data
 	}
 
-	log.Printf("File %s download complete, sent %d chunks", fileId, chunkIndex)
+	h.sendFileChunk(conn, fileId, chunkIndex, pending, true)
+	log.Printf("File %s download complete, sent %d chunks", fileId, chunkIndex+1)
+}
+
+// sendFileChunk отправляет клиенту чанк скачиваемого файла в base64
+func (h *WebSocketHandler) sendFileChunk(conn *websocket.Conn, fileId string, chunkIndex int32, data []byte, isLastChunk bool) {
+	h.sendMessage(conn, Message{
+		Type:        "file_chunk",
+		FileId:      fileId,
+		ChunkIndex:  chunkIndex,
+		DataString:  base64.StdEncoding.EncodeToString(data),
+		Encoding:    "base64",
+		IsLastChunk: isLastChunk,
+	})
 }
 
 // Отправка сообщения клиенту
 func (h *WebSocketHandler) sendMessage(conn *websocket.Conn, message Message) {
+	h.sendJSON(conn, message)
+}
+
+// Отправка клиенту произвольного JSON, например страницы истории
+func (h *WebSocketHandler) sendJSON(conn *websocket.Conn, value interface{}) {
 	h.writeMutex.Lock()
 	defer h.writeMutex.Unlock()
 
-	messageJSON, err := json.Marshal(message)
+	messageJSON, err := json.Marshal(value)
 	if err != nil {
 		log.Printf("Error marshalling message: %v", err)
 		return
@@ -2,30 +2,135 @@ package utils
 
 import (
 	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
 	"encoding/base64"
+	"encoding/hex"
+	"errors"
 	"fmt"
+	"strings"
 
 	"golang.org/x/crypto/argon2"
 )
 
+// Argon2Params задает стоимость хеширования паролей argon2id
+type Argon2Params struct {
+	Time    uint32
+	Memory  uint32 // в КиБ
+	Threads uint8
+	KeyLen  uint32
+	SaltLen uint32
+}
+
+var argon2Params = Argon2Params{
+	Time:    1,
+	Memory:  64 * 1024,
+	Threads: 1,
+	KeyLen:  32,
+	SaltLen: 16,
+}
+
+// SetArgon2Params задает параметры для новых хешей; вызывается один раз при старте сервера.
+// Хеши со старыми параметрами перехешируются при следующем входе.
+func SetArgon2Params(params Argon2Params) {
+	argon2Params = params
+}
+
+// HashPassword возвращает хеш пароля в формате PHC:
+// $argon2id$v=19$m=65536,t=1,p=1$<соль>$<хеш>
 func HashPassword(password string) (string, error) {
-	salt, err := GenerateSalt(16)
+	salt := make([]byte, argon2Params.SaltLen)
+	if _, err := rand.Read(salt); err != nil {
+		return "", fmt.Errorf("failed to generate salt: %v", err)
+	}
+
+	hash := argon2.IDKey([]byte(password), salt, argon2Params.Time, argon2Params.Memory, argon2Params.Threads, argon2Params.KeyLen)
+
+	return fmt.Sprintf("$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2.Version,
+		argon2Params.Memory, argon2Params.Time, argon2Params.Threads,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(hash),
+	), nil
+}
+
+// VerifyPassword сверяет пароль с хешем. needsRehash сообщает, что хеш создан
+// с устаревшими параметрами или в старом формате "соль:хеш" и его стоит пересчитать.
+func VerifyPassword(password, encodedHash string) (match bool, needsRehash bool, err error) {
+	if !strings.HasPrefix(encodedHash, "$") {
+		match, err = verifyLegacyPassword(password, encodedHash)
+		return match, true, err
+	}
+
+	params, salt, storedHash, err := decodePHCHash(encodedHash)
+	if err != nil {
+		return false, false, err
+	}
+
+	hash := argon2.IDKey([]byte(password), salt, params.Time, params.Memory, params.Threads, uint32(len(storedHash)))
+	if subtle.ConstantTimeCompare(storedHash, hash) != 1 {
+		return false, false, nil
+	}
+
+	needsRehash = params.Time < argon2Params.Time ||
+		params.Memory < argon2Params.Memory ||
+		params.Threads < argon2Params.Threads ||
+		uint32(len(storedHash)) < argon2Params.KeyLen ||
+		uint32(len(salt)) < argon2Params.SaltLen
+
+	return true, needsRehash, nil
+}
+
+func decodePHCHash(encodedHash string) (Argon2Params, []byte, []byte, error) {
+	var params Argon2Params
+
+	parts := strings.Split(encodedHash, "$")
+	if len(parts) != 6 || parts[1] != "argon2id" {
+		return params, nil, nil, errors.New("invalid hash format")
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil {
+		return params, nil, nil, fmt.Errorf("invalid hash version: %v", err)
+	}
+	if version != argon2.Version {
+		return params, nil, nil, fmt.Errorf("unsupported argon2 version %d", version)
+	}
+
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &params.Memory, &params.Time, &params.Threads); err != nil {
+		return params, nil, nil, fmt.Errorf("invalid hash parameters: %v", err)
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
 	if err != nil {
-		return "", err
+		return params, nil, nil, fmt.Errorf("invalid hash salt: %v", err)
 	}
 
-	timeCost := uint32(1)
-	memoryCost := uint32(64 * 1024)
-	parallelism := uint8(1)
-	hashLength := uint32(32)
+	hash, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil {
+		return params, nil, nil, fmt.Errorf("invalid hash value: %v", err)
+	}
 
-	hash := argon2.IDKey([]byte(password), []byte(salt), timeCost, memoryCost, parallelism, hashLength)
+	params.SaltLen = uint32(len(salt))
+	params.KeyLen = uint32(len(hash))
 
-	encodedHash := base64.StdEncoding.EncodeToString(hash)
+	return params, salt, hash, nil
+}
 
-	hashString := fmt.Sprintf("%s:%s", salt, encodedHash)
+// verifyLegacyPassword проверяет хеш старого формата "соль:хеш" с фиксированными параметрами
+func verifyLegacyPassword(password, encodedHash string) (bool, error) {
+	parts := strings.Split(encodedHash, ":")
+	if len(parts) != 2 {
+		return false, errors.New("invalid hash format")
+	}
 
-	return hashString, nil
+	salt := parts[0]
+	storedHash := parts[1]
+
+	hash := argon2.IDKey([]byte(password), []byte(salt), 1, 64*1024, 1, 32)
+	hashStr := base64.StdEncoding.EncodeToString(hash)
+
+	return subtle.ConstantTimeCompare([]byte(storedHash), []byte(hashStr)) == 1, nil
 }
 
 func GenerateSalt(length int) (string, error) {
@@ -36,3 +141,9 @@ func GenerateSalt(length int) (string, error) {
 	}
 	return base64.StdEncoding.EncodeToString(salt), nil
 }
+
+// HashToken возвращает SHA-256 токена в hex, чтобы не хранить токены в открытом виде
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
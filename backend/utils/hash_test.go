@@ -0,0 +1,143 @@
+package utils
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"golang.org/x/crypto/argon2"
+)
+
+// Дешевые параметры, чтобы тесты не тратили 64 МиБ на каждый хеш
+var testArgon2Params = Argon2Params{
+	Time:    1,
+	Memory:  1024,
+	Threads: 1,
+	KeyLen:  32,
+	SaltLen: 16,
+}
+
+func withArgon2Params(t *testing.T, params Argon2Params) {
+	t.Helper()
+
+	previous := argon2Params
+	SetArgon2Params(params)
+	t.Cleanup(func() { SetArgon2Params(previous) })
+}
+
+func TestHashPasswordFormat(t *testing.T) {
+	withArgon2Params(t, testArgon2Params)
+
+	hash, err := HashPassword("correct horse")
+	if err != nil {
+		t.Fatalf("HashPassword: %v", err)
+	}
+
+	if !strings.HasPrefix(hash, "$argon2id$v=19$m=1024,t=1,p=1$") {
+		t.Errorf("unexpected PHC prefix in %q", hash)
+	}
+
+	params, salt, key, err := decodePHCHash(hash)
+	if err != nil {
+		t.Fatalf("decodePHCHash: %v", err)
+	}
+
+	if params.Memory != 1024 || params.Time != 1 || params.Threads != 1 {
+		t.Errorf("decoded params = %+v", params)
+	}
+	if len(salt) != 16 || len(key) != 32 {
+		t.Errorf("salt length %d, key length %d; want 16 and 32", len(salt), len(key))
+	}
+}
+
+func TestDecodePHCHash(t *testing.T) {
+	salt := base64.RawStdEncoding.EncodeToString([]byte("0123456789abcdef"))
+	key := base64.RawStdEncoding.EncodeToString(make([]byte, 32))
+
+	tests := []struct {
+		name    string
+		hash    string
+		wantErr bool
+	}{
+		{"valid", "$argon2id$v=19$m=1024,t=2,p=1$" + salt + "$" + key, false},
+		{"argon2i", "$argon2i$v=19$m=1024,t=2,p=1$" + salt + "$" + key, true},
+		{"old version", "$argon2id$v=16$m=1024,t=2,p=1$" + salt + "$" + key, true},
+		{"missing parameters", "$argon2id$v=19$" + salt + "$" + key, true},
+		{"malformed parameters", "$argon2id$v=19$m=x,t=2,p=1$" + salt + "$" + key, true},
+		{"malformed salt", "$argon2id$v=19$m=1024,t=2,p=1$!!!$" + key, true},
+		{"malformed key", "$argon2id$v=19$m=1024,t=2,p=1$" + salt + "$!!!", true},
+		{"empty", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params, _, _, err := decodePHCHash(tt.hash)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("decodePHCHash error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if !tt.wantErr && (params.Memory != 1024 || params.Time != 2 || params.Threads != 1 || params.KeyLen != 32 || params.SaltLen != 16) {
+				t.Errorf("decoded params = %+v", params)
+			}
+		})
+	}
+}
+
+func TestVerifyPassword(t *testing.T) {
+	withArgon2Params(t, testArgon2Params)
+
+	hash, err := HashPassword("correct horse")
+	if err != nil {
+		t.Fatalf("HashPassword: %v", err)
+	}
+
+	legacy := legacyHash(t, "correct horse")
+
+	tests := []struct {
+		name       string
+		password   string
+		hash       string
+		params     Argon2Params
+		wantMatch  bool
+		wantRehash bool
+		wantErr    bool
+	}{
+		{"matching password", "correct horse", hash, testArgon2Params, true, false, false},
+		{"wrong password", "battery staple", hash, testArgon2Params, false, false, false},
+		{"stronger params requested", "correct horse", hash, Argon2Params{Time: 2, Memory: 1024, Threads: 1, KeyLen: 32, SaltLen: 16}, true, true, false},
+		{"more memory requested", "correct horse", hash, Argon2Params{Time: 1, Memory: 2048, Threads: 1, KeyLen: 32, SaltLen: 16}, true, true, false},
+		{"legacy hash", "correct horse", legacy, testArgon2Params, true, true, false},
+		{"legacy hash wrong password", "battery staple", legacy, testArgon2Params, false, true, false},
+		{"malformed legacy hash", "correct horse", "no-separator", testArgon2Params, false, true, true},
+		{"malformed PHC hash", "correct horse", "$argon2id$broken", testArgon2Params, false, false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			withArgon2Params(t, tt.params)
+
+			match, rehash, err := VerifyPassword(tt.password, tt.hash)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("VerifyPassword error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if match != tt.wantMatch || rehash != tt.wantRehash {
+				t.Errorf("VerifyPassword = (%v, %v), want (%v, %v)", match, rehash, tt.wantMatch, tt.wantRehash)
+			}
+		})
+	}
+}
+
+// legacyHash строит хеш старого формата "соль:хеш"
+func legacyHash(t *testing.T, password string) string {
+	t.Helper()
+
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		t.Fatalf("failed to generate salt: %v", err)
+	}
+	salt := base64.StdEncoding.EncodeToString(buf)
+
+	hash := argon2.IDKey([]byte(password), []byte(salt), 1, 64*1024, 1, 32)
+	return salt + ":" + base64.StdEncoding.EncodeToString(hash)
+}
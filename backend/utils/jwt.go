@@ -1,53 +1,160 @@
 package utils
 
 import (
+	"crypto/rand"
+	"encoding/base64"
 	"errors"
+	"fmt"
 	"time"
 
 	"github.com/dgrijalva/jwt-go"
 )
 
-var jwtSecret = []byte("secure-key")
+const (
+	// Access-токен короткий: веб-клиент обновляет его через RefreshToken до истечения
+	AccessTokenTTL    = 15 * time.Minute
+	RefreshTokenTTL   = 7 * 24 * time.Hour
+	ChallengeTokenTTL = 5 * time.Minute
+)
+
+// challengePurpose отличает токен второго фактора от access-токена
+const challengePurpose = "2fa"
 
 type Claims struct {
-	UserId uint64 `json:"used_id"`
+	UserId    uint64 `json:"used_id"`
+	SessionId string `json:"sid"`
+	jwt.StandardClaims
+}
+
+// ChallengeClaims — утверждения короткоживущего токена, выдаваемого после проверки пароля,
+// когда у пользователя включена двухфакторная аутентификация
+type ChallengeClaims struct {
+	UserId      uint64 `json:"used_id"`
+	DeviceLabel string `json:"device,omitempty"`
+	Purpose     string `json:"purpose"`
 	jwt.StandardClaims
 }
 
-func GenerateToken(userId uint64) (string, error) {
-	expirationTime := time.Now().Add(1 * time.Hour)
+func GenerateToken(userId uint64, sessionId string) (string, error) {
+	expirationTime := time.Now().Add(AccessTokenTTL)
 
 	claims := &Claims{
-		UserId: userId,
+		UserId:    userId,
+		SessionId: sessionId,
 		StandardClaims: jwt.StandardClaims{
 			ExpiresAt: expirationTime.Unix(),
 			IssuedAt:  time.Now().Unix(),
 		},
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return signClaims(claims)
+}
 
-	tokenString, err := token.SignedString(jwtSecret)
-	if err != nil {
-		return "", err
+// GenerateChallengeToken выдает токен, который обменивается на сессию в VerifySecondFactor.
+// challengeId попадает в jti и связывает токен с его записью на сервере.
+func GenerateChallengeToken(challengeId string, userId uint64, deviceLabel string, expiresAt time.Time) (string, error) {
+	claims := &ChallengeClaims{
+		UserId:      userId,
+		DeviceLabel: deviceLabel,
+		Purpose:     challengePurpose,
+		StandardClaims: jwt.StandardClaims{
+			Id:        challengeId,
+			ExpiresAt: expiresAt.Unix(),
+			IssuedAt:  time.Now().Unix(),
+		},
+	}
+
+	return signClaims(claims)
+}
+
+func signClaims(claims jwt.Claims) (string, error) {
+	active, ok := keyring.keys[keyring.activeKid]
+	if !ok {
+		return "", errors.New("JWT signing keys are not configured")
 	}
 
-	return tokenString, err
+	token := jwt.NewWithClaims(active.method, claims)
+	token.Header["kid"] = active.kid
+
+	return token.SignedString(active.signKey)
+}
+
+// GenerateRefreshToken возвращает случайный непрозрачный refresh-токен
+func GenerateRefreshToken() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate refresh token: %v", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+// GenerateChallengeID возвращает случайный идентификатор challenge-токена
+func GenerateChallengeID() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate challenge id: %v", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
 }
 
 func ValidateToken(tokenStr string) (*Claims, error) {
 	claims := &Claims{}
+	if err := parseClaims(tokenStr, claims); err != nil {
+		return nil, err
+	}
+
+	if claims.SessionId == "" {
+		return nil, errors.New("token is not bound to a session")
+	}
+
+	return claims, nil
+}
+
+// ValidateChallengeToken проверяет токен второго фактора
+func ValidateChallengeToken(tokenStr string) (*ChallengeClaims, error) {
+	claims := &ChallengeClaims{}
+	if err := parseClaims(tokenStr, claims); err != nil {
+		return nil, err
+	}
+
+	if claims.Purpose != challengePurpose {
+		return nil, errors.New("not a challenge token")
+	}
+
+	if claims.Id == "" {
+		return nil, errors.New("challenge token has no id")
+	}
+
+	return claims, nil
+}
+
+func parseClaims(tokenStr string, claims jwt.Claims) error {
 	token, err := jwt.ParseWithClaims(tokenStr, claims, func(token *jwt.Token) (interface{}, error) {
-		return jwtSecret, nil
+		kid, _ := token.Header["kid"].(string)
+		if kid == "" {
+			kid = DefaultKeyID
+		}
+
+		key, ok := keyring.keys[kid]
+		if !ok {
+			return nil, fmt.Errorf("unknown signing key %q", kid)
+		}
+
+		// Алгоритм задается ключом, а не заголовком токена
+		if token.Method.Alg() != key.method.Alg() {
+			return nil, fmt.Errorf("unexpected signing method %s for key %q", token.Method.Alg(), kid)
+		}
+
+		return key.verifyKey, nil
 	})
 
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	if !token.Valid {
-		return nil, errors.New("invalid token")
+		return errors.New("invalid token")
 	}
 
-	return claims, nil
+	return nil
 }
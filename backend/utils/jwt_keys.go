@@ -0,0 +1,320 @@
+package utils
+
+import (
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"sort"
+
+	"github.com/dgrijalva/jwt-go"
+)
+
+// DefaultKeyID используется для токенов без заголовка kid и для ключа по умолчанию
+const DefaultKeyID = "default"
+
+// KeyConfig описывает один ключ в конфигурации.
+// Для HS256 задается secret (или secret_file), для RS256/EdDSA — PEM-файлы.
+// Ключ только с public_key_file годится лишь для проверки подписи (например, после ротации).
+type KeyConfig struct {
+	Kid            string `json:"kid"`
+	Alg            string `json:"alg"`
+	Secret         string `json:"secret,omitempty"`
+	SecretFile     string `json:"secret_file,omitempty"`
+	PrivateKeyFile string `json:"private_key_file,omitempty"`
+	PublicKeyFile  string `json:"public_key_file,omitempty"`
+}
+
+// KeyringConfig описывает набор ключей: active_kid подписывает новые токены,
+// остальные ключи принимаются при проверке
+type KeyringConfig struct {
+	ActiveKid string      `json:"active_kid"`
+	Keys      []KeyConfig `json:"keys"`
+}
+
+type signingKey struct {
+	kid       string
+	method    jwt.SigningMethod
+	signKey   interface{}
+	verifyKey interface{}
+}
+
+// Keyring хранит ключи подписи и проверки JWT
+type Keyring struct {
+	activeKid string
+	keys      map[string]*signingKey
+}
+
+// До вызова SetKeyring ключей нет, и токены не выдаются
+var keyring = &Keyring{keys: make(map[string]*signingKey)}
+
+// DevelopmentKeyring возвращает общеизвестный HS256-ключ для локальной разработки
+func DevelopmentKeyring() *Keyring {
+	k, err := NewKeyring(KeyringConfig{
+		ActiveKid: DefaultKeyID,
+		Keys:      []KeyConfig{{Kid: DefaultKeyID, Alg: "HS256", Secret: "secure-key"}},
+	})
+	if err != nil {
+		panic(err)
+	}
+	return k
+}
+
+// SetKeyring заменяет набор ключей; вызывается один раз при старте сервера
+func SetKeyring(k *Keyring) {
+	keyring = k
+}
+
+// LoadKeyringFile читает конфигурацию ключей из JSON-файла
+func LoadKeyringFile(path string) (*Keyring, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read keyring config: %v", err)
+	}
+
+	var cfg KeyringConfig
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse keyring config: %v", err)
+	}
+
+	return NewKeyring(cfg)
+}
+
+// NewKeyring создает набор ключей по конфигурации
+func NewKeyring(cfg KeyringConfig) (*Keyring, error) {
+	k := &Keyring{
+		activeKid: cfg.ActiveKid,
+		keys:      make(map[string]*signingKey),
+	}
+
+	for _, kc := range cfg.Keys {
+		if kc.Kid == "" {
+			return nil, errors.New("key without kid")
+		}
+		if _, exists := k.keys[kc.Kid]; exists {
+			return nil, fmt.Errorf("duplicate kid %q", kc.Kid)
+		}
+
+		key, err := loadKey(kc)
+		if err != nil {
+			return nil, fmt.Errorf("key %q: %v", kc.Kid, err)
+		}
+		k.keys[kc.Kid] = key
+	}
+
+	active, ok := k.keys[cfg.ActiveKid]
+	if !ok {
+		return nil, fmt.Errorf("active key %q is not configured", cfg.ActiveKid)
+	}
+	if active.signKey == nil {
+		return nil, fmt.Errorf("active key %q has no private key", cfg.ActiveKid)
+	}
+
+	return k, nil
+}
+
+func loadKey(kc KeyConfig) (*signingKey, error) {
+	key := &signingKey{kid: kc.Kid}
+
+	switch kc.Alg {
+	case "HS256":
+		secret := []byte(kc.Secret)
+		if kc.SecretFile != "" {
+			data, err := os.ReadFile(kc.SecretFile)
+			if err != nil {
+				return nil, err
+			}
+			secret = data
+		}
+		if len(secret) == 0 {
+			return nil, errors.New("empty HMAC secret")
+		}
+		key.method = jwt.SigningMethodHS256
+		key.signKey = secret
+		key.verifyKey = secret
+
+	case "RS256":
+		key.method = jwt.SigningMethodRS256
+		if kc.PrivateKeyFile != "" {
+			data, err := os.ReadFile(kc.PrivateKeyFile)
+			if err != nil {
+				return nil, err
+			}
+			private, err := jwt.ParseRSAPrivateKeyFromPEM(data)
+			if err != nil {
+				return nil, err
+			}
+			key.signKey = private
+			key.verifyKey = &private.PublicKey
+		} else {
+			data, err := os.ReadFile(kc.PublicKeyFile)
+			if err != nil {
+				return nil, err
+			}
+			public, err := jwt.ParseRSAPublicKeyFromPEM(data)
+			if err != nil {
+				return nil, err
+			}
+			key.verifyKey = public
+		}
+
+	case "EdDSA":
+		key.method = SigningMethodEdDSA
+		if kc.PrivateKeyFile != "" {
+			private, err := parseEd25519PrivateKey(kc.PrivateKeyFile)
+			if err != nil {
+				return nil, err
+			}
+			key.signKey = private
+			key.verifyKey = private.Public()
+		} else {
+			public, err := parseEd25519PublicKey(kc.PublicKeyFile)
+			if err != nil {
+				return nil, err
+			}
+			key.verifyKey = public
+		}
+
+	default:
+		return nil, fmt.Errorf("unsupported algorithm %q", kc.Alg)
+	}
+
+	return key, nil
+}
+
+func readPEM(path string) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("%s: no PEM block found", path)
+	}
+	return block.Bytes, nil
+}
+
+func parseEd25519PrivateKey(path string) (ed25519.PrivateKey, error) {
+	der, err := readPEM(path)
+	if err != nil {
+		return nil, err
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(der)
+	if err != nil {
+		return nil, err
+	}
+	private, ok := parsed.(ed25519.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("%s: not an Ed25519 private key", path)
+	}
+	return private, nil
+}
+
+func parseEd25519PublicKey(path string) (ed25519.PublicKey, error) {
+	der, err := readPEM(path)
+	if err != nil {
+		return nil, err
+	}
+	parsed, err := x509.ParsePKIXPublicKey(der)
+	if err != nil {
+		return nil, err
+	}
+	public, ok := parsed.(ed25519.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("%s: not an Ed25519 public key", path)
+	}
+	return public, nil
+}
+
+// signingMethodEdDSA реализует подпись Ed25519 (RFC 8037), которой нет в jwt-go v3
+type signingMethodEdDSA struct{}
+
+var SigningMethodEdDSA jwt.SigningMethod = &signingMethodEdDSA{}
+
+func init() {
+	jwt.RegisterSigningMethod(SigningMethodEdDSA.Alg(), func() jwt.SigningMethod {
+		return SigningMethodEdDSA
+	})
+}
+
+func (m *signingMethodEdDSA) Alg() string {
+	return "EdDSA"
+}
+
+func (m *signingMethodEdDSA) Verify(signingString, signature string, key interface{}) error {
+	public, ok := key.(ed25519.PublicKey)
+	if !ok {
+		return jwt.ErrInvalidKeyType
+	}
+
+	sig, err := jwt.DecodeSegment(signature)
+	if err != nil {
+		return err
+	}
+
+	if !ed25519.Verify(public, []byte(signingString), sig) {
+		return errors.New("ed25519: verification error")
+	}
+
+	return nil
+}
+
+func (m *signingMethodEdDSA) Sign(signingString string, key interface{}) (string, error) {
+	private, ok := key.(ed25519.PrivateKey)
+	if !ok {
+		return "", jwt.ErrInvalidKeyType
+	}
+
+	return jwt.EncodeSegment(ed25519.Sign(private, []byte(signingString))), nil
+}
+
+// JWK представляет открытый ключ в формате JSON Web Key (RFC 7517)
+type JWK struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Alg string `json:"alg"`
+	Use string `json:"use"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+}
+
+// JWKS возвращает открытые ключи проверки в формате JWK Set.
+// Симметричные ключи HS256 не публикуются.
+func JWKS() map[string][]JWK {
+	keys := make([]JWK, 0, len(keyring.keys))
+
+	for _, key := range keyring.keys {
+		switch public := key.verifyKey.(type) {
+		case *rsa.PublicKey:
+			keys = append(keys, JWK{
+				Kty: "RSA",
+				Kid: key.kid,
+				Alg: key.method.Alg(),
+				Use: "sig",
+				N:   base64.RawURLEncoding.EncodeToString(public.N.Bytes()),
+				E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(public.E)).Bytes()),
+			})
+		case ed25519.PublicKey:
+			keys = append(keys, JWK{
+				Kty: "OKP",
+				Kid: key.kid,
+				Alg: key.method.Alg(),
+				Use: "sig",
+				Crv: "Ed25519",
+				X:   base64.RawURLEncoding.EncodeToString(public),
+			})
+		}
+	}
+
+	sort.Slice(keys, func(i, j int) bool { return keys[i].Kid < keys[j].Kid })
+
+	return map[string][]JWK{"keys": keys}
+}
@@ -0,0 +1,355 @@
+package utils
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dgrijalva/jwt-go"
+)
+
+// testKeys PEM-файлы ключей RS256 и EdDSA во временном каталоге теста
+type testKeys struct {
+	rsa            *rsa.PrivateKey
+	rsaPrivate     string
+	rsaPublic      string
+	ed25519        ed25519.PrivateKey
+	ed25519Private string
+	ed25519Public  string
+}
+
+func newTestKeys(t *testing.T) *testKeys {
+	t.Helper()
+
+	dir := t.TempDir()
+	writePEM := func(name, blockType string, der []byte) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), 0600); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+		return path
+	}
+	marshalPublic := func(public interface{}) []byte {
+		der, err := x509.MarshalPKIXPublicKey(public)
+		if err != nil {
+			t.Fatalf("failed to marshal public key: %v", err)
+		}
+		return der
+	}
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate RSA key: %v", err)
+	}
+
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate Ed25519 key: %v", err)
+	}
+	edDER, err := x509.MarshalPKCS8PrivateKey(edKey)
+	if err != nil {
+		t.Fatalf("failed to marshal Ed25519 key: %v", err)
+	}
+
+	return &testKeys{
+		rsa:            rsaKey,
+		rsaPrivate:     writePEM("rsa.pem", "RSA PRIVATE KEY", x509.MarshalPKCS1PrivateKey(rsaKey)),
+		rsaPublic:      writePEM("rsa.pub.pem", "PUBLIC KEY", marshalPublic(&rsaKey.PublicKey)),
+		ed25519:        edKey,
+		ed25519Private: writePEM("ed25519.pem", "PRIVATE KEY", edDER),
+		ed25519Public:  writePEM("ed25519.pub.pem", "PUBLIC KEY", marshalPublic(edKey.Public())),
+	}
+}
+
+// useKeyring подменяет набор ключей на время теста
+func useKeyring(t *testing.T, k *Keyring) {
+	t.Helper()
+
+	previous := keyring
+	SetKeyring(k)
+	t.Cleanup(func() { SetKeyring(previous) })
+}
+
+func mustKeyring(t *testing.T, cfg KeyringConfig) *Keyring {
+	t.Helper()
+
+	k, err := NewKeyring(cfg)
+	if err != nil {
+		t.Fatalf("NewKeyring: %v", err)
+	}
+	return k
+}
+
+// tokenHeader возвращает заголовок токена без проверки подписи
+func tokenHeader(t *testing.T, tokenStr string) map[string]interface{} {
+	t.Helper()
+
+	token, _, err := new(jwt.Parser).ParseUnverified(tokenStr, &Claims{})
+	if err != nil {
+		t.Fatalf("failed to parse token: %v", err)
+	}
+	return token.Header
+}
+
+// tamperSignature меняет первый символ подписи токена
+func tamperSignature(token string) string {
+	i := strings.LastIndexByte(token, '.') + 1
+	replacement := byte('A')
+	if token[i] == 'A' {
+		replacement = 'B'
+	}
+	return token[:i] + string(replacement) + token[i+1:]
+}
+
+func TestNewKeyring(t *testing.T) {
+	keys := newTestKeys(t)
+
+	secretFile := filepath.Join(t.TempDir(), "secret")
+	if err := os.WriteFile(secretFile, []byte("file-secret"), 0600); err != nil {
+		t.Fatalf("failed to write secret: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		cfg     KeyringConfig
+		wantErr string
+	}{
+		{"HS256 secret", KeyringConfig{ActiveKid: "a", Keys: []KeyConfig{{Kid: "a", Alg: "HS256", Secret: "s"}}}, ""},
+		{"HS256 secret file", KeyringConfig{ActiveKid: "a", Keys: []KeyConfig{{Kid: "a", Alg: "HS256", SecretFile: secretFile}}}, ""},
+		{"RS256", KeyringConfig{ActiveKid: "a", Keys: []KeyConfig{{Kid: "a", Alg: "RS256", PrivateKeyFile: keys.rsaPrivate}}}, ""},
+		{"EdDSA", KeyringConfig{ActiveKid: "a", Keys: []KeyConfig{{Kid: "a", Alg: "EdDSA", PrivateKeyFile: keys.ed25519Private}}}, ""},
+		{"verification-only keys next to the active one", KeyringConfig{ActiveKid: "a", Keys: []KeyConfig{
+			{Kid: "a", Alg: "EdDSA", PrivateKeyFile: keys.ed25519Private},
+			{Kid: "b", Alg: "RS256", PublicKeyFile: keys.rsaPublic},
+			{Kid: "c", Alg: "EdDSA", PublicKeyFile: keys.ed25519Public},
+		}}, ""},
+		{"key without kid", KeyringConfig{ActiveKid: "a", Keys: []KeyConfig{{Alg: "HS256", Secret: "s"}}}, "key without kid"},
+		{"duplicate kid", KeyringConfig{ActiveKid: "a", Keys: []KeyConfig{
+			{Kid: "a", Alg: "HS256", Secret: "s"},
+			{Kid: "a", Alg: "HS256", Secret: "t"},
+		}}, `duplicate kid "a"`},
+		{"unsupported algorithm", KeyringConfig{ActiveKid: "a", Keys: []KeyConfig{{Kid: "a", Alg: "none"}}}, "unsupported algorithm"},
+		{"empty secret", KeyringConfig{ActiveKid: "a", Keys: []KeyConfig{{Kid: "a", Alg: "HS256"}}}, "empty HMAC secret"},
+		{"missing key file", KeyringConfig{ActiveKid: "a", Keys: []KeyConfig{{Kid: "a", Alg: "RS256", PrivateKeyFile: filepath.Join(t.TempDir(), "missing.pem")}}}, "no such file"},
+		{"RSA key for EdDSA", KeyringConfig{ActiveKid: "a", Keys: []KeyConfig{{Kid: "a", Alg: "EdDSA", PublicKeyFile: keys.rsaPublic}}}, "not an Ed25519 public key"},
+		{"active key not configured", KeyringConfig{ActiveKid: "b", Keys: []KeyConfig{{Kid: "a", Alg: "HS256", Secret: "s"}}}, `active key "b" is not configured`},
+		{"active key without private key", KeyringConfig{ActiveKid: "a", Keys: []KeyConfig{{Kid: "a", Alg: "RS256", PublicKeyFile: keys.rsaPublic}}}, `active key "a" has no private key`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewKeyring(tt.cfg)
+			switch {
+			case tt.wantErr == "" && err != nil:
+				t.Errorf("NewKeyring: %v", err)
+			case tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)):
+				t.Errorf("NewKeyring error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLoadKeyringFile(t *testing.T) {
+	keys := newTestKeys(t)
+	dir := t.TempDir()
+
+	tests := []struct {
+		name    string
+		config  string
+		wantErr string
+	}{
+		{"valid config", `{"active_kid": "k1", "keys": [{"kid": "k1", "alg": "EdDSA", "private_key_file": "` + keys.ed25519Private + `"}]}`, ""},
+		{"malformed JSON", `{"active_kid": `, "failed to parse keyring config"},
+		{"invalid key", `{"active_kid": "k1", "keys": [{"kid": "k1", "alg": "HS512", "secret": "s"}]}`, "unsupported algorithm"},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(dir, strings.Repeat("k", i+1)+".json")
+			if err := os.WriteFile(path, []byte(tt.config), 0600); err != nil {
+				t.Fatalf("failed to write config: %v", err)
+			}
+
+			_, err := LoadKeyringFile(path)
+			switch {
+			case tt.wantErr == "" && err != nil:
+				t.Errorf("LoadKeyringFile: %v", err)
+			case tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)):
+				t.Errorf("LoadKeyringFile error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+
+	if _, err := LoadKeyringFile(filepath.Join(dir, "missing.json")); err == nil {
+		t.Error("LoadKeyringFile of a missing file succeeded")
+	}
+}
+
+func TestKeyringSignAndValidate(t *testing.T) {
+	keys := newTestKeys(t)
+
+	tests := []struct {
+		name string
+		key  KeyConfig
+	}{
+		{"HS256", KeyConfig{Kid: "hmac", Alg: "HS256", Secret: "secret"}},
+		{"RS256", KeyConfig{Kid: "rsa", Alg: "RS256", PrivateKeyFile: keys.rsaPrivate}},
+		{"EdDSA", KeyConfig{Kid: "ed", Alg: "EdDSA", PrivateKeyFile: keys.ed25519Private}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			useKeyring(t, mustKeyring(t, KeyringConfig{ActiveKid: tt.key.Kid, Keys: []KeyConfig{tt.key}}))
+
+			token, err := GenerateToken(42, "session")
+			if err != nil {
+				t.Fatalf("GenerateToken: %v", err)
+			}
+
+			header := tokenHeader(t, token)
+			if header["kid"] != tt.key.Kid || header["alg"] != tt.key.Alg {
+				t.Errorf("header = %v, want kid %q and alg %q", header, tt.key.Kid, tt.key.Alg)
+			}
+
+			claims, err := ValidateToken(token)
+			if err != nil {
+				t.Fatalf("ValidateToken: %v", err)
+			}
+			if claims.UserId != 42 || claims.SessionId != "session" {
+				t.Errorf("claims = %+v", claims)
+			}
+
+			if _, err := ValidateToken(tamperSignature(token)); err == nil {
+				t.Error("ValidateToken accepted a token with a tampered signature")
+			}
+		})
+	}
+}
+
+func TestKeyringRotation(t *testing.T) {
+	keys := newTestKeys(t)
+
+	// До ротации токены подписывает ключ EdDSA
+	useKeyring(t, mustKeyring(t, KeyringConfig{ActiveKid: "old", Keys: []KeyConfig{
+		{Kid: "old", Alg: "EdDSA", PrivateKeyFile: keys.ed25519Private},
+	}}))
+	oldToken, err := GenerateToken(1, "old-session")
+	if err != nil {
+		t.Fatalf("GenerateToken: %v", err)
+	}
+
+	// После ротации новым ключом RS256 старый ключ остается только для проверки
+	rotated := mustKeyring(t, KeyringConfig{ActiveKid: "new", Keys: []KeyConfig{
+		{Kid: "new", Alg: "RS256", PrivateKeyFile: keys.rsaPrivate},
+		{Kid: "old", Alg: "EdDSA", PublicKeyFile: keys.ed25519Public},
+	}})
+	SetKeyring(rotated)
+	newToken, err := GenerateToken(1, "new-session")
+	if err != nil {
+		t.Fatalf("GenerateToken: %v", err)
+	}
+	if kid := tokenHeader(t, newToken)["kid"]; kid != "new" {
+		t.Errorf("new token kid = %v, want new", kid)
+	}
+
+	// Когда старые токены истекли, старый ключ удаляется из набора
+	retired := mustKeyring(t, KeyringConfig{ActiveKid: "new", Keys: []KeyConfig{
+		{Kid: "new", Alg: "RS256", PrivateKeyFile: keys.rsaPrivate},
+	}})
+
+	// Токен с kid ключа HS256, подписанный тем же ключом, но другим алгоритмом, отклоняется
+	hmacToken := jwt.NewWithClaims(jwt.SigningMethodHS256, &Claims{UserId: 1, SessionId: "s"})
+	hmacToken.Header["kid"] = "new"
+	forged, err := hmacToken.SignedString(x509.MarshalPKCS1PublicKey(&keys.rsa.PublicKey))
+	if err != nil {
+		t.Fatalf("failed to sign forged token: %v", err)
+	}
+
+	// Токен без kid проверяется ключом по умолчанию
+	noKidToken, err := jwt.NewWithClaims(jwt.SigningMethodHS256, &Claims{UserId: 1, SessionId: "s"}).SignedString([]byte("secure-key"))
+	if err != nil {
+		t.Fatalf("failed to sign token without kid: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		keyring *Keyring
+		token   string
+		wantErr string
+	}{
+		{"old token after rotation", rotated, oldToken, ""},
+		{"new token after rotation", rotated, newToken, ""},
+		{"old token after the old key is removed", retired, oldToken, `unknown signing key "old"`},
+		{"new token after the old key is removed", retired, newToken, ""},
+		{"algorithm of the token differs from the key", rotated, forged, "unexpected signing method HS256"},
+		{"token without kid and the default key", DevelopmentKeyring(), noKidToken, ""},
+		{"token without kid and no default key", rotated, noKidToken, `unknown signing key "default"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetKeyring(tt.keyring)
+
+			_, err := ValidateToken(tt.token)
+			switch {
+			case tt.wantErr == "" && err != nil:
+				t.Errorf("ValidateToken: %v", err)
+			case tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)):
+				t.Errorf("ValidateToken error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestJWKS(t *testing.T) {
+	keys := newTestKeys(t)
+
+	useKeyring(t, mustKeyring(t, KeyringConfig{ActiveKid: "hmac", Keys: []KeyConfig{
+		{Kid: "hmac", Alg: "HS256", Secret: "secret"},
+		{Kid: "rsa", Alg: "RS256", PublicKeyFile: keys.rsaPublic},
+		{Kid: "ed", Alg: "EdDSA", PrivateKeyFile: keys.ed25519Private},
+	}}))
+
+	// Ключ HS256 не публикуется, остальные отсортированы по kid
+	want := []JWK{
+		{
+			Kty: "OKP",
+			Kid: "ed",
+			Alg: "EdDSA",
+			Use: "sig",
+			Crv: "Ed25519",
+			X:   base64.RawURLEncoding.EncodeToString(keys.ed25519.Public().(ed25519.PublicKey)),
+		},
+		{
+			Kty: "RSA",
+			Kid: "rsa",
+			Alg: "RS256",
+			Use: "sig",
+			N:   base64.RawURLEncoding.EncodeToString(keys.rsa.N.Bytes()),
+			E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(keys.rsa.E)).Bytes()),
+		},
+	}
+
+	got := JWKS()["keys"]
+	if len(got) != len(want) {
+		t.Fatalf("JWKS returned %d keys, want %d: %+v", len(got), len(want), got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("key %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+
+	// Значение e для стандартного показателя 65537 — "AQAB"
+	if got[1].E != "AQAB" {
+		t.Errorf("RSA exponent = %q, want AQAB", got[1].E)
+	}
+}
@@ -0,0 +1,75 @@
+package utils
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"unicode/utf8"
+)
+
+// PasswordPolicy задает требования к паролям при регистрации
+type PasswordPolicy struct {
+	MinLength int
+	MaxLength int
+	banned    map[string]struct{}
+}
+
+// NewPasswordPolicy создает политику паролей без списка запрещенных паролей
+func NewPasswordPolicy(minLength, maxLength int) *PasswordPolicy {
+	return &PasswordPolicy{
+		MinLength: minLength,
+		MaxLength: maxLength,
+		banned:    make(map[string]struct{}),
+	}
+}
+
+// LoadBannedList загружает запрещенные пароли из файла (по одному в строке, # — комментарий)
+func (p *PasswordPolicy) LoadBannedList(path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open banned passwords list: %v", err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		p.banned[strings.ToLower(line)] = struct{}{}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read banned passwords list: %v", err)
+	}
+
+	return nil
+}
+
+// Validate проверяет пароль и его подтверждение
+func (p *PasswordPolicy) Validate(username, password, confirmPassword string) error {
+	if password != confirmPassword {
+		return errors.New("passwords must match")
+	}
+
+	length := utf8.RuneCountInString(password)
+	if length < p.MinLength {
+		return fmt.Errorf("password must be at least %d characters long", p.MinLength)
+	}
+	if p.MaxLength > 0 && length > p.MaxLength {
+		return fmt.Errorf("password must be at most %d characters long", p.MaxLength)
+	}
+
+	lowered := strings.ToLower(password)
+	if lowered == strings.ToLower(username) {
+		return errors.New("password must not match the username")
+	}
+	if _, banned := p.banned[lowered]; banned {
+		return errors.New("password is too common")
+	}
+
+	return nil
+}
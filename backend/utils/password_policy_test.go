@@ -0,0 +1,68 @@
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestPasswordPolicyValidate(t *testing.T) {
+	list := filepath.Join(t.TempDir(), "banned.txt")
+	if err := os.WriteFile(list, []byte("# частые пароли\nPassword123\n\n  qwertyuiop  \n"), 0o600); err != nil {
+		t.Fatalf("failed to write banned list: %v", err)
+	}
+
+	policy := NewPasswordPolicy(8, 16)
+	if err := policy.LoadBannedList(list); err != nil {
+		t.Fatalf("LoadBannedList: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		username string
+		password string
+		confirm  string
+		wantErr  bool
+	}{
+		{"valid", "alice", "s3cret-phrase", "s3cret-phrase", false},
+		{"minimum length", "alice", "12345678", "12345678", false},
+		{"maximum length", "alice", "1234567890123456", "1234567890123456", false},
+		{"length counted in runes", "alice", "пароль12", "пароль12", false},
+		{"confirmation mismatch", "alice", "s3cret-phrase", "s3cret-phrasE", true},
+		{"too short", "alice", "1234567", "1234567", true},
+		{"too long", "alice", "12345678901234567", "12345678901234567", true},
+		{"same as username", "LongUsername", "longusername", "longusername", true},
+		{"banned", "alice", "password123", "password123", true},
+		{"banned with surrounding spaces in list", "alice", "QwertyUiop", "QwertyUiop", true},
+		{"comment is not banned", "alice", "# частые пароли", "# частые пароли", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := policy.Validate(tt.username, tt.password, tt.confirm)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPasswordPolicyWithoutMaxLength(t *testing.T) {
+	policy := NewPasswordPolicy(8, 0)
+
+	long := make([]byte, 1000)
+	for i := range long {
+		long[i] = 'a' + byte(i%26)
+	}
+
+	if err := policy.Validate("alice", string(long), string(long)); err != nil {
+		t.Errorf("Validate without max length: %v", err)
+	}
+}
+
+func TestLoadBannedListMissingFile(t *testing.T) {
+	policy := NewPasswordPolicy(8, 16)
+	if err := policy.LoadBannedList(filepath.Join(t.TempDir(), "missing.txt")); err == nil {
+		t.Error("LoadBannedList of a missing file returned no error")
+	}
+}
@@ -0,0 +1,168 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// Секрет из RFC 6238 ("12345678901234567890") в base32
+const rfcSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestTOTPCode(t *testing.T) {
+	key, err := base32NoPadding.DecodeString(rfcSecret)
+	if err != nil {
+		t.Fatalf("failed to decode secret: %v", err)
+	}
+
+	// Контрольные значения RFC 6238 для SHA1, усеченные до шести цифр
+	tests := []struct {
+		unix int64
+		code string
+	}{
+		{59, "287082"},
+		{1111111109, "081804"},
+		{1111111111, "050471"},
+		{1234567890, "005924"},
+		{2000000000, "279037"},
+	}
+
+	for _, tt := range tests {
+		if got := totpCode(key, tt.unix/totpPeriod); got != tt.code {
+			t.Errorf("totpCode at %d = %s, want %s", tt.unix, got, tt.code)
+		}
+	}
+}
+
+func TestValidateTOTP(t *testing.T) {
+	now := time.Unix(1234567890, 0)
+	step := now.Unix() / totpPeriod
+
+	key, err := base32NoPadding.DecodeString(rfcSecret)
+	if err != nil {
+		t.Fatalf("failed to decode secret: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		secret   string
+		code     string
+		wantStep int64
+		wantOK   bool
+	}{
+		{"current step", rfcSecret, totpCode(key, step), step, true},
+		{"previous step within skew", rfcSecret, totpCode(key, step-1), step - 1, true},
+		{"next step within skew", rfcSecret, totpCode(key, step+1), step + 1, true},
+		{"two steps behind", rfcSecret, totpCode(key, step-2), 0, false},
+		{"two steps ahead", rfcSecret, totpCode(key, step+2), 0, false},
+		{"surrounding spaces", rfcSecret, " " + totpCode(key, step) + " ", step, true},
+		{"lowercase secret", strings.ToLower(rfcSecret), totpCode(key, step), step, true},
+		{"short code", rfcSecret, "00592", 0, false},
+		{"long code", rfcSecret, "0059240", 0, false},
+		{"wrong code", rfcSecret, "000000", 0, false},
+		{"invalid secret", "not base32!", "005924", 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotStep, gotOK := ValidateTOTP(tt.secret, tt.code, now)
+			if gotOK != tt.wantOK || gotStep != tt.wantStep {
+				t.Errorf("ValidateTOTP = (%d, %v), want (%d, %v)", gotStep, gotOK, tt.wantStep, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestIsTOTPCode(t *testing.T) {
+	tests := []struct {
+		code string
+		want bool
+	}{
+		{"123456", true},
+		{" 123456 ", true},
+		{"12345", false},
+		{"1234567", false},
+		{"12345a", false},
+		{"abcde-fghij", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		if got := IsTOTPCode(tt.code); got != tt.want {
+			t.Errorf("IsTOTPCode(%q) = %v, want %v", tt.code, got, tt.want)
+		}
+	}
+}
+
+func TestNormalizeRecoveryCode(t *testing.T) {
+	tests := []struct {
+		code string
+		want string
+	}{
+		{"abcde-fghij", "abcdefghij"},
+		{"ABCDE-FGHIJ", "abcdefghij"},
+		{"  abcde fghij ", "abcdefghij"},
+		{"abcdefghij", "abcdefghij"},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		if got := NormalizeRecoveryCode(tt.code); got != tt.want {
+			t.Errorf("NormalizeRecoveryCode(%q) = %q, want %q", tt.code, got, tt.want)
+		}
+	}
+}
+
+func TestIsRecoveryCode(t *testing.T) {
+	tests := []struct {
+		normalized string
+		want       bool
+	}{
+		{"abcdefghij", true},
+		{"a2b3c4d5e6", true},
+		{"abcdefghi", false},
+		{"abcdefghijk", false},
+		{"abcdefghi1", false}, // 1 нет в алфавите base32
+		{"abcdefghi8", false},
+		{"ABCDEFGHIJ", false}, // код проверяется после нормализации
+		{"123456", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		if got := IsRecoveryCode(tt.normalized); got != tt.want {
+			t.Errorf("IsRecoveryCode(%q) = %v, want %v", tt.normalized, got, tt.want)
+		}
+	}
+}
+
+func TestGenerateRecoveryCodes(t *testing.T) {
+	codes, err := GenerateRecoveryCodes(10)
+	if err != nil {
+		t.Fatalf("GenerateRecoveryCodes: %v", err)
+	}
+
+	if len(codes) != 10 {
+		t.Fatalf("got %d codes, want 10", len(codes))
+	}
+
+	seen := make(map[string]bool)
+	for _, code := range codes {
+		if len(code) != recoveryCodeLength+1 || code[5] != '-' {
+			t.Errorf("code %q is not in the abcde-fghij format", code)
+		}
+
+		normalized := NormalizeRecoveryCode(code)
+		if !IsRecoveryCode(normalized) {
+			t.Errorf("normalized code %q is not accepted by IsRecoveryCode", normalized)
+		}
+		if IsTOTPCode(code) {
+			t.Errorf("code %q looks like a TOTP code", code)
+		}
+
+		if seen[normalized] {
+			t.Errorf("duplicate code %q", code)
+		}
+		seen[normalized] = true
+	}
+}
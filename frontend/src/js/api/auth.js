@@ -1,6 +1,16 @@
-import { LoginRequest, RegisterRequest }  from '../../proto/user_service_pb';
+import { LoginRequest, RegisterRequest, RefreshTokenRequest }  from '../../proto/user_service_pb';
 import { userClient } from './client';
 
+// Access-токен обновляется за минуту до истечения
+const REFRESH_BEFORE_EXPIRY_MS = 60 * 1000;
+// Пока одна вкладка обновляет токен, остальные ждут: повторное использование
+// refresh-токена сервер считает кражей и отзывает сессию
+const REFRESH_LOCK_MS = 10 * 1000;
+const UNAUTHENTICATED = 16;
+
+let refreshTimer = null;
+let refreshStarted = false;
+
 export function login(username, password, callback) {
 
     const request = new LoginRequest();
@@ -12,7 +22,7 @@ export function login(username, password, callback) {
             console.error('Login error:', err);
             callback(err, null);
         } else {
-            callback(null, response.getToken());
+            callback(null, response.getToken(), response.getRefreshToken());
         }
     });
 }
@@ -29,8 +39,96 @@ export function register(username, password, confirmPassword, callback) {
             console.error("Registration error:", err);
             callback(err, null);
         } else {
-            callback(null, response.getToken());
+            callback(null, response.getToken(), response.getRefreshToken());
         }
     })
 }
 
+export function saveTokens(token, refreshToken) {
+    localStorage.setItem('token', token);
+    localStorage.setItem('refreshToken', refreshToken);
+}
+
+export function clearTokens() {
+    clearTimeout(refreshTimer);
+    localStorage.removeItem('token');
+    localStorage.removeItem('refreshToken');
+    localStorage.removeItem('tokenRefreshStartedAt');
+}
+
+// Обменивает refresh-токен на новую пару токенов
+export function refreshAccessToken(callback) {
+    const refreshToken = localStorage.getItem('refreshToken');
+    if (!refreshToken) {
+        callback(new Error("Unauthorized: No refresh token found"));
+        return;
+    }
+
+    localStorage.setItem('tokenRefreshStartedAt', Date.now().toString());
+
+    const request = new RefreshTokenRequest();
+    request.setRefreshToken(refreshToken);
+
+    userClient.refreshToken(request, {}, (err, response) => {
+        localStorage.removeItem('tokenRefreshStartedAt');
+        if (err) {
+            console.error('Token refresh error:', err);
+            callback(err);
+            return;
+        }
+
+        saveTokens(response.getToken(), response.getRefreshToken());
+        callback(null);
+    });
+}
+
+// Планирует обновление access-токена до его истечения. Если сессия отозвана
+// или refresh-токен истек, возвращает пользователя на страницу входа
+export function startTokenRefresh() {
+    clearTimeout(refreshTimer);
+    refreshStarted = true;
+
+    const token = localStorage.getItem('token');
+    if (!token || !localStorage.getItem('refreshToken')) {
+        return;
+    }
+
+    const delay = tokenExpiresAt(token) - REFRESH_BEFORE_EXPIRY_MS - Date.now();
+    refreshTimer = setTimeout(() => {
+        // Токен уже обновляет другая вкладка: дожидаемся нового токена от нее
+        const startedAt = parseInt(localStorage.getItem('tokenRefreshStartedAt') || '0');
+        if (Date.now() - startedAt < REFRESH_LOCK_MS) {
+            refreshTimer = setTimeout(startTokenRefresh, REFRESH_LOCK_MS);
+            return;
+        }
+
+        refreshAccessToken((err) => {
+            if (err && err.code === UNAUTHENTICATED) {
+                clearTokens();
+                window.location.href = "index.html";
+                return;
+            }
+            if (err) {
+                refreshTimer = setTimeout(startTokenRefresh, REFRESH_BEFORE_EXPIRY_MS / 2);
+                return;
+            }
+            startTokenRefresh();
+        });
+    }, Math.max(delay, 0));
+}
+
+// Другая вкладка обновила токен: переносим обновление на срок нового токена
+window.addEventListener('storage', (event) => {
+    if (refreshStarted && event.key === 'token' && event.newValue) {
+        startTokenRefresh();
+    }
+});
+
+function tokenExpiresAt(token) {
+    try {
+        const payload = token.split('.')[1].replace(/-/g, '+').replace(/_/g, '/');
+        return JSON.parse(atob(payload)).exp * 1000;
+    } catch (e) {
+        return 0;
+    }
+}
@@ -57,9 +57,15 @@ export function connectToChat(receiverUsername, callback) {
 }
 
 let currentHandler = null;
+let currentEventHandler = null;
 let isConnected = false;
 
-export function startChat(onMessageReceived) {
+/**
+ * Подключается к потоку чата
+ * @param {function} onMessageReceived - Обработчик новых сообщений
+ * @param {function} onEvent - Обработчик событий чата (отметок, правок, отклоненных сообщений); необязателен
+ */
+export function startChat(onMessageReceived, onEvent = null) {
     const token = localStorage.getItem("token");
     if (!token) {
         console.error("User is not authenticated");
@@ -75,6 +81,7 @@ export function startChat(onMessageReceived) {
         }
         currentHandler = onMessageReceived;
         socket.addMessageHandler(onMessageReceived);
+        setEventHandler(onEvent);
         return;
     }
 
@@ -83,6 +90,48 @@ export function startChat(onMessageReceived) {
     isConnected = true;
     currentHandler = onMessageReceived;
     socket.addMessageHandler(onMessageReceived);
+    setEventHandler(onEvent);
+}
+
+function setEventHandler(onEvent) {
+    if (currentEventHandler) {
+        socket.removeEventHandler(currentEventHandler);
+    }
+    currentEventHandler = onEvent;
+    if (onEvent) {
+        socket.addEventHandler(onEvent);
+    }
+}
+
+/**
+ * Загружает страницу истории чата через GetHistory. Поток чата не присылает
+ * старые сообщения при подключении.
+ * @param {Object} target - Чат: { username } для личного чата или { groupId } для группы
+ * @param {number} beforeId - Сообщения с ID меньше указанного; 0 — последние сообщения
+ * @param {function} callback - Функция обратного вызова (err, { messages, hasMore }); сообщения от старых к новым
+ */
+export function getHistory(target, beforeId, callback) {
+    const handler = (data) => {
+        if ((data.chatUsername || '') !== (target.username || '') ||
+            (data.groupId || 0) !== (target.groupId || 0) ||
+            (data.beforeId || 0) !== (beforeId || 0)) {
+            return;
+        }
+
+        socket.removeHistoryHandler(handler);
+        if (data.type === 'history_error') {
+            callback(new Error(data.error), null);
+            return;
+        }
+        callback(null, { messages: data.messages || [], hasMore: !!data.hasMore });
+    };
+
+    socket.addHistoryHandler(handler);
+    socket.requestHistory({
+        chatUsername: target.username,
+        groupId: target.groupId,
+        beforeId: beforeId,
+    });
 }
 
 /**
@@ -136,6 +185,7 @@ export function stopChat() {
         socket.removeSpecificMessageHandler(currentHandler);
         currentHandler = null;
     }
+    setEventHandler(null);
     isConnected = false;
     socket.close();
 }
@@ -2,7 +2,10 @@ class WebSocketClient {
     constructor() {
         this.socket = null;
         this.messageHandlers = [];
+        this.eventHandlers = []; // Для событий чата: отметок, реакций, правок и т.п.
         this.fileHandlers = new Map(); // Для обработчиков файловых сообщений
+        this.historyHandlers = []; // Для страниц истории, запрошенных через requestHistory
+        this.pendingRequests = []; // Запросы, отправленные до открытия сокета
     }
 
     connect(token) {
@@ -16,6 +19,10 @@ class WebSocketClient {
 
         this.socket.onopen = () => {
             console.log("WebSocket connected");
+
+            const pending = this.pendingRequests;
+            this.pendingRequests = [];
+            pending.forEach((request) => this.socket.send(JSON.stringify(request)));
         };
 
         this.socket.onmessage = (event) => {
@@ -43,6 +50,24 @@ class WebSocketClient {
                             console.error("Error in file handler:", e);
                         }
                     });
+                } else if (data.type === 'history' || data.type === 'history_error') {
+                    // Страница истории рисуется тем, кто ее запросил
+                    this.historyHandlers.forEach((handler) => {
+                        try {
+                            handler(data);
+                        } catch (e) {
+                            console.error("Error in history handler:", e);
+                        }
+                    });
+                } else if (data.type === 'chat_event') {
+                    // События чата не являются новыми сообщениями и не попадают в ленту
+                    this.eventHandlers.forEach((handler) => {
+                        try {
+                            handler(data);
+                        } catch (e) {
+                            console.error("Error in event handler:", e);
+                        }
+                    });
                 } else {
                     // Вызываем общие обработчики только для обычных сообщений чата
                     this.messageHandlers.forEach((handler) => {
@@ -90,6 +115,37 @@ class WebSocketClient {
         }
     }
 
+    /**
+     * Запрашивает страницу истории чата. Если сокет еще открывается, запрос
+     * отправляется сразу после подключения.
+     */
+    requestHistory(request) {
+        const message = { type: 'history_request', ...request };
+
+        if (this.socket && this.socket.readyState === WebSocket.CONNECTING) {
+            this.pendingRequests.push(message);
+            return;
+        }
+
+        if (!this.socket || this.socket.readyState !== WebSocket.OPEN) {
+            console.error("WebSocket is not connected.");
+            return;
+        }
+
+        this.socket.send(JSON.stringify(message));
+    }
+
+    addHistoryHandler(handler) {
+        this.historyHandlers.push(handler);
+    }
+
+    removeHistoryHandler(handler) {
+        const index = this.historyHandlers.indexOf(handler);
+        if (index !== -1) {
+            this.historyHandlers.splice(index, 1);
+        }
+    }
+
     addMessageHandler(handler) {
         this.messageHandlers.push(handler);
     }
@@ -104,6 +160,17 @@ class WebSocketClient {
     removeMessageHandler() {
         this.messageHandlers = [];
     }
+
+    addEventHandler(handler) {
+        this.eventHandlers.push(handler);
+    }
+
+    removeEventHandler(handler) {
+        const index = this.eventHandlers.indexOf(handler);
+        if (index !== -1) {
+            this.eventHandlers.splice(index, 1);
+        }
+    }
     
     // Для файловых обработчиков
     addFileHandler(handlerId, handler) {
@@ -120,6 +187,9 @@ class WebSocketClient {
             this.socket = null;
         }
         this.removeMessageHandler();
+        this.eventHandlers = [];
+        this.historyHandlers = [];
+        this.pendingRequests = [];
         this.fileHandlers.clear();
     }
 
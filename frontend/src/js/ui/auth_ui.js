@@ -1,4 +1,4 @@
-import { login as apiLogin, register as apiRegister } from "../api/auth";
+import { login as apiLogin, register as apiRegister, saveTokens } from "../api/auth";
 import '../../styles/style.css';
 
 class AuthManager {
@@ -46,11 +46,11 @@ class AuthManager {
             return;
         }
 
-        apiLogin(username, password, (err, token) => {
+        apiLogin(username, password, (err, token, refreshToken) => {
             if (err) {
                 alert("Ошибка входа: " + err.message);
             } else {
-                localStorage.setItem('token', token);
+                saveTokens(token, refreshToken);
                 window.location.href = "chats.html";
             }
         });
@@ -66,11 +66,11 @@ class AuthManager {
             return;
         }
 
-        apiRegister(username, password, confirmPassword, (err, token) => {
+        apiRegister(username, password, confirmPassword, (err, token, refreshToken) => {
             if (err) {
                 alert('Ошибка регистрации: ' + err.message)
             } else {
-                localStorage.setItem('token', token);
+                saveTokens(token, refreshToken);
                 window.location.href = "chats.html";
             }
         })
@@ -1,11 +1,13 @@
-import { getChats, connectToChat, startChat, chat, stopChat, createChat, sendFileMessage, deleteChat } from "../api/chat";
+import { getChats, connectToChat, startChat, chat, stopChat, createChat, sendFileMessage, deleteChat, getHistory } from "../api/chat";
 import { uploadFile, downloadFile } from "../api/file";
+import { startTokenRefresh, clearTokens } from "../api/auth";
 import { initKeyExchange, completeKeyExchange, getKeyExchangeParams, getDiffieHellmanParams } from "../api/key_exchange";
 
 let currentChat = null;
 let lastDisplayedDate = null;
 let selectedFile = null;
 let selectedUser = null;
+let historyLoadedFor = null; // Чат, история которого уже загружена в ленту
 
 function loadChats() {
     const chatList = document.getElementById('chat-list');
@@ -389,6 +391,7 @@ function connectToChatHandler(username) {
         messagesContainer.innerHTML = '';
         lastDisplayedDate = null;
     }
+    historyLoadedFor = null;
     
     // Активируем чат в боковой панели
     const chatItems = document.querySelectorAll('.chat-item');
@@ -504,6 +507,18 @@ function onSendMessage() {
  * Показать уведомление об ошибке
  * @param {string} message - Текст ошибки
  */
+/**
+ * Обработка событий чата. Сервер не закрывает поток из-за неверного сообщения,
+ * а присылает MESSAGE_REJECTED с причиной.
+ * @param {Object} event - Событие чата
+ */
+function handleChatEvent(event) {
+    if (event.event === 'MESSAGE_REJECTED') {
+        console.error(`Сообщение ${event.clientId} не отправлено: ${event.error}`);
+        showErrorToast(`Сообщение не отправлено: ${event.error}`);
+    }
+}
+
 function showErrorToast(message) {
     // Простая реализация через alert, можно заменить на более красивые уведомления
     alert(message);
@@ -665,7 +680,7 @@ function closeModal() {
 }
 
 function handleLogout() {
-    localStorage.removeItem("token");
+    clearTokens();
     window.location.href = "index.html";
 }
 
@@ -1794,8 +1809,9 @@ function startChatAfterKeyExchange(username) {
         stopChat();
     }
     
-    // Запускаем чат и регистрируем обработчик входящих сообщений
-    startChat(handleIncomingMessage);
+    // Запускаем чат и регистрируем обработчики входящих сообщений и событий
+    startChat(handleIncomingMessage, handleChatEvent);
+    loadChatHistory(username);
     
     // Обновляем URL с параметром выбранного чата
     const urlParams = new URLSearchParams(window.location.search);
@@ -1824,10 +1840,41 @@ function startChatAfterKeyExchange(username) {
     currentChat = username;
 }
 
+/**
+ * Загружает последние сообщения чата через GetHistory: поток чата не присылает их при подключении.
+ * Обмен ключами может запустить чат повторно, поэтому история загружается один раз.
+ * @param {string} username - Имя собеседника
+ */
+function loadChatHistory(username) {
+    if (historyLoadedFor === username) {
+        return;
+    }
+    historyLoadedFor = username;
+
+    getHistory({ username }, 0, (err, page) => {
+        if (err) {
+            console.error(`Ошибка загрузки истории чата с ${username}:`, err);
+            showErrorToast('Не удалось загрузить историю сообщений');
+            historyLoadedFor = null;
+            return;
+        }
+
+        // Пока история загружалась, пользователь мог открыть другой чат
+        if (selectedUser !== username) {
+            return;
+        }
+
+        page.messages.forEach(handleIncomingMessage);
+    });
+}
+
 /**
  * Экспортируемая функция инициализации интерфейса чатов
  */
 export function setupChatsUI() {
+    // Access-токен короткий: обновляем его через RefreshToken, пока открыта страница
+    startTokenRefresh();
+
     // Инициализация интерфейса
     loadChats();
     
@@ -257,5 +257,66 @@ proto.messenger.UserServicePromiseClient.prototype.logout =
 };
 
 
+/**
+ * @const
+ * @type {!grpc.web.MethodDescriptor<
+ *   !proto.messenger.RefreshTokenRequest,
+ *   !proto.messenger.RefreshTokenResponse>}
+ */
+const methodDescriptor_UserService_RefreshToken = new grpc.web.MethodDescriptor(
+  '/messenger.UserService/RefreshToken',
+  grpc.web.MethodType.UNARY,
+  proto.messenger.RefreshTokenRequest,
+  proto.messenger.RefreshTokenResponse,
+  /**
+   * @param {!proto.messenger.RefreshTokenRequest} request
+   * @return {!Uint8Array}
+   */
+  function(request) {
+    return request.serializeBinary();
+  },
+  proto.messenger.RefreshTokenResponse.deserializeBinary
+);
+
+
+/**
+ * @param {!proto.messenger.RefreshTokenRequest} request The
+ *     request proto
+ * @param {?Object<string, string>} metadata User defined
+ *     call metadata
+ * @param {function(?grpc.web.RpcError, ?proto.messenger.RefreshTokenResponse)}
+ *     callback The callback function(error, response)
+ * @return {!grpc.web.ClientReadableStream<!proto.messenger.RefreshTokenResponse>|undefined}
+ *     The XHR Node Readable Stream
+ */
+proto.messenger.UserServiceClient.prototype.refreshToken =
+    function(request, metadata, callback) {
+  return this.client_.rpcCall(this.hostname_ +
+      '/messenger.UserService/RefreshToken',
+      request,
+      metadata || {},
+      methodDescriptor_UserService_RefreshToken,
+      callback);
+};
+
+
+/**
+ * @param {!proto.messenger.RefreshTokenRequest} request The
+ *     request proto
+ * @param {?Object<string, string>=} metadata User defined
+ *     call metadata
+ * @return {!Promise<!proto.messenger.RefreshTokenResponse>}
+ *     Promise that resolves to the response
+ */
+proto.messenger.UserServicePromiseClient.prototype.refreshToken =
+    function(request, metadata) {
+  return this.client_.unaryCall(this.hostname_ +
+      '/messenger.UserService/RefreshToken',
+      request,
+      metadata || {},
+      methodDescriptor_UserService_RefreshToken);
+};
+
+
 module.exports = proto.messenger;
 